@@ -181,6 +181,94 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 			return tag
 		}),
 	)
+	g.GenerateModelAs(
+		"post_revision",
+		"PostRevisionM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("postID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_post_revision_postID_revision,priority:1")
+			return tag
+		}),
+		gen.FieldGORMTag("revision", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_post_revision_postID_revision,priority:2")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"comment",
+		"CommentM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("commentID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_comment_commentID")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"tag",
+		"TagM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("name", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_tag_name")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"category",
+		"CategoryM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("name", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_category_name")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"post_tag",
+		"PostTagM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("postID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_post_tag_postID_tagID,priority:1")
+			return tag
+		}),
+		gen.FieldGORMTag("tagID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_post_tag_postID_tagID,priority:2")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"post_like",
+		"PostLikeM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("postID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_post_like_postID_userID,priority:1")
+			return tag
+		}),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_post_like_postID_userID,priority:2")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"bookmark",
+		"BookmarkM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_bookmark_userID_postID,priority:1")
+			return tag
+		}),
+		gen.FieldGORMTag("postID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_bookmark_userID_postID,priority:2")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"outbox",
+		"OutboxM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("eventID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_outbox_eventID")
+			return tag
+		}),
+	)
 	g.GenerateModelAs(
 		"casbin_rule",
 		"CasbinRuleM",
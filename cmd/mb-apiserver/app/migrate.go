@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wshadm/miniblog/cmd/mb-apiserver/app/options"
+	"github.com/wshadm/miniblog/internal/apiserver/migration"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// newMigrateCommand 创建 migrate 子命令，用于管理数据库表结构的版本.
+func newMigrateCommand(opts *options.ServerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage database schema migrations",
+		Long:  "Apply, revert and inspect the versioned SQL migrations embedded in mb-apiserver.",
+	}
+
+	cmd.AddCommand(
+		newMigrateSubCommand(opts, "up", "Apply all pending migrations", cobra.NoArgs,
+			func(ctx context.Context, cmd *cobra.Command, m *migration.Migrator, _ []string) error {
+				return m.Up(ctx)
+			}),
+		newMigrateSubCommand(opts, "down", "Revert the most recently applied migration", cobra.NoArgs,
+			func(ctx context.Context, cmd *cobra.Command, m *migration.Migrator, _ []string) error {
+				return m.Down(ctx)
+			}),
+		newMigrateSubCommand(opts, "to <version>", "Migrate up or down to the given version, 0 reverts all migrations", cobra.ExactArgs(1),
+			func(ctx context.Context, cmd *cobra.Command, m *migration.Migrator, args []string) error {
+				version, err := strconv.ParseUint(args[0], 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid version %q: %w", args[0], err)
+				}
+				return m.To(ctx, uint(version))
+			}),
+		newMigrateSubCommand(opts, "status", "Show the status of all migrations", cobra.NoArgs, printMigrationStatus),
+	)
+	return cmd
+}
+
+// newMigrateSubCommand 创建 migrate 下的子命令，run 在连接数据库并创建 Migrator 之后执行.
+func newMigrateSubCommand(opts *options.ServerOptions, use, short string, args cobra.PositionalArgs,
+	run func(ctx context.Context, cmd *cobra.Command, m *migration.Migrator, args []string) error) *cobra.Command {
+	return &cobra.Command{
+		Use:          use,
+		Short:        short,
+		Args:         args,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := viper.Unmarshal(&opts); err != nil {
+				return err
+			}
+			if errs := opts.DBOptions.Validate(); len(errs) != 0 {
+				return utilerrors.NewAggregate(errs)
+			}
+
+			db, err := opts.DBOptions.NewDB()
+			if err != nil {
+				return err
+			}
+			m, err := migration.New(db, opts.DBOptions.Driver)
+			if err != nil {
+				return err
+			}
+			return run(cmd.Context(), cmd, m, args)
+		},
+	}
+}
+
+// printMigrationStatus 以表格形式输出所有迁移的执行状态.
+func printMigrationStatus(ctx context.Context, cmd *cobra.Command, m *migration.Migrator, _ []string) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, st := range statuses {
+		status, appliedAt := "pending", "-"
+		if st.Applied {
+			status, appliedAt = "applied", st.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", st.Version, st.Name, status, appliedAt)
+	}
+	return w.Flush()
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/wshadm/miniblog/internal/apiserver"
 	"github.com/wshadm/miniblog/pkg/options"
+	"golang.org/x/crypto/bcrypt"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -18,10 +20,24 @@ var availableServerModes = sets.New(
 	apiserver.GRPCGatewayServerMode,
 )
 
+// 定义支持的存储类型集合
+var availableStores = sets.New(
+	apiserver.DBStore,
+	apiserver.MemoryStore,
+)
+
+// 定义支持的浏览次数缓冲类型集合
+var availableViewCountBuffers = sets.New(
+	apiserver.MemoryViewCountBuffer,
+	apiserver.RedisViewCountBuffer,
+)
+
 // ServerOptions 包含服务器配置选项
 type ServerOptions struct {
 	//ServerMode 定义服务器模式：gRPC、gin、HTTP、HTTP Reverse Proxy。
 	ServerMode string `json:"server-mode" mapstructure:"server-mode"`
+	// Store 定义存储类型：db 或 memory
+	Store string `json:"store" mapstructure:"store"`
 	//JWTKey 定义JWT秘钥
 	JWTKey string `json:"jwt-key" mapstructure:"jwt-key"`
 	//Expiration定义JWT Token的过期时间
@@ -30,19 +46,79 @@ type ServerOptions struct {
 	GRPCOptions *options.GRPCOptions `json:"grpc" mapstructure:"grpc"`
 	// HTTPOptions 包含HTTP配置选项
 	HTTPOptions *options.HTTPOptions `json:"http" mapstructure:"http"`
+	// DBOptions 包含数据库配置选项，通过 driver 选择 MySQL、PostgreSQL 或 SQLite
+	DBOptions *options.DBOptions `json:"db" mapstructure:"db"`
+	// RedisOptions 包含 Redis 配置选项
+	RedisOptions *options.RedisOptions `json:"redis" mapstructure:"redis"`
+	// EnableCache 表示是否使用 Redis 缓存用户和博客的查询结果
+	EnableCache bool `json:"enable-cache" mapstructure:"enable-cache"`
+	// CacheTTL 定义缓存的过期时间
+	CacheTTL time.Duration `json:"cache-ttl" mapstructure:"cache-ttl"`
+	// KafkaOptions 包含 Kafka 配置选项
+	KafkaOptions *options.KafkaOptions `json:"kafka" mapstructure:"kafka"`
+	// EnableOutboxRelay 表示是否将发件箱中的领域事件发布到 Kafka
+	EnableOutboxRelay bool `json:"enable-outbox-relay" mapstructure:"enable-outbox-relay"`
+	// OutboxRelayInterval 定义轮询发件箱的时间间隔
+	OutboxRelayInterval time.Duration `json:"outbox-relay-interval" mapstructure:"outbox-relay-interval"`
+	// EnableWorker 表示是否启动 Kafka 消费者处理领域事件
+	EnableWorker bool `json:"enable-worker" mapstructure:"enable-worker"`
+	// WorkerMaxRetries 定义消息处理失败后的最大重试次数
+	WorkerMaxRetries int `json:"worker-max-retries" mapstructure:"worker-max-retries"`
+	// WorkerRetryBackoff 定义消息处理失败后第一次重试前的等待时间
+	WorkerRetryBackoff time.Duration `json:"worker-retry-backoff" mapstructure:"worker-retry-backoff"`
+	// TrashRetention 定义回收站中的博客和已删除用户的保留期限，为 0 时不清理
+	TrashRetention time.Duration `json:"trash-retention" mapstructure:"trash-retention"`
+	// TrashPurgeInterval 定义清理回收站的时间间隔
+	TrashPurgeInterval time.Duration `json:"trash-purge-interval" mapstructure:"trash-purge-interval"`
+	// PublishSchedulerInterval 定义检查定时发布博客的时间间隔，为 0 时不启动定时发布
+	PublishSchedulerInterval time.Duration `json:"publish-scheduler-interval" mapstructure:"publish-scheduler-interval"`
+	// SearchIndexPath 定义博客全文索引的目录，为空时使用内存索引
+	SearchIndexPath string `json:"search-index-path" mapstructure:"search-index-path"`
+	// ViewCountBuffer 定义缓冲博客浏览次数的位置：memory 或 redis
+	ViewCountBuffer string `json:"view-count-buffer" mapstructure:"view-count-buffer"`
+	// ViewCountFlushInterval 定义将缓冲的浏览次数写入数据库的时间间隔
+	ViewCountFlushInterval time.Duration `json:"view-count-flush-interval" mapstructure:"view-count-flush-interval"`
+	// AutoMigrate 表示启动时是否自动执行数据库迁移
+	AutoMigrate bool `json:"auto-migrate" mapstructure:"auto-migrate"`
+	// BcryptCost 定义加密用户密码时 bcrypt 的计算代价
+	BcryptCost int `json:"bcrypt-cost" mapstructure:"bcrypt-cost" validate:"min=4,max=31"`
+	// PolicyReloadInterval 定义授权策略的重新加载间隔
+	PolicyReloadInterval time.Duration `json:"policy-reload-interval" mapstructure:"policy-reload-interval"`
 }
 
 // NewServerOptions 创建带有默认值的ServerOptions 实例
 func NewServerOptions() *ServerOptions {
 	opts := &ServerOptions{
 		ServerMode:  apiserver.GRPCGatewayServerMode,
+		Store:       apiserver.DBStore,
 		JWTKey:      "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
 		Expiration:  2 * time.Hour,
 		GRPCOptions: options.NewGRPCOptions(),
 		HTTPOptions: options.NewHTTPOptions(),
+		DBOptions:   options.NewDBOptions(),
+
+		RedisOptions:             options.NewRedisOptions(),
+		CacheTTL:                 5 * time.Minute,
+		KafkaOptions:             options.NewKafkaOptions(),
+		OutboxRelayInterval:      time.Second,
+		WorkerMaxRetries:         3,
+		WorkerRetryBackoff:       time.Second,
+		TrashRetention:           30 * 24 * time.Hour,
+		TrashPurgeInterval:       time.Hour,
+		PublishSchedulerInterval: 10 * time.Second,
+		ViewCountBuffer:          apiserver.MemoryViewCountBuffer,
+		ViewCountFlushInterval:   5 * time.Second,
+		BcryptCost:               bcrypt.DefaultCost,
+		PolicyReloadInterval:     10 * time.Second,
 	}
 	opts.GRPCOptions.Addr = ":6666"
 	opts.HTTPOptions.Addr = ":5555"
+	opts.DBOptions.MySQL.Username = "miniblog"
+	opts.DBOptions.MySQL.Password = "miniblog1234"
+	opts.DBOptions.MySQL.Database = "miniblog"
+	opts.DBOptions.PostgreSQL.Username = "miniblog"
+	opts.DBOptions.PostgreSQL.Password = "miniblog1234"
+	opts.DBOptions.PostgreSQL.Database = "miniblog"
 	return opts
 }
 
@@ -51,12 +127,32 @@ func NewServerOptions() *ServerOptions {
 func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	// --server-mode是参数，默认值是o.ServerMode，最后是帮助说明描述。解析后的值会写入 &o.ServerMode
 	fs.StringVar(&o.ServerMode, "server-mode", o.ServerMode, fmt.Sprintf("Server mode, available options: %v", availableServerModes.UnsortedList()))
+	fs.StringVar(&o.Store, "store", o.Store, fmt.Sprintf("Storage backend, available options: %v. The memory store keeps all data in process memory.", availableStores.UnsortedList()))
 	fs.StringVar(&o.JWTKey, "jwt-key", o.JWTKey, "JWT signing key. Must be at least 6 characters long.")
 	// 绑定 JWT Token 的过期时间选项到命令行标志。
 	// 参数名称为 `--expiration`，默认值为 o.Expiration
 	fs.DurationVar(&o.Expiration, "expiration", o.Expiration, "The expiration duration of JWT tokens.")
+	fs.BoolVar(&o.EnableCache, "enable-cache", o.EnableCache, "Cache users and posts in redis.")
+	fs.DurationVar(&o.CacheTTL, "cache-ttl", o.CacheTTL, "The expiration duration of cached users and posts.")
+	fs.BoolVar(&o.EnableOutboxRelay, "enable-outbox-relay", o.EnableOutboxRelay, "Publish domain events in the outbox table to kafka.")
+	fs.DurationVar(&o.OutboxRelayInterval, "outbox-relay-interval", o.OutboxRelayInterval, "The interval at which the outbox table is polled for unpublished events.")
+	fs.BoolVar(&o.EnableWorker, "enable-worker", o.EnableWorker, "Consume domain events from --kafka.topic in the background.")
+	fs.IntVar(&o.WorkerMaxRetries, "worker-max-retries", o.WorkerMaxRetries, "The maximum number of retries before a message is moved to the dead letter topic.")
+	fs.DurationVar(&o.WorkerRetryBackoff, "worker-retry-backoff", o.WorkerRetryBackoff, "The wait time before the first retry, doubled on each retry.")
+	fs.DurationVar(&o.TrashRetention, "trash-retention", o.TrashRetention, "How long deleted posts and users are kept before they are permanently removed. 0 disables purging.")
+	fs.DurationVar(&o.TrashPurgeInterval, "trash-purge-interval", o.TrashPurgeInterval, "The interval at which expired deleted posts and users are purged.")
+	fs.DurationVar(&o.PublishSchedulerInterval, "publish-scheduler-interval", o.PublishSchedulerInterval, "The interval at which scheduled posts are checked and published. 0 disables scheduled publishing.")
+	fs.StringVar(&o.SearchIndexPath, "search-index-path", o.SearchIndexPath, "Directory of the full-text search index of posts. An empty value keeps the index in memory and rebuilds it on startup.")
+	fs.StringVar(&o.ViewCountBuffer, "view-count-buffer", o.ViewCountBuffer, fmt.Sprintf("Where post views are buffered before they are written to the database, available options: %v. The redis buffer is shared by all instances.", availableViewCountBuffers.UnsortedList()))
+	fs.DurationVar(&o.ViewCountFlushInterval, "view-count-flush-interval", o.ViewCountFlushInterval, "The interval at which buffered post views are written to the database.")
+	fs.BoolVar(&o.AutoMigrate, "auto-migrate", o.AutoMigrate, "Apply pending database migrations on startup. Always enabled for sqlite.")
+	fs.IntVar(&o.BcryptCost, "bcrypt-cost", o.BcryptCost, "The bcrypt cost used to hash user passwords, in the range [4, 31].")
+	fs.DurationVar(&o.PolicyReloadInterval, "policy-reload-interval", o.PolicyReloadInterval, "The interval at which authorization policies are reloaded from the database.")
 	o.GRPCOptions.AddFlags(fs)
 	o.HTTPOptions.AddFlags(fs)
+	o.DBOptions.AddFlags(fs)
+	o.RedisOptions.AddFlags(fs)
+	o.KafkaOptions.AddFlags(fs)
 }
 
 func (o *ServerOptions) Validate(obj any) error {
@@ -71,11 +167,69 @@ func (o *ServerOptions) Validate(obj any) error {
 
 // Config基于ServerOption构建apiserver.Config
 func (o *ServerOptions) Config() (*apiserver.Config, error) {
+	if !availableStores.Has(o.Store) {
+		return nil, fmt.Errorf("invalid store: %q, available options: %v", o.Store, availableStores.UnsortedList())
+	}
+	if errs := o.DBOptions.Validate(); len(errs) != 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	if !availableViewCountBuffers.Has(o.ViewCountBuffer) {
+		return nil, fmt.Errorf("invalid view count buffer: %q, available options: %v", o.ViewCountBuffer, availableViewCountBuffers.UnsortedList())
+	}
+	if o.EnableCache || o.ViewCountBuffer == apiserver.RedisViewCountBuffer {
+		if errs := o.RedisOptions.Validate(); len(errs) != 0 {
+			return nil, utilerrors.NewAggregate(errs)
+		}
+	}
+	if o.EnableOutboxRelay || o.EnableWorker {
+		errs := o.KafkaOptions.Validate()
+		if o.KafkaOptions.Topic == "" {
+			errs = append(errs, fmt.Errorf("--kafka.topic must be specified when outbox relay or worker is enabled"))
+		}
+		// 只有消费者组才能提交位移，保证重启后从上次处理的位置继续消费
+		if o.EnableWorker && o.KafkaOptions.ReaderOptions.GroupID == "" {
+			errs = append(errs, fmt.Errorf("--kafka.reader.group-id must be specified when worker is enabled"))
+		}
+		if len(errs) != 0 {
+			return nil, utilerrors.NewAggregate(errs)
+		}
+	}
+	if o.TrashRetention < 0 || (o.TrashRetention > 0 && o.TrashPurgeInterval <= 0) {
+		return nil, fmt.Errorf("--trash-retention cannot be negative and --trash-purge-interval must be positive")
+	}
+	if o.PublishSchedulerInterval < 0 {
+		return nil, fmt.Errorf("--publish-scheduler-interval cannot be negative")
+	}
+	if o.ViewCountFlushInterval <= 0 {
+		return nil, fmt.Errorf("--view-count-flush-interval must be positive")
+	}
+
 	return &apiserver.Config{
 		ServerMode:  o.ServerMode,
+		Store:       o.Store,
 		JWTKey:      o.JWTKey,
 		Expiration:  o.Expiration,
 		GRPCOptions: o.GRPCOptions,
 		HTTPOptions: o.HTTPOptions,
+		DBOptions:   o.DBOptions,
+		AutoMigrate: o.AutoMigrate,
+
+		RedisOptions:             o.RedisOptions,
+		EnableCache:              o.EnableCache,
+		CacheTTL:                 o.CacheTTL,
+		KafkaOptions:             o.KafkaOptions,
+		EnableOutboxRelay:        o.EnableOutboxRelay,
+		OutboxRelayInterval:      o.OutboxRelayInterval,
+		EnableWorker:             o.EnableWorker,
+		WorkerMaxRetries:         o.WorkerMaxRetries,
+		WorkerRetryBackoff:       o.WorkerRetryBackoff,
+		TrashRetention:           o.TrashRetention,
+		TrashPurgeInterval:       o.TrashPurgeInterval,
+		PublishSchedulerInterval: o.PublishSchedulerInterval,
+		SearchIndexPath:          o.SearchIndexPath,
+		ViewCountBuffer:          o.ViewCountBuffer,
+		ViewCountFlushInterval:   o.ViewCountFlushInterval,
+		BcryptCost:               o.BcryptCost,
+		PolicyReloadInterval:     o.PolicyReloadInterval,
 	}, nil
 }
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wshadm/miniblog/cmd/mb-apiserver/app/options"
+	"github.com/wshadm/miniblog/internal/apiserver/search"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// newReindexCommand 创建 reindex 子命令，用于从数据库重建博客的全文索引.
+func newReindexCommand(opts *options.ServerOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the full-text search index of posts",
+		Long: "Rebuild the full-text search index at --search-index-path from all published posts in the database. " +
+			"mb-apiserver must be stopped while the index is rebuilt.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := viper.Unmarshal(&opts); err != nil {
+				return err
+			}
+			if opts.SearchIndexPath == "" {
+				return fmt.Errorf("--search-index-path must be specified, the in-memory index is rebuilt on startup")
+			}
+			if errs := opts.DBOptions.Validate(); len(errs) != 0 {
+				return utilerrors.NewAggregate(errs)
+			}
+
+			db, err := opts.DBOptions.NewDB()
+			if err != nil {
+				return err
+			}
+			count, err := search.Reindex(cmd.Context(), opts.SearchIndexPath, store.NewStore(db))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Indexed %d posts into %s\n", count, opts.SearchIndexPath)
+			return nil
+		},
+	}
+}
@@ -34,6 +34,10 @@ func NewMiniBlogCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&configFile, "config", "c", filePath(), "配置文件路径")
 	// 将 ServerOptions 中的选项绑定到命令标志
 	ops.AddFlags(cmd.PersistentFlags())
+	// 注册数据库迁移子命令
+	cmd.AddCommand(newMigrateCommand(ops))
+	// 注册重建全文索引子命令
+	cmd.AddCommand(newReindexCommand(ops))
 	return cmd
 }
 
@@ -0,0 +1,63 @@
+package biz
+
+import (
+	commentv1 "github.com/wshadm/miniblog/internal/apiserver/biz/v1/comment"
+	postv1 "github.com/wshadm/miniblog/internal/apiserver/biz/v1/post"
+	tagv1 "github.com/wshadm/miniblog/internal/apiserver/biz/v1/tag"
+	userv1 "github.com/wshadm/miniblog/internal/apiserver/biz/v1/user"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/validation"
+	"github.com/wshadm/miniblog/internal/apiserver/search"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/apiserver/viewcount"
+	"github.com/wshadm/miniblog/internal/pkg/authz"
+)
+
+// IBiz 定义了业务层需要实现的方法.
+// gRPC 和 Gin 处理器共用同一个 IBiz 实例，避免重复实现业务逻辑.
+type IBiz interface {
+	// UserV1 获取用户业务接口.
+	UserV1() userv1.UserBiz
+	// PostV1 获取博客业务接口.
+	PostV1() postv1.PostBiz
+	// CommentV1 获取评论业务接口.
+	CommentV1() commentv1.CommentBiz
+	// TagV1 获取标签业务接口.
+	TagV1() tagv1.TagBiz
+}
+
+// biz 是 IBiz 的一个具体实现.
+type biz struct {
+	store store.IStore
+	val   *validation.Validator
+	authz *authz.Authz
+	index *search.Index
+	views viewcount.Buffer
+}
+
+// 确保 biz 实现了 IBiz 接口.
+var _ IBiz = (*biz)(nil)
+
+// NewBiz 创建一个 IBiz 类型的实例，index 为博客的全文索引，views 缓冲尚未写入数据库的博客浏览次数.
+func NewBiz(store store.IStore, authz *authz.Authz, index *search.Index, views viewcount.Buffer) *biz {
+	return &biz{store: store, val: validation.New(store), authz: authz, index: index, views: views}
+}
+
+// UserV1 返回一个实现了 UserBiz 接口的实例.
+func (b *biz) UserV1() userv1.UserBiz {
+	return userv1.New(b.store, b.val, b.authz)
+}
+
+// PostV1 返回一个实现了 PostBiz 接口的实例.
+func (b *biz) PostV1() postv1.PostBiz {
+	return postv1.New(b.store, b.val, b.authz, b.index, b.views)
+}
+
+// CommentV1 返回一个实现了 CommentBiz 接口的实例.
+func (b *biz) CommentV1() commentv1.CommentBiz {
+	return commentv1.New(b.store, b.val, b.authz)
+}
+
+// TagV1 返回一个实现了 TagBiz 接口的实例.
+func (b *biz) TagV1() tagv1.TagBiz {
+	return tagv1.New(b.store, b.val)
+}
@@ -0,0 +1,251 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/validation"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/authz"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"github.com/wshadm/miniblog/internal/pkg/pagetoken"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// replyOrder 为回复的排序，按 (createdAt, id) 正序，即按回复的先后顺序.
+var replyOrder = clause.OrderBy{Columns: []clause.OrderByColumn{
+	{Column: clause.Column{Name: "createdAt"}},
+	{Column: clause.Column{Name: "id"}},
+}}
+
+// CommentBiz 定义处理评论请求所需的方法.
+type CommentBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreateCommentRequest) (*apiv1.CreateCommentResponse, error)
+	List(ctx context.Context, rq *apiv1.ListCommentsRequest) (*apiv1.ListCommentsResponse, error)
+	Update(ctx context.Context, rq *apiv1.UpdateCommentRequest) (*apiv1.UpdateCommentResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeleteCommentRequest) (*apiv1.DeleteCommentResponse, error)
+}
+
+// commentBiz 是 CommentBiz 接口的实现.
+// 评论只能发表在当前用户可以读取的博客上：已发布的博客、自己的博客，管理员不受限制.
+// 评论者可以修改和删除自己的评论，博客作者可以删除自己博客下的任意评论.
+type commentBiz struct {
+	store store.IStore
+	val   *validation.Validator
+	authz *authz.Authz
+}
+
+// 确保 commentBiz 实现了 CommentBiz 接口.
+var _ CommentBiz = (*commentBiz)(nil)
+
+// New 创建一个 commentBiz 实例.
+func New(store store.IStore, val *validation.Validator, authz *authz.Authz) *commentBiz {
+	return &commentBiz{store: store, val: val, authz: authz}
+}
+
+// Create 在博客下发表评论或回复某条评论，评论归属于当前请求的用户.
+func (b *commentBiz) Create(ctx context.Context, rq *apiv1.CreateCommentRequest) (*apiv1.CreateCommentResponse, error) {
+	if err := b.val.ValidateCreateCommentRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if _, err := b.getPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	commentM := model.CommentM{
+		PostID:  rq.GetPostID(),
+		UserID:  contextx.UserID(ctx),
+		Content: rq.GetContent(),
+	}
+	if rq.GetParentID() != "" {
+		parent, err := b.getComment(ctx, rq.GetPostID(), rq.GetParentID())
+		if err != nil {
+			return nil, err
+		}
+		// 回复与被回复的评论属于同一个讨论串
+		commentM.ParentID, commentM.RootID = parent.CommentID, parent.RootID
+		if commentM.RootID == "" {
+			commentM.RootID = parent.CommentID
+		}
+	}
+
+	if err := b.store.Comment().Create(ctx, &commentM); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("Comment created", "postID", commentM.PostID, "commentID", commentM.CommentID)
+	return &apiv1.CreateCommentResponse{CommentID: commentM.CommentID}, nil
+}
+
+// List 按顶层评论分页列出博客的评论，每条顶层评论包含其全部回复. 匿名用户只能列出已发布博客的评论.
+func (b *commentBiz) List(ctx context.Context, rq *apiv1.ListCommentsRequest) (*apiv1.ListCommentsResponse, error) {
+	if err := b.val.ValidateListCommentsRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if _, err := b.getPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	whr := where.F("postID", rq.GetPostID(), "rootID", "").L(int(rq.GetLimit()))
+
+	// 分页令牌只能用于同一篇博客的评论
+	scope := strings.Join([]string{"comment", rq.GetPostID()}, "\x00")
+	offset := rq.GetOffset()
+	if rq.GetPageToken() != "" {
+		cursor, err := pagetoken.Decode(rq.GetPageToken(), scope)
+		if err != nil {
+			return nil, errno.ErrPageTokenInvalid
+		}
+		whr, offset = whr.C(pagetoken.After(cursor)), 0
+	}
+	whr = whr.O(int(offset))
+
+	count, roots, err := b.store.Comment().List(ctx, whr)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	comments := make([]*apiv1.Comment, 0, len(roots))
+	threads := make(map[string]*apiv1.Comment, len(roots))
+	rootIDs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		comment := conversion.CommentModelToCommentV1(root)
+		comments = append(comments, comment)
+		threads[root.CommentID] = comment
+		rootIDs = append(rootIDs, root.CommentID)
+	}
+	if len(rootIDs) > 0 {
+		_, replies, err := b.store.Comment().List(ctx, where.F("postID", rq.GetPostID(), "rootID", rootIDs).C(replyOrder))
+		if err != nil {
+			return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+		for _, reply := range replies {
+			thread := threads[reply.RootID]
+			thread.Replies = append(thread.Replies, conversion.CommentModelToCommentV1(reply))
+		}
+	}
+
+	var nextPageToken string
+	if len(roots) > 0 && offset+int64(len(roots)) < count {
+		last := roots[len(roots)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, scope)
+	}
+	return &apiv1.ListCommentsResponse{TotalCount: count, Comments: comments, NextPageToken: nextPageToken}, nil
+}
+
+// Update 修改当前用户的评论，只有评论者本人和管理员可以修改评论.
+func (b *commentBiz) Update(ctx context.Context, rq *apiv1.UpdateCommentRequest) (*apiv1.UpdateCommentResponse, error) {
+	if err := b.val.ValidateUpdateCommentRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if _, err := b.getPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	commentM, err := b.getComment(ctx, rq.GetPostID(), rq.GetCommentID())
+	if err != nil {
+		return nil, err
+	}
+	userID := contextx.UserID(ctx)
+	if commentM.UserID != userID && !b.authz.IsAdmin(userID) {
+		return nil, errorsx.ErrPermissionDenied.WithMessage("only the author of the comment can edit it")
+	}
+
+	commentM.Content = rq.GetContent()
+	if err := b.store.Comment().Update(ctx, commentM); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return &apiv1.UpdateCommentResponse{}, nil
+}
+
+// Delete 删除评论及其全部回复. 评论者本人、博客作者和管理员可以删除评论.
+func (b *commentBiz) Delete(ctx context.Context, rq *apiv1.DeleteCommentRequest) (*apiv1.DeleteCommentResponse, error) {
+	if err := b.val.ValidateDeleteCommentRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	postM, err := b.getPost(ctx, rq.GetPostID())
+	if err != nil {
+		return nil, err
+	}
+
+	commentM, err := b.getComment(ctx, rq.GetPostID(), rq.GetCommentID())
+	if err != nil {
+		return nil, err
+	}
+	userID := contextx.UserID(ctx)
+	if commentM.UserID != userID && postM.UserID != userID && !b.authz.IsAdmin(userID) {
+		return nil, errorsx.ErrPermissionDenied.WithMessage("only the author of the comment or the post can delete it")
+	}
+
+	ids, err := b.subtree(ctx, commentM)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	if err := b.store.Comment().Delete(ctx, where.F("postID", rq.GetPostID(), "commentID", ids)); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("Comment deleted", "postID", commentM.PostID, "commentID", commentM.CommentID, "count", len(ids))
+	return &apiv1.DeleteCommentResponse{}, nil
+}
+
+// subtree 返回评论及其全部回复的 ID.
+func (b *commentBiz) subtree(ctx context.Context, commentM *model.CommentM) ([]string, error) {
+	rootID := commentM.RootID
+	if rootID == "" {
+		rootID = commentM.CommentID
+	}
+	_, thread, err := b.store.Comment().List(ctx, where.F("postID", commentM.PostID, "rootID", rootID))
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]string, len(thread))
+	for _, reply := range thread {
+		children[reply.ParentID] = append(children[reply.ParentID], reply.CommentID)
+	}
+	ids := []string{commentM.CommentID}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+	return ids, nil
+}
+
+// getPost 查询当前用户可以读取的博客：已发布的博客或当前用户自己的博客，管理员可以读取所有博客.
+// 博客不存在或无权读取时都返回 errno.ErrPostNotFound，避免泄露未发布博客是否存在.
+func (b *commentBiz) getPost(ctx context.Context, postID string) (*model.PostM, error) {
+	postM, err := b.store.Post().Get(ctx, where.F("postID", postID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPostNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	userID := contextx.UserID(ctx)
+	if postM.Status != model.PostStatusPublished && (userID == "" || postM.UserID != userID) && !b.authz.IsAdmin(userID) {
+		return nil, errno.ErrPostNotFound
+	}
+	return postM, nil
+}
+
+// getComment 查询博客下的某条评论，并将记录不存在的错误转换为 errno.ErrCommentNotFound.
+func (b *commentBiz) getComment(ctx context.Context, postID string, commentID string) (*model.CommentM, error) {
+	commentM, err := b.store.Comment().Get(ctx, where.F("postID", postID, "commentID", commentID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrCommentNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return commentM, nil
+}
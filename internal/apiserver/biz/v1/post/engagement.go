@@ -0,0 +1,153 @@
+package post
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// Like 以当前用户的身份点赞已发布的博客，重复点赞不会重复计数. 返回博客的点赞数.
+// 点赞数与点赞记录在同一个事务中更新，且不会改变博客的 etag.
+func (b *postBiz) Like(ctx context.Context, rq *apiv1.LikePostRequest) (*apiv1.LikePostResponse, error) {
+	if err := b.val.ValidateLikePostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	var likeCount int64
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		postM, err := b.find(ctx, where.F("postID", rq.GetPostID(), "status", model.PostStatusPublished))
+		if err != nil {
+			return err
+		}
+
+		likeCount = postM.LikeCount
+		created, err := b.store.PostLike().CreateIfNotExist(ctx, &model.PostLikeM{PostID: postM.PostID, UserID: contextx.UserID(ctx)})
+		if err != nil {
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		if !created {
+			return nil
+		}
+		if err := b.store.Post().Increment(ctx, postM.PostID, "likeCount", 1); err != nil {
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		likeCount++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.LikePostResponse{LikeCount: likeCount}, nil
+}
+
+// Unlike 取消当前用户对已发布博客的点赞，未点赞时不返回错误. 返回博客的点赞数.
+func (b *postBiz) Unlike(ctx context.Context, rq *apiv1.UnlikePostRequest) (*apiv1.UnlikePostResponse, error) {
+	if err := b.val.ValidateUnlikePostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	var likeCount int64
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		postM, err := b.find(ctx, where.F("postID", rq.GetPostID(), "status", model.PostStatusPublished))
+		if err != nil {
+			return err
+		}
+
+		likeCount = postM.LikeCount
+		n, err := b.store.PostLike().Delete(ctx, where.F("postID", postM.PostID, "userID", contextx.UserID(ctx)))
+		if err != nil {
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		if n == 0 {
+			return nil
+		}
+		if err := b.store.Post().Increment(ctx, postM.PostID, "likeCount", -n); err != nil {
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		likeCount -= n
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.UnlikePostResponse{LikeCount: likeCount}, nil
+}
+
+// Bookmark 将已发布的博客加入当前用户的收藏，重复收藏不会返回错误.
+func (b *postBiz) Bookmark(ctx context.Context, rq *apiv1.BookmarkPostRequest) (*apiv1.BookmarkPostResponse, error) {
+	if err := b.val.ValidateBookmarkPostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	postM, err := b.find(ctx, where.F("postID", rq.GetPostID(), "status", model.PostStatusPublished))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.store.Bookmark().CreateIfNotExist(ctx, &model.BookmarkM{UserID: contextx.UserID(ctx), PostID: postM.PostID}); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return &apiv1.BookmarkPostResponse{}, nil
+}
+
+// Unbookmark 将博客移出当前用户的收藏，未收藏时不返回错误.
+// 已删除或取消发布的博客也可以移出收藏.
+func (b *postBiz) Unbookmark(ctx context.Context, rq *apiv1.UnbookmarkPostRequest) (*apiv1.UnbookmarkPostResponse, error) {
+	if err := b.val.ValidateUnbookmarkPostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.Bookmark().Delete(ctx, where.F("userID", contextx.UserID(ctx), "postID", rq.GetPostID())); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return &apiv1.UnbookmarkPostResponse{}, nil
+}
+
+// ListBookmarks 列出当前用户的收藏，按收藏时间倒序排列.
+// 收藏的博客已被删除或取消发布时，收藏仍会被列出，但不包含博客内容.
+func (b *postBiz) ListBookmarks(ctx context.Context, rq *apiv1.ListBookmarksRequest) (*apiv1.ListBookmarksResponse, error) {
+	if err := b.val.ValidateListBookmarksRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	count, bookmarkList, err := b.store.Bookmark().List(ctx, where.T(ctx).O(int(rq.GetOffset())).L(int(rq.GetLimit())))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	if len(bookmarkList) == 0 {
+		return &apiv1.ListBookmarksResponse{TotalCount: count}, nil
+	}
+
+	ids := make([]string, 0, len(bookmarkList))
+	for _, bookmarkM := range bookmarkList {
+		ids = append(ids, bookmarkM.PostID)
+	}
+	_, postList, err := b.store.Post().List(ctx, where.F("postID", ids, "status", model.PostStatusPublished))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	posts := make([]*apiv1.Post, 0, len(postList))
+	for _, postM := range postList {
+		posts = append(posts, conversion.PostModelToPostV1(postM))
+	}
+	if err := b.setTaxonomy(ctx, posts, postList, nil); err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*apiv1.Post, len(posts))
+	for _, post := range posts {
+		byID[post.GetPostID()] = post
+	}
+
+	bookmarks := make([]*apiv1.Bookmark, 0, len(bookmarkList))
+	for _, bookmarkM := range bookmarkList {
+		bookmark := conversion.BookmarkModelToBookmarkV1(bookmarkM)
+		bookmark.Post = byID[bookmarkM.PostID]
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return &apiv1.ListBookmarksResponse{TotalCount: count, Bookmarks: bookmarks}, nil
+}
@@ -0,0 +1,562 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/event"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/validation"
+	"github.com/wshadm/miniblog/internal/apiserver/search"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/apiserver/viewcount"
+	"github.com/wshadm/miniblog/internal/pkg/authz"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/etag"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	"github.com/wshadm/miniblog/internal/pkg/filtering"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"github.com/wshadm/miniblog/internal/pkg/pagetoken"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// postFields 为博客列表允许过滤和排序的字段.
+var postFields = filtering.Fields{
+	"postID":    {Column: "postID", Type: filtering.String},
+	"userID":    {Column: "userID", Type: filtering.String},
+	"title":     {Column: "title", Type: filtering.String},
+	"content":   {Column: "content", Type: filtering.String},
+	"status":    {Column: "status", Type: filtering.String},
+	"publishAt": {Column: "publishAt", Type: filtering.Time},
+	"createdAt": {Column: "createdAt", Type: filtering.Time},
+	"updatedAt": {Column: "updatedAt", Type: filtering.Time},
+	"likeCount": {Column: "likeCount", Type: filtering.Int},
+	"viewCount": {Column: "viewCount", Type: filtering.Int},
+}
+
+// PostBiz 定义处理博客请求所需的方法.
+type PostBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreatePostRequest) (*apiv1.CreatePostResponse, error)
+	Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*apiv1.UpdatePostResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeletePostsRequest) (*apiv1.DeletePostsResponse, error)
+	Get(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.GetPostResponse, error)
+	List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.ListPostResponse, error)
+	Publish(ctx context.Context, rq *apiv1.PublishPostRequest) (*apiv1.PublishPostResponse, error)
+	Unpublish(ctx context.Context, rq *apiv1.UnpublishPostRequest) (*apiv1.UnpublishPostResponse, error)
+	ListRevisions(ctx context.Context, rq *apiv1.ListPostRevisionsRequest) (*apiv1.ListPostRevisionsResponse, error)
+	GetRevision(ctx context.Context, rq *apiv1.GetPostRevisionRequest) (*apiv1.GetPostRevisionResponse, error)
+	Revert(ctx context.Context, rq *apiv1.RevertPostRequest) (*apiv1.RevertPostResponse, error)
+	Diff(ctx context.Context, rq *apiv1.DiffPostRevisionsRequest) (*apiv1.DiffPostRevisionsResponse, error)
+	ListDeleted(ctx context.Context, rq *apiv1.ListDeletedPostsRequest) (*apiv1.ListDeletedPostsResponse, error)
+	Restore(ctx context.Context, rq *apiv1.RestorePostRequest) (*apiv1.RestorePostResponse, error)
+	Purge(ctx context.Context, rq *apiv1.PurgePostRequest) (*apiv1.PurgePostResponse, error)
+	Search(ctx context.Context, rq *apiv1.SearchPostsRequest) (*apiv1.SearchPostsResponse, error)
+	Like(ctx context.Context, rq *apiv1.LikePostRequest) (*apiv1.LikePostResponse, error)
+	Unlike(ctx context.Context, rq *apiv1.UnlikePostRequest) (*apiv1.UnlikePostResponse, error)
+	Bookmark(ctx context.Context, rq *apiv1.BookmarkPostRequest) (*apiv1.BookmarkPostResponse, error)
+	Unbookmark(ctx context.Context, rq *apiv1.UnbookmarkPostRequest) (*apiv1.UnbookmarkPostResponse, error)
+	ListBookmarks(ctx context.Context, rq *apiv1.ListBookmarksRequest) (*apiv1.ListBookmarksResponse, error)
+}
+
+// postBiz 是 PostBiz 接口的实现.
+// 普通用户的修改都通过 where.T(ctx) 限定在当前用户范围内，管理员不受限制.
+// 所有用户都可以读取已发布的博客，登录用户还可以读取自己的博客.
+type postBiz struct {
+	store store.IStore
+	val   *validation.Validator
+	authz *authz.Authz
+	index *search.Index
+	views viewcount.Buffer
+}
+
+// 确保 postBiz 实现了 PostBiz 接口.
+var _ PostBiz = (*postBiz)(nil)
+
+// New 创建一个 postBiz 实例.
+func New(store store.IStore, val *validation.Validator, authz *authz.Authz, index *search.Index, views viewcount.Buffer) *postBiz {
+	return &postBiz{store: store, val: val, authz: authz, index: index, views: views}
+}
+
+// Create 创建博客，博客归属于当前请求的用户.
+// 默认立即发布，publishAt 晚于当前时间时博客会在 publishAt 定时发布. 不存在的标签和分类会被自动创建.
+func (b *postBiz) Create(ctx context.Context, rq *apiv1.CreatePostRequest) (*apiv1.CreatePostResponse, error) {
+	if err := b.val.ValidateCreatePostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	postM := model.PostM{
+		UserID:  contextx.UserID(ctx),
+		Title:   rq.GetTitle(),
+		Content: rq.GetContent(),
+		Status:  model.PostStatusDraft,
+	}
+	if rq.GetStatus() != model.PostStatusDraft {
+		postM.Status, postM.PublishAt = schedule(rq.GetPublishAt())
+	}
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		var err error
+		if postM.CategoryID, err = b.categoryID(ctx, rq.GetCategory()); err != nil {
+			return err
+		}
+		if err := b.store.Post().Create(ctx, &postM); err != nil {
+			return err
+		}
+		if err := b.saveRevision(ctx, &postM); err != nil {
+			return err
+		}
+		if err := b.saveTags(ctx, postM.PostID, rq.GetTags()); err != nil {
+			return err
+		}
+		return b.publish(ctx, event.PostCreated, &postM)
+	})
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("Post created", "postID", postM.PostID)
+	return &apiv1.CreatePostResponse{PostID: postM.PostID}, nil
+}
+
+// Update 更新当前用户的博客，修改后的标题和内容会保存为一个新的修订.
+// 只修改标签或分类时不会产生新的修订，但 etag 仍会变化.
+func (b *postBiz) Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*apiv1.UpdatePostResponse, error) {
+	if err := b.val.ValidateUpdatePostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	postM, err := b.getPost(ctx, rq.GetPostID())
+	if err != nil {
+		return nil, err
+	}
+	if rq.GetEtag() != "" && !etag.Match(rq.GetEtag(), postM.Version) {
+		return nil, errno.ErrEtagMismatch
+	}
+
+	// 设置了 updateMask 时只更新其中的字段，否则只更新请求中显式设置的字段.
+	// updateMask 已经在校验阶段检查过，这里不会出错
+	paths, _ := conversion.PostUpdateFields.Paths(rq.GetUpdateMask())
+	var columns []string
+	if fieldmask.Has(paths, "title", rq.Title != nil) {
+		postM.Title = rq.GetTitle()
+		columns = append(columns, "title")
+	}
+	if fieldmask.Has(paths, "content", rq.Content != nil) {
+		postM.Content = rq.GetContent()
+		columns = append(columns, "content")
+	}
+	revise := len(columns) > 0
+	setCategory := fieldmask.Has(paths, "category", rq.Category != nil)
+	if setCategory {
+		columns = append(columns, "categoryID")
+	}
+	setTags := fieldmask.Has(paths, "tags", rq.Tags != nil)
+	if len(columns) == 0 && !setTags {
+		return &apiv1.UpdatePostResponse{Etag: etag.Format(postM.Version)}, nil
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		var err error
+		if setCategory {
+			if postM.CategoryID, err = b.categoryID(ctx, rq.GetCategory()); err != nil {
+				return err
+			}
+		}
+		// 只修改标签时同样更新版本号，使 etag 发生变化
+		if err := b.store.Post().UpdateColumns(ctx, postM, columns...); err != nil {
+			return err
+		}
+		if revise {
+			if err := b.saveRevision(ctx, postM); err != nil {
+				return err
+			}
+		}
+		if setTags {
+			if err := b.saveTags(ctx, postM.PostID, conversion.PostTags(rq.GetTags())); err != nil {
+				return err
+			}
+		}
+		return b.publish(ctx, event.PostUpdated, postM)
+	})
+	if errors.Is(err, store.ErrVersionConflict) {
+		return nil, errno.ErrEtagMismatch
+	}
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return &apiv1.UpdatePostResponse{Etag: etag.Format(postM.Version)}, nil
+}
+
+// Delete 批量将当前用户的博客移入回收站.
+func (b *postBiz) Delete(ctx context.Context, rq *apiv1.DeletePostsRequest) (*apiv1.DeletePostsResponse, error) {
+	if err := b.val.ValidateDeletePostsRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if len(rq.GetPostIDs()) == 0 {
+		return &apiv1.DeletePostsResponse{}, nil
+	}
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		// 先查询出实际会被删除的博客，只为这些博客写入删除事件
+		_, posts, err := b.store.Post().List(ctx, b.scope(ctx).F("postID", rq.GetPostIDs()))
+		if err != nil || len(posts) == 0 {
+			return err
+		}
+		if err := b.store.Post().Delete(ctx, b.scope(ctx).F("postID", rq.GetPostIDs())); err != nil {
+			return err
+		}
+
+		events := make([]*model.OutboxM, 0, len(posts))
+		for _, postM := range posts {
+			evt, err := event.NewPostEvent(event.PostDeleted, postM)
+			if err != nil {
+				return err
+			}
+			events = append(events, evt)
+		}
+		return b.store.Outbox().CreateInBatches(ctx, events, len(events))
+	})
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return &apiv1.DeletePostsResponse{}, nil
+}
+
+// Get 获取一篇已发布的博客或当前用户自己的博客，管理员可以获取任意博客.
+func (b *postBiz) Get(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.GetPostResponse, error) {
+	if err := b.val.ValidateGetPostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	// 只查询 readMask 中的字段对应的列，查询标签需要 postID 列，统计浏览次数和检查可见性需要 status 和 userID 列
+	paths, _ := conversion.PostReadFields.Paths(rq.GetReadMask())
+	whr := where.F("postID", rq.GetPostID())
+	if contextx.UserID(ctx) == "" {
+		whr = whr.F("status", model.PostStatusPublished)
+	}
+	// 登录用户按 postID 查询后再检查可见性，使查询可以命中缓存
+	postM, err := b.find(ctx, whr.C(conversion.PostReadFields.Select(paths, "id", "postID", "status", "userID")...))
+	if err != nil {
+		return nil, err
+	}
+	if !b.readable(ctx, postM) {
+		return nil, errno.ErrPostNotFound
+	}
+
+	post := conversion.PostModelToPostV1(postM)
+	b.countView(ctx, post, postM, paths)
+	if err := b.setTaxonomy(ctx, []*apiv1.Post{post}, []*model.PostM{postM}, paths); err != nil {
+		return nil, err
+	}
+	fieldmask.Prune(post, paths)
+	return &apiv1.GetPostResponse{Post: post}, nil
+}
+
+// List 列出已发布的博客和当前用户自己的博客，管理员可以列出所有博客. 可以按标签和分类过滤.
+func (b *postBiz) List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.ListPostResponse, error) {
+	if err := b.val.ValidateListPostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	conds, err := postFields.Filter(rq.GetFilter())
+	if err != nil {
+		return nil, errorsx.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+	order, err := postFields.OrderBy(rq.GetOrderBy())
+	if err != nil {
+		return nil, errorsx.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+
+	// 游标分页需要 id 和 createdAt 列，查询标签需要 postID 列
+	paths, _ := conversion.PostReadFields.Paths(rq.GetReadMask())
+	sel := conversion.PostReadFields.Select(paths, "id", "createdAt", "postID")
+
+	whr := b.readScope(ctx).L(int(rq.GetLimit())).C(conds...).C(order...).C(sel...)
+	if rq.Title != nil {
+		whr = whr.C(filtering.Contains("title", rq.GetTitle()))
+	}
+	whr, found, err := b.taxonomyScope(ctx, whr, rq.GetTag(), rq.GetCategory())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return &apiv1.ListPostResponse{}, nil
+	}
+
+	// 分页令牌只能用于相同用户、相同过滤条件的查询
+	scope := strings.Join([]string{"post", contextx.UserID(ctx), rq.GetTitle(), rq.GetFilter(), rq.GetTag(), rq.GetCategory()}, "\x00")
+	offset := rq.GetOffset()
+	var countWhr *where.Options
+	if rq.GetPageToken() != "" {
+		cursor, err := pagetoken.Decode(rq.GetPageToken(), scope)
+		if err != nil {
+			return nil, errno.ErrPageTokenInvalid
+		}
+		// 总数不包含游标条件，与偏移分页返回的总数一致
+		countWhr = &where.Options{Filters: whr.Filters, Clauses: slices.Clone(whr.Clauses), Queries: slices.Clone(whr.Queries)}
+		whr, offset = whr.C(pagetoken.After(cursor)), 0
+	}
+	whr = whr.O(int(offset))
+
+	count, postList, err := b.store.Post().List(ctx, whr)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	totalCount := count
+	if countWhr != nil {
+		if totalCount, err = b.store.Post().Count(ctx, countWhr); err != nil {
+			return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+	}
+
+	posts := make([]*apiv1.Post, 0, len(postList))
+	for _, postM := range postList {
+		posts = append(posts, conversion.PostModelToPostV1(postM))
+	}
+	if err := b.setTaxonomy(ctx, posts, postList, paths); err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		fieldmask.Prune(post, paths)
+	}
+
+	var nextPageToken string
+	if rq.GetOrderBy() == "" && len(postList) > 0 && offset+int64(len(postList)) < count {
+		last := postList[len(postList)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, scope)
+	}
+	return &apiv1.ListPostResponse{TotalCount: totalCount, Posts: posts, NextPageToken: nextPageToken}, nil
+}
+
+// Publish 发布当前用户的博客，publishAt 晚于当前时间时改为在 publishAt 定时发布.
+// 已发布的博客再次立即发布时不做修改，发布时间保持不变.
+func (b *postBiz) Publish(ctx context.Context, rq *apiv1.PublishPostRequest) (*apiv1.PublishPostResponse, error) {
+	if err := b.val.ValidatePublishPostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	postM, err := b.getPost(ctx, rq.GetPostID())
+	if err != nil {
+		return nil, err
+	}
+	if rq.GetEtag() != "" && !etag.Match(rq.GetEtag(), postM.Version) {
+		return nil, errno.ErrEtagMismatch
+	}
+
+	status, publishAt := schedule(rq.GetPublishAt())
+	if postM.Status == model.PostStatusPublished && status == model.PostStatusPublished {
+		return &apiv1.PublishPostResponse{}, nil
+	}
+	postM.Status, postM.PublishAt = status, publishAt
+
+	eventType := event.PostPublished
+	if status == model.PostStatusScheduled {
+		eventType = event.PostUpdated
+	}
+	if err := b.updateStatus(ctx, postM, eventType); err != nil {
+		return nil, err
+	}
+	return &apiv1.PublishPostResponse{}, nil
+}
+
+// Unpublish 撤回当前用户的博客，博客变为草稿，archive 为 true 时变为归档.
+// 撤回定时发布的博客会取消定时发布，归档的博客保留原来的发布时间.
+func (b *postBiz) Unpublish(ctx context.Context, rq *apiv1.UnpublishPostRequest) (*apiv1.UnpublishPostResponse, error) {
+	if err := b.val.ValidateUnpublishPostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	postM, err := b.getPost(ctx, rq.GetPostID())
+	if err != nil {
+		return nil, err
+	}
+	if rq.GetEtag() != "" && !etag.Match(rq.GetEtag(), postM.Version) {
+		return nil, errno.ErrEtagMismatch
+	}
+
+	status := model.PostStatusDraft
+	if rq.GetArchive() {
+		status = model.PostStatusArchived
+	}
+	if postM.Status == status {
+		return &apiv1.UnpublishPostResponse{}, nil
+	}
+
+	eventType := event.PostUpdated
+	if postM.Status == model.PostStatusPublished {
+		eventType = event.PostUnpublished
+	}
+	postM.Status = status
+	if status == model.PostStatusDraft {
+		postM.PublishAt = nil
+	}
+	if err := b.updateStatus(ctx, postM, eventType); err != nil {
+		return nil, err
+	}
+	return &apiv1.UnpublishPostResponse{}, nil
+}
+
+// ListDeleted 列出当前用户回收站中的博客.
+func (b *postBiz) ListDeleted(ctx context.Context, rq *apiv1.ListDeletedPostsRequest) (*apiv1.ListDeletedPostsResponse, error) {
+	if err := b.val.ValidateListDeletedPostsRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	count, postList, err := b.store.Post().ListDeleted(ctx, b.scope(ctx).O(int(rq.GetOffset())).L(int(rq.GetLimit())))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	posts := make([]*apiv1.Post, 0, len(postList))
+	for _, postM := range postList {
+		posts = append(posts, conversion.PostModelToPostV1(postM))
+	}
+	if err := b.setTaxonomy(ctx, posts, postList, nil); err != nil {
+		return nil, err
+	}
+	return &apiv1.ListDeletedPostsResponse{TotalCount: count, Posts: posts}, nil
+}
+
+// Restore 恢复当前用户回收站中的博客.
+func (b *postBiz) Restore(ctx context.Context, rq *apiv1.RestorePostRequest) (*apiv1.RestorePostResponse, error) {
+	if err := b.val.ValidateRestorePostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		n, err := b.store.Post().Restore(ctx, b.scope(ctx).F("postID", rq.GetPostID()))
+		if err != nil {
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		if n == 0 {
+			return errno.ErrPostNotFound
+		}
+
+		postM, err := b.getPost(ctx, rq.GetPostID())
+		if err != nil {
+			return err
+		}
+		if err := b.publish(ctx, event.PostRestored, postM); err != nil {
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.RestorePostResponse{}, nil
+}
+
+// Purge 永久删除当前用户回收站中的博客，未被删除的博客需要先移入回收站.
+func (b *postBiz) Purge(ctx context.Context, rq *apiv1.PurgePostRequest) (*apiv1.PurgePostResponse, error) {
+	if err := b.val.ValidatePurgePostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	n, err := b.store.Post().Purge(ctx, b.scope(ctx).F("postID", rq.GetPostID()))
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	if n == 0 {
+		return nil, errno.ErrPostNotFound
+	}
+	return &apiv1.PurgePostResponse{}, nil
+}
+
+// publish 将博客事件写入发件箱，需要在写入博客数据的同一个事务中调用.
+func (b *postBiz) publish(ctx context.Context, eventType string, postM *model.PostM) error {
+	evt, err := event.NewPostEvent(eventType, postM)
+	if err != nil {
+		return err
+	}
+	return b.store.Outbox().Create(ctx, evt)
+}
+
+// updateStatus 更新博客的状态和发布时间，并写入 eventType 事件.
+func (b *postBiz) updateStatus(ctx context.Context, postM *model.PostM, eventType string) error {
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.Post().UpdateColumns(ctx, postM, "status", "publishAt"); err != nil {
+			return err
+		}
+		return b.publish(ctx, eventType, postM)
+	})
+	if errors.Is(err, store.ErrVersionConflict) {
+		return errno.ErrEtagMismatch
+	}
+	if err != nil {
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("Post status changed", "postID", postM.PostID, "status", postM.Status)
+	return nil
+}
+
+// schedule 返回在 publishAt 发布的博客的状态和发布时间，publishAt 为空时立即发布.
+// 发布时间统一使用 UTC，使 SQLite 中保存的时间可以按字符串比较.
+func schedule(publishAt *timestamppb.Timestamp) (string, *time.Time) {
+	now := time.Now().UTC()
+	if publishAt == nil {
+		return model.PostStatusPublished, &now
+	}
+	at := publishAt.AsTime()
+	if at.After(now) {
+		return model.PostStatusScheduled, &at
+	}
+	return model.PostStatusPublished, &at
+}
+
+// readScope 返回当前用户可读取的博客范围：所有用户都可以读取已发布的博客，
+// 登录用户还可以读取自己的博客，管理员可以读取所有博客. 登录不会缩小可读取的范围.
+func (b *postBiz) readScope(ctx context.Context) *where.Options {
+	userID := contextx.UserID(ctx)
+	switch {
+	case userID == "":
+		return where.F("status", model.PostStatusPublished)
+	case b.authz.IsAdmin(userID):
+		return where.NewWhere()
+	}
+	return where.C(clause.Or(
+		clause.Eq{Column: clause.Column{Name: "status"}, Value: model.PostStatusPublished},
+		clause.Eq{Column: clause.Column{Name: "userID"}, Value: userID},
+	))
+}
+
+// readable 判断当前用户是否可以读取 postM，与 readScope 的范围一致.
+func (b *postBiz) readable(ctx context.Context, postM *model.PostM) bool {
+	userID := contextx.UserID(ctx)
+	return postM.Status == model.PostStatusPublished || (userID != "" && (postM.UserID == userID || b.authz.IsAdmin(userID)))
+}
+
+// scope 返回当前用户可访问的博客范围.
+func (b *postBiz) scope(ctx context.Context) *where.Options {
+	if b.authz.IsAdmin(contextx.UserID(ctx)) {
+		return where.NewWhere()
+	}
+	return where.T(ctx)
+}
+
+// getPost 查询当前用户的某篇博客.
+func (b *postBiz) getPost(ctx context.Context, postID string) (*model.PostM, error) {
+	return b.find(ctx, b.scope(ctx).F("postID", postID))
+}
+
+// find 查询满足 whr 的博客，并将记录不存在的错误转换为 errno.ErrPostNotFound.
+func (b *postBiz) find(ctx context.Context, whr *where.Options) (*model.PostM, error) {
+	postM, err := b.store.Post().Get(ctx, whr)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPostNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return postM, nil
+}
@@ -0,0 +1,221 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/validation"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/apiserver/viewcount"
+	"github.com/wshadm/miniblog/internal/pkg/authz"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/options"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestBiz 创建使用内存存储的 postBiz，授权策略保存在内存 SQLite 中，不使用全文检索.
+func newTestBiz(t *testing.T) (*postBiz, store.IStore) {
+	t.Helper()
+
+	// 与服务启动时一致，where.T(ctx) 按当前用户过滤
+	where.RegisterTenant("userID", contextx.UserID)
+
+	opts := options.NewSQLiteOptions()
+	opts.Path = ":memory:"
+	db, err := opts.NewDB()
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	a, err := authz.NewAuthz(db, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create authz: %v", err)
+	}
+
+	s := store.NewMemoryStore()
+	return New(s, validation.New(s), a, nil, viewcount.NewMemoryBuffer()), s
+}
+
+// TestListWalksEveryPage 使用分页令牌遍历所有页，每篇已发布的博客都恰好出现一次.
+func TestListWalksEveryPage(t *testing.T) {
+	ctx := context.Background()
+	b, s := newTestBiz(t)
+
+	// 多篇博客的创建时间相同，分页时按 id 区分先后
+	epoch := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	offsets := []int{0, 0, 0, 1, 1, 2, 3, 3, 3, 3, 4}
+	want := make(map[string]bool)
+	for i, hours := range offsets {
+		postM := &model.PostM{
+			UserID:    "user-a",
+			Title:     fmt.Sprintf("post %d", i),
+			Content:   "content",
+			Status:    model.PostStatusPublished,
+			CreatedAt: epoch.Add(time.Duration(hours) * time.Hour),
+		}
+		// 草稿不在匿名用户的列表中
+		if i%4 == 1 {
+			postM.Status = model.PostStatusDraft
+		}
+		if err := s.Post().Create(ctx, postM); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if postM.Status == model.PostStatusPublished {
+			want[postM.PostID] = true
+		}
+	}
+
+	for _, limit := range []int64{1, 2, 3, 4, int64(len(offsets))} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			seen := make(map[string]bool)
+			var last *apiv1.Post
+			token := ""
+			for page := 0; ; page++ {
+				if page > len(offsets) {
+					t.Fatal("paging did not terminate")
+				}
+				resp, err := b.List(ctx, &apiv1.ListPostRequest{Limit: limit, PageToken: token})
+				if err != nil {
+					t.Fatalf("List page %d: %v", page, err)
+				}
+				if resp.GetTotalCount() != int64(len(want)) {
+					t.Errorf("page %d totalCount = %d, want %d", page, resp.GetTotalCount(), len(want))
+				}
+				if int64(len(resp.GetPosts())) > limit {
+					t.Errorf("page %d has %d posts, want at most %d", page, len(resp.GetPosts()), limit)
+				}
+				for _, post := range resp.GetPosts() {
+					if seen[post.GetPostID()] {
+						t.Errorf("post %s is returned twice", post.GetPostID())
+					}
+					if !want[post.GetPostID()] {
+						t.Errorf("post %s should not be listed", post.GetPostID())
+					}
+					seen[post.GetPostID()] = true
+					// 按 createdAt 倒序排列
+					if last != nil && post.GetCreatedAt().AsTime().After(last.GetCreatedAt().AsTime()) {
+						t.Errorf("post %s is listed after a newer post %s", post.GetPostID(), last.GetPostID())
+					}
+					last = post
+				}
+				if token = resp.GetNextPageToken(); token == "" {
+					break
+				}
+			}
+			if len(seen) != len(want) {
+				t.Errorf("listed %d posts, want %d", len(seen), len(want))
+			}
+		})
+	}
+}
+
+func TestUpdateTags(t *testing.T) {
+	ctx := contextx.WithUserID(context.Background(), "user-a")
+	b, _ := newTestBiz(t)
+
+	tests := []struct {
+		name string
+		rq   *apiv1.UpdatePostRequest
+		want []string
+	}{
+		{"tags not set", &apiv1.UpdatePostRequest{Title: ptr("changed")}, []string{"db", "go"}},
+		{"replace tags", &apiv1.UpdatePostRequest{Tags: list("rust")}, []string{"rust"}},
+		// 与其他字段一致，显式设置为空列表时清空标签
+		{"clear with empty list", &apiv1.UpdatePostRequest{Tags: list()}, nil},
+		{"clear with update mask", &apiv1.UpdatePostRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tags"}}}, nil},
+		{"tags not in update mask", &apiv1.UpdatePostRequest{
+			Title:      ptr("changed"),
+			Tags:       list(),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+		}, []string{"db", "go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := b.Create(ctx, &apiv1.CreatePostRequest{Title: "title", Content: "content", Tags: []string{"go", "db"}})
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			tt.rq.PostID = created.GetPostID()
+			if _, err := b.Update(ctx, tt.rq); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+
+			got, err := b.Get(ctx, &apiv1.GetPostRequest{PostID: created.GetPostID()})
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if tags := got.GetPost().GetTags(); !slices.Equal(tags, tt.want) {
+				t.Errorf("tags after Update() = %q, want %q", tags, tt.want)
+			}
+		})
+	}
+
+	created, err := b.Create(ctx, &apiv1.CreatePostRequest{Title: "title", Content: "content"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	invalid := &structpb.ListValue{Values: []*structpb.Value{structpb.NewNumberValue(1)}}
+	if _, err := b.Update(ctx, &apiv1.UpdatePostRequest{PostID: created.GetPostID(), Tags: invalid}); err == nil {
+		t.Error("Update() with a non-string tag should fail")
+	}
+}
+
+func TestUpdateReturnsEtag(t *testing.T) {
+	ctx := contextx.WithUserID(context.Background(), "user-a")
+	b, _ := newTestBiz(t)
+
+	created, err := b.Create(ctx, &apiv1.CreatePostRequest{Title: "title", Content: "content"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	got, err := b.Get(ctx, &apiv1.GetPostRequest{PostID: created.GetPostID()})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	old := got.GetPost().GetEtag()
+
+	resp, err := b.Update(ctx, &apiv1.UpdatePostRequest{PostID: created.GetPostID(), Title: ptr("changed"), Etag: old})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if resp.GetEtag() == "" || resp.GetEtag() == old {
+		t.Fatalf("Update() etag = %q, want a new etag other than %q", resp.GetEtag(), old)
+	}
+	if got, err := b.Get(ctx, &apiv1.GetPostRequest{PostID: created.GetPostID()}); err != nil || got.GetPost().GetEtag() != resp.GetEtag() {
+		t.Errorf("Get() etag = %q, %v, want %q", got.GetPost().GetEtag(), err, resp.GetEtag())
+	}
+
+	// 旧的 etag 失效，返回的 etag 可以用于下一次更新
+	if _, err := b.Update(ctx, &apiv1.UpdatePostRequest{PostID: created.GetPostID(), Title: ptr("again"), Etag: old}); !errors.Is(err, errno.ErrEtagMismatch) {
+		t.Errorf("Update() with the old etag error = %v, want %v", err, errno.ErrEtagMismatch)
+	}
+	next, err := b.Update(ctx, &apiv1.UpdatePostRequest{PostID: created.GetPostID(), Title: ptr("again"), Etag: resp.GetEtag()})
+	if err != nil {
+		t.Fatalf("Update() with the returned etag: %v", err)
+	}
+	// 没有需要更新的字段时返回当前的 etag
+	if noop, err := b.Update(ctx, &apiv1.UpdatePostRequest{PostID: created.GetPostID()}); err != nil || noop.GetEtag() != next.GetEtag() {
+		t.Errorf("Update() without changes = %q, %v, want %q", noop.GetEtag(), err, next.GetEtag())
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// list 返回由 names 组成的 ListValue.
+func list(names ...string) *structpb.ListValue {
+	values := make([]*structpb.Value, 0, len(names))
+	for _, name := range names {
+		values = append(values, structpb.NewStringValue(name))
+	}
+	return &structpb.ListValue{Values: values}
+}
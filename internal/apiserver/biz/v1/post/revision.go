@@ -0,0 +1,184 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/event"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/etag"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"gorm.io/gorm/clause"
+)
+
+// diffContext 为 unified diff 中每处修改前后保留的上下文行数.
+const diffContext = 3
+
+// ListRevisions 列出当前用户某篇博客的修订历史，列表中不包含修订的内容.
+func (b *postBiz) ListRevisions(ctx context.Context, rq *apiv1.ListPostRevisionsRequest) (*apiv1.ListPostRevisionsResponse, error) {
+	if err := b.val.ValidateListPostRevisionsRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if _, err := b.getPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	whr := where.F("postID", rq.GetPostID()).O(int(rq.GetOffset())).L(int(rq.GetLimit()))
+	count, revisionList, err := b.store.PostRevision().List(ctx, whr)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	revisions := make([]*apiv1.PostRevision, 0, len(revisionList))
+	for _, revisionM := range revisionList {
+		revision := conversion.PostRevisionModelToPostRevisionV1(revisionM)
+		revision.Content = ""
+		revisions = append(revisions, revision)
+	}
+	return &apiv1.ListPostRevisionsResponse{TotalCount: count, Revisions: revisions}, nil
+}
+
+// GetRevision 获取当前用户某篇博客的某个修订.
+func (b *postBiz) GetRevision(ctx context.Context, rq *apiv1.GetPostRevisionRequest) (*apiv1.GetPostRevisionResponse, error) {
+	if err := b.val.ValidateGetPostRevisionRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if _, err := b.getPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	revisionM, err := b.getRevision(ctx, where.F("postID", rq.GetPostID(), "revision", rq.GetRevision()))
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetPostRevisionResponse{Revision: conversion.PostRevisionModelToPostRevisionV1(revisionM)}, nil
+}
+
+// Revert 将当前用户的博客回滚到某个修订的标题和内容.
+// 回滚不会删除之后的修订，而是产生一个新的修订，因此回滚本身也可以被回滚.
+func (b *postBiz) Revert(ctx context.Context, rq *apiv1.RevertPostRequest) (*apiv1.RevertPostResponse, error) {
+	if err := b.val.ValidateRevertPostRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	postM, err := b.getPost(ctx, rq.GetPostID())
+	if err != nil {
+		return nil, err
+	}
+	if rq.GetEtag() != "" && !etag.Match(rq.GetEtag(), postM.Version) {
+		return nil, errno.ErrEtagMismatch
+	}
+	revisionM, err := b.getRevision(ctx, where.F("postID", rq.GetPostID(), "revision", rq.GetRevision()))
+	if err != nil {
+		return nil, err
+	}
+
+	postM.Title, postM.Content = revisionM.Title, revisionM.Content
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.Post().UpdateColumns(ctx, postM, "title", "content"); err != nil {
+			return err
+		}
+		if err := b.saveRevision(ctx, postM); err != nil {
+			return err
+		}
+		return b.publish(ctx, event.PostUpdated, postM)
+	})
+	if errors.Is(err, store.ErrVersionConflict) {
+		return nil, errno.ErrEtagMismatch
+	}
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return &apiv1.RevertPostResponse{Revision: postM.Version}, nil
+}
+
+// Diff 返回当前用户某篇博客两个修订之间的 unified diff.
+func (b *postBiz) Diff(ctx context.Context, rq *apiv1.DiffPostRevisionsRequest) (*apiv1.DiffPostRevisionsResponse, error) {
+	if err := b.val.ValidateDiffPostRevisionsRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if _, err := b.getPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	// to 为 0 时取最新的修订
+	whr := where.F("postID", rq.GetPostID())
+	if rq.GetTo() != 0 {
+		whr = whr.F("revision", rq.GetTo())
+	}
+	to, err := b.getRevision(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
+	// from 为 0 时取 to 的上一个修订
+	var from *model.PostRevisionM
+	if rq.GetFrom() != 0 {
+		if rq.GetFrom() >= to.Revision {
+			return nil, errorsx.ErrInvalidArgument.WithMessage("from must be less than to")
+		}
+		from, err = b.getRevision(ctx, where.F("postID", rq.GetPostID(), "revision", rq.GetFrom()))
+	} else {
+		from, err = b.getRevision(ctx, where.F("postID", rq.GetPostID()).
+			C(clause.Lt{Column: clause.Column{Name: "revision"}, Value: to.Revision}))
+		// to 为第一个修订时与空文本比较
+		if errors.Is(err, errno.ErrPostRevisionNotFound) {
+			from, err = &model.PostRevisionM{}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        revisionLines(from),
+		B:        revisionLines(to),
+		FromFile: fmt.Sprintf("revision %d", from.Revision),
+		ToFile:   fmt.Sprintf("revision %d", to.Revision),
+		Context:  diffContext,
+	})
+	if err != nil {
+		return nil, errorsx.ErrInternal.WithMessage("%s", err.Error())
+	}
+	return &apiv1.DiffPostRevisionsResponse{From: from.Revision, To: to.Revision, Diff: diff}, nil
+}
+
+// saveRevision 保存博客当前标题和内容的快照，修订号为博客当前的版本号.
+// 需要在修改博客的同一个事务中调用.
+func (b *postBiz) saveRevision(ctx context.Context, postM *model.PostM) error {
+	return b.store.PostRevision().Create(ctx, &model.PostRevisionM{
+		PostID:   postM.PostID,
+		Revision: postM.Version,
+		UserID:   contextx.UserID(ctx),
+		Title:    postM.Title,
+		Content:  postM.Content,
+	})
+}
+
+// getRevision 查询最新的满足 whr 的修订，并将记录不存在的错误转换为 errno.ErrPostRevisionNotFound.
+func (b *postBiz) getRevision(ctx context.Context, whr *where.Options) (*model.PostRevisionM, error) {
+	_, revisions, err := b.store.PostRevision().List(ctx, whr.L(1))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	if len(revisions) == 0 {
+		return nil, errno.ErrPostRevisionNotFound
+	}
+	return revisions[0], nil
+}
+
+// revisionLines 返回用于比较修订的文本行，第一行为标题，空一行后为内容. 修订号为 0 表示空文本.
+func revisionLines(revisionM *model.PostRevisionM) []string {
+	if revisionM.Revision == 0 {
+		return nil
+	}
+	return difflib.SplitLines(revisionM.Title + "\n\n" + revisionM.Content)
+}
@@ -0,0 +1,76 @@
+package post
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/apiserver/search"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+// defaultSearchLimit 为未指定 limit 时全文检索每页的数量.
+const defaultSearchLimit = 10
+
+// Search 在所有已发布的博客中全文检索，结果按相关度倒序排列.
+// 命中的博客从数据库中重新读取，索引尚未同步的已删除或未发布博客不会被返回.
+func (b *postBiz) Search(ctx context.Context, rq *apiv1.SearchPostsRequest) (*apiv1.SearchPostsResponse, error) {
+	if err := b.val.ValidateSearchPostsRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	limit := rq.GetLimit()
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	res, err := b.index.Search(ctx, &search.Request{Query: rq.GetQuery(), Offset: int(rq.GetOffset()), Limit: int(limit)})
+	if err != nil {
+		if errors.Is(err, search.ErrInvalidQuery) {
+			return nil, errorsx.ErrInvalidArgument.WithMessage("%s", err.Error())
+		}
+		return nil, errorsx.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if len(res.Hits) == 0 {
+		return &apiv1.SearchPostsResponse{TotalCount: int64(res.Total)}, nil
+	}
+
+	ids := make([]string, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.PostID)
+	}
+	_, postList, err := b.store.Post().List(ctx, where.F("postID", ids, "status", model.PostStatusPublished))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	posts := make([]*apiv1.Post, 0, len(postList))
+	for _, postM := range postList {
+		posts = append(posts, conversion.PostModelToPostV1(postM))
+	}
+	if err := b.setTaxonomy(ctx, posts, postList, nil); err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*apiv1.Post, len(posts))
+	for _, post := range posts {
+		byID[post.GetPostID()] = post
+	}
+
+	hits := make([]*apiv1.PostSearchHit, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		post, ok := byID[hit.PostID]
+		if !ok {
+			continue
+		}
+		hits = append(hits, &apiv1.PostSearchHit{
+			Post:              post,
+			Score:             hit.Score,
+			TitleHighlights:   hit.Fragments["title"],
+			ContentHighlights: hit.Fragments["content"],
+		})
+	}
+	return &apiv1.SearchPostsResponse{TotalCount: int64(res.Total), Hits: hits}, nil
+}
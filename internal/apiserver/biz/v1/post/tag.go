@@ -0,0 +1,183 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"gorm.io/gorm"
+)
+
+// saveTags 将博客的标签替换为 names，不存在的标签会被创建，需要在事务中调用.
+func (b *postBiz) saveTags(ctx context.Context, postID string, names []string) error {
+	if err := b.store.PostTag().Delete(ctx, where.F("postID", postID)); err != nil {
+		return err
+	}
+
+	names = normalizeNames(names)
+	if len(names) == 0 {
+		return nil
+	}
+	tags := make([]*model.TagM, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, &model.TagM{Name: name})
+	}
+	if err := b.store.Tag().CreateIfNotExist(ctx, tags); err != nil {
+		return err
+	}
+	_, tags, err := b.store.Tag().List(ctx, where.F("name", names))
+	if err != nil {
+		return err
+	}
+
+	postTags := make([]*model.PostTagM, 0, len(tags))
+	for _, tag := range tags {
+		postTags = append(postTags, &model.PostTagM{PostID: postID, TagID: tag.ID})
+	}
+	return b.store.PostTag().CreateInBatches(ctx, postTags, len(postTags))
+}
+
+// categoryID 返回分类 name 的 ID，不存在时创建该分类，name 为空时返回 0 表示未分类.
+func (b *postBiz) categoryID(ctx context.Context, name string) (int64, error) {
+	name = normalizeName(name)
+	if name == "" {
+		return 0, nil
+	}
+	if err := b.store.Category().CreateIfNotExist(ctx, &model.CategoryM{Name: name}); err != nil {
+		return 0, err
+	}
+	category, err := b.store.Category().Get(ctx, where.F("name", name))
+	if err != nil {
+		return 0, err
+	}
+	return category.ID, nil
+}
+
+// taxonomyScope 为 whr 增加按标签和分类过滤的条件，标签或分类不存在时返回 false，即没有满足条件的博客.
+func (b *postBiz) taxonomyScope(ctx context.Context, whr *where.Options, tag string, category string) (*where.Options, bool, error) {
+	if tag != "" {
+		tagM, err := b.store.Tag().Get(ctx, where.F("name", normalizeName(tag)))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return whr, false, nil
+		}
+		if err != nil {
+			return nil, false, errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+		whr = whr.C(store.PostTagged(tagM.ID))
+	}
+	if category != "" {
+		categoryM, err := b.store.Category().Get(ctx, where.F("name", normalizeName(category)))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return whr, false, nil
+		}
+		if err != nil {
+			return nil, false, errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+		whr = whr.F("categoryID", categoryM.ID)
+	}
+	return whr, true, nil
+}
+
+// setTaxonomy 设置 posts 的标签和分类，posts 与 postList 一一对应.
+// 设置了 readMask 时只查询其中包含的字段.
+func (b *postBiz) setTaxonomy(ctx context.Context, posts []*apiv1.Post, postList []*model.PostM, paths []string) error {
+	if fieldmask.Has(paths, "tags", true) {
+		if err := b.setTags(ctx, posts, postList); err != nil {
+			return errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+	}
+	if fieldmask.Has(paths, "category", true) {
+		if err := b.setCategories(ctx, posts, postList); err != nil {
+			return errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+	}
+	return nil
+}
+
+// setTags 查询博客的标签，每篇博客的标签按标签名排列.
+func (b *postBiz) setTags(ctx context.Context, posts []*apiv1.Post, postList []*model.PostM) error {
+	postIDs := make([]string, 0, len(postList))
+	for _, postM := range postList {
+		postIDs = append(postIDs, postM.PostID)
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+	_, postTags, err := b.store.PostTag().List(ctx, where.F("postID", postIDs))
+	if err != nil || len(postTags) == 0 {
+		return err
+	}
+
+	tagIDs := make([]int64, 0, len(postTags))
+	for _, postTag := range postTags {
+		tagIDs = append(tagIDs, postTag.TagID)
+	}
+	_, tags, err := b.store.Tag().List(ctx, where.F("id", tagIDs))
+	if err != nil {
+		return err
+	}
+	names := make(map[int64]string, len(tags))
+	for _, tag := range tags {
+		names[tag.ID] = tag.Name
+	}
+
+	byPost := make(map[string][]string, len(postIDs))
+	for _, postTag := range postTags {
+		if name, ok := names[postTag.TagID]; ok {
+			byPost[postTag.PostID] = append(byPost[postTag.PostID], name)
+		}
+	}
+	for i, post := range posts {
+		post.Tags = byPost[postList[i].PostID]
+		slices.Sort(post.Tags)
+	}
+	return nil
+}
+
+// setCategories 查询博客的分类名.
+func (b *postBiz) setCategories(ctx context.Context, posts []*apiv1.Post, postList []*model.PostM) error {
+	var ids []int64
+	for _, postM := range postList {
+		if postM.CategoryID != 0 {
+			ids = append(ids, postM.CategoryID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	_, categories, err := b.store.Category().List(ctx, where.F("id", ids))
+	if err != nil {
+		return err
+	}
+	names := make(map[int64]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+	for i, post := range posts {
+		post.Category = names[postList[i].CategoryID]
+	}
+	return nil
+}
+
+// normalizeNames 规范化标签名并去除重复的标签.
+func normalizeNames(names []string) []string {
+	ret := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = normalizeName(name); name != "" && !slices.Contains(ret, name) {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// normalizeName 规范化标签名和分类名：去除首尾空白并转换为小写.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,32 @@
+package post
+
+import (
+	"context"
+
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// countView 记录一次已发布博客的浏览，并在返回的浏览数中加上尚未写入数据库的浏览次数.
+// 浏览次数只是统计数据，记录失败时不影响读取博客.
+func (b *postBiz) countView(ctx context.Context, post *apiv1.Post, postM *model.PostM, paths []string) {
+	if postM.Status != model.PostStatusPublished {
+		return
+	}
+	if err := b.views.Incr(ctx, postM.PostID); err != nil {
+		log.W(ctx).Warnw("Failed to count post view", "err", err, "postID", postM.PostID)
+		return
+	}
+
+	if !fieldmask.Has(paths, "viewCount", true) {
+		return
+	}
+	n, err := b.views.Get(ctx, postM.PostID)
+	if err != nil {
+		log.W(ctx).Warnw("Failed to read buffered post views", "err", err, "postID", postM.PostID)
+		return
+	}
+	post.ViewCount += n
+}
@@ -0,0 +1,49 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/validation"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// TagBiz 定义处理标签请求所需的方法.
+type TagBiz interface {
+	List(ctx context.Context, rq *apiv1.ListTagsRequest) (*apiv1.ListTagsResponse, error)
+}
+
+// tagBiz 是 TagBiz 接口的实现.
+// 标签在创建和更新博客时自动创建，只统计已发布的博客，因此所有用户看到的结果相同.
+type tagBiz struct {
+	store store.IStore
+	val   *validation.Validator
+}
+
+// 确保 tagBiz 实现了 TagBiz 接口.
+var _ TagBiz = (*tagBiz)(nil)
+
+// New 创建一个 tagBiz 实例.
+func New(store store.IStore, val *validation.Validator) *tagBiz {
+	return &tagBiz{store: store, val: val}
+}
+
+// List 列出有已发布博客的标签及其博客数，按博客数倒序排列.
+func (b *tagBiz) List(ctx context.Context, rq *apiv1.ListTagsRequest) (*apiv1.ListTagsResponse, error) {
+	if err := b.val.ValidateListTagsRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	count, tagList, err := b.store.Tag().ListPostCounts(ctx, where.NewWhere().O(int(rq.GetOffset())).L(int(rq.GetLimit())))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	tags := make([]*apiv1.Tag, 0, len(tagList))
+	for _, tag := range tagList {
+		tags = append(tags, &apiv1.Tag{Name: tag.Name, PostCount: tag.PostCount})
+	}
+	return &apiv1.ListTagsResponse{TotalCount: count, Tags: tags}, nil
+}
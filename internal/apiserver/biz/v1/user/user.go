@@ -0,0 +1,416 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/onexstack/onexstack/pkg/token"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/event"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/validation"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/authn"
+	"github.com/wshadm/miniblog/internal/pkg/authz"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/etag"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	"github.com/wshadm/miniblog/internal/pkg/filtering"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"github.com/wshadm/miniblog/internal/pkg/pagetoken"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// userPageScope 为用户列表分页令牌作用范围的前缀.
+const userPageScope = "user"
+
+// userFields 为用户列表允许过滤和排序的字段.
+var userFields = filtering.Fields{
+	"userID":    {Column: "userID", Type: filtering.String},
+	"username":  {Column: "username", Type: filtering.String},
+	"nickname":  {Column: "nickname", Type: filtering.String},
+	"email":     {Column: "email", Type: filtering.String},
+	"phone":     {Column: "phone", Type: filtering.String},
+	"createdAt": {Column: "createdAt", Type: filtering.Time},
+	"updatedAt": {Column: "updatedAt", Type: filtering.Time},
+}
+
+// UserBiz 定义处理用户请求所需的方法.
+type UserBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*apiv1.CreateUserResponse, error)
+	Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*apiv1.UpdateUserResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeleteUserRequest) (*apiv1.DeleteUserResponse, error)
+	Get(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.GetUserResponse, error)
+	List(ctx context.Context, rq *apiv1.ListUsersRequest) (*apiv1.ListUsersResponse, error)
+
+	UserExpansion
+}
+
+// UserExpansion 定义用户操作的扩展方法.
+type UserExpansion interface {
+	Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error)
+	RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error)
+	ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error)
+}
+
+// userBiz 是 UserBiz 接口的实现.
+type userBiz struct {
+	store store.IStore
+	val   *validation.Validator
+	authz *authz.Authz
+}
+
+// 确保 userBiz 实现了 UserBiz 接口.
+var _ UserBiz = (*userBiz)(nil)
+
+// New 创建一个 userBiz 实例.
+func New(store store.IStore, val *validation.Validator, authz *authz.Authz) *userBiz {
+	return &userBiz{store: store, val: val, authz: authz}
+}
+
+// Login 校验用户名和密码，成功后签发 JWT Token.
+func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error) {
+	if err := b.val.ValidateLoginRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("username", rq.GetUsername()))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	// 对比传入的明文密码和数据库中加密后的密码
+	if err := authn.Compare(userM.Password, rq.GetPassword()); err != nil {
+		log.W(ctx).Errorw("Failed to compare password", "err", err)
+		return nil, errno.ErrPasswordInvalid
+	}
+
+	tokenStr, expireAt, err := token.Sign(userM.UserID)
+	if err != nil {
+		return nil, errno.ErrSignToken.WithMessage("%s", err.Error())
+	}
+	return &apiv1.LoginResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+}
+
+// RefreshToken 为当前登录用户签发新的 JWT Token.
+func (b *userBiz) RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error) {
+	tokenStr, expireAt, err := token.Sign(contextx.UserID(ctx))
+	if err != nil {
+		return nil, errno.ErrSignToken.WithMessage("%s", err.Error())
+	}
+	return &apiv1.RefreshTokenResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+}
+
+// ChangePassword 校验旧密码后修改用户密码.
+func (b *userBiz) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error) {
+	if err := b.val.ValidateChangePasswordRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if err := b.authorize(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	// 缓存中的用户不包含密码，在事务中查询时直接读取数据库
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		userM, err := b.getUser(ctx, rq.GetUserID())
+		if err != nil {
+			return err
+		}
+		if err := authn.Compare(userM.Password, rq.GetOldPassword()); err != nil {
+			log.W(ctx).Errorw("Failed to compare password", "err", err)
+			return errno.ErrPasswordInvalid
+		}
+
+		// 新密码会在 UserM 的 BeforeUpdate 钩子中加密
+		userM.Password = rq.GetNewPassword()
+		if err := b.store.User().UpdateColumns(ctx, userM, "password"); err != nil {
+			if errors.Is(err, store.ErrVersionConflict) {
+				return errno.ErrEtagMismatch
+			}
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		if err := b.publish(ctx, event.UserPasswordChanged, userM); err != nil {
+			return errno.ErrDBWrite.WithMessage("%s", err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.ChangePasswordResponse{}, nil
+}
+
+// Create 创建新用户.
+func (b *userBiz) Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*apiv1.CreateUserResponse, error) {
+	if err := b.val.ValidateCreateUserRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	// 密码会在 UserM 的 BeforeCreate 钩子中加密
+	userM := model.UserM{
+		Username: rq.GetUsername(),
+		Password: rq.GetPassword(),
+		Nickname: rq.GetNickname(),
+		Email:    rq.GetEmail(),
+		Phone:    rq.GetPhone(),
+	}
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.User().Create(ctx, &userM); err != nil {
+			return err
+		}
+		return b.publish(ctx, event.UserCreated, &userM)
+	})
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+
+	// 新用户默认授予普通用户角色
+	if err := b.authz.AddUser(userM.UserID); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("User created", "userID", userM.UserID)
+	return &apiv1.CreateUserResponse{UserID: userM.UserID}, nil
+}
+
+// Update 更新用户信息，只能更新当前登录用户自己的信息.
+func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*apiv1.UpdateUserResponse, error) {
+	if err := b.val.ValidateUpdateUserRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if err := b.authorize(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.getUser(ctx, rq.GetUserID())
+	if err != nil {
+		return nil, err
+	}
+	if rq.GetEtag() != "" && !etag.Match(rq.GetEtag(), userM.Version) {
+		return nil, errno.ErrEtagMismatch
+	}
+
+	// 设置了 updateMask 时只更新其中的字段，否则只更新请求中显式设置的字段.
+	// updateMask 已经在校验阶段检查过，这里不会出错
+	paths, _ := conversion.UserUpdateFields.Paths(rq.GetUpdateMask())
+	var columns []string
+	// 修改用户名时需要保证新用户名未被占用，被软删除的用户仍然占用用户名
+	if fieldmask.Has(paths, "username", rq.Username != nil) && rq.GetUsername() != userM.Username {
+		exists, err := b.store.User().Exists(ctx, where.F("username", rq.GetUsername()))
+		if err != nil {
+			return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+		if exists {
+			return nil, errno.ErrUserAlreadyExists
+		}
+		userM.Username = rq.GetUsername()
+		columns = append(columns, "username")
+	}
+	if fieldmask.Has(paths, "nickname", rq.Nickname != nil) {
+		userM.Nickname = rq.GetNickname()
+		columns = append(columns, "nickname")
+	}
+	if fieldmask.Has(paths, "email", rq.Email != nil) {
+		userM.Email = rq.GetEmail()
+		columns = append(columns, "email")
+	}
+	if fieldmask.Has(paths, "phone", rq.Phone != nil) {
+		userM.Phone = rq.GetPhone()
+		columns = append(columns, "phone")
+	}
+	if len(columns) == 0 {
+		return &apiv1.UpdateUserResponse{Etag: etag.Format(userM.Version)}, nil
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.User().UpdateColumns(ctx, userM, columns...); err != nil {
+			return err
+		}
+		return b.publish(ctx, event.UserUpdated, userM)
+	})
+	if errors.Is(err, store.ErrVersionConflict) {
+		return nil, errno.ErrEtagMismatch
+	}
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return &apiv1.UpdateUserResponse{Etag: etag.Format(userM.Version)}, nil
+}
+
+// Delete 软删除用户，并在同一个事务中将该用户的全部博客移入回收站，同时写入对应的删除事件.
+// 用户和博客在回收站保留期限过后被永久删除，在此之前用户名和手机号不能被重新注册.
+func (b *userBiz) Delete(ctx context.Context, rq *apiv1.DeleteUserRequest) (*apiv1.DeleteUserResponse, error) {
+	if err := b.val.ValidateDeleteUserRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if err := b.authorize(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, posts, err := b.store.Post().List(ctx, where.F("userID", rq.GetUserID()))
+		if err != nil {
+			return err
+		}
+		events := make([]*model.OutboxM, 0, len(posts)+1)
+		for _, postM := range posts {
+			evt, err := event.NewPostEvent(event.PostDeleted, postM)
+			if err != nil {
+				return err
+			}
+			events = append(events, evt)
+		}
+		evt, err := event.NewUserEvent(event.UserDeleted, userM)
+		if err != nil {
+			return err
+		}
+		events = append(events, evt)
+
+		if err := b.store.Post().Delete(ctx, where.F("userID", rq.GetUserID())); err != nil {
+			return err
+		}
+		if err := b.store.User().Delete(ctx, where.F("userID", rq.GetUserID())); err != nil {
+			return err
+		}
+		return b.store.Outbox().CreateInBatches(ctx, events, len(events))
+	})
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+
+	if err := b.authz.RemoveUser(rq.GetUserID()); err != nil {
+		log.W(ctx).Errorw("Failed to remove user roles", "userID", rq.GetUserID(), "err", err)
+	}
+	return &apiv1.DeleteUserResponse{}, nil
+}
+
+// Get 获取用户信息.
+func (b *userBiz) Get(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.GetUserResponse, error) {
+	if err := b.val.ValidateGetUserRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+	if err := b.authorize(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	// 只查询 readMask 中的字段对应的列
+	paths, _ := conversion.UserReadFields.Paths(rq.GetReadMask())
+	userM, err := b.getUser(ctx, rq.GetUserID(), conversion.UserReadFields.Select(paths, "id")...)
+	if err != nil {
+		return nil, err
+	}
+
+	user := conversion.UserModelToUserV1(userM)
+	fieldmask.Prune(user, paths)
+	return &apiv1.GetUserResponse{User: user}, nil
+}
+
+// List 列出用户.
+func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUsersRequest) (*apiv1.ListUsersResponse, error) {
+	if err := b.val.ValidateListUsersRequest(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	conds, err := userFields.Filter(rq.GetFilter())
+	if err != nil {
+		return nil, errorsx.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+	order, err := userFields.OrderBy(rq.GetOrderBy())
+	if err != nil {
+		return nil, errorsx.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+
+	// 游标分页需要 id 和 createdAt 列
+	paths, _ := conversion.UserReadFields.Paths(rq.GetReadMask())
+	sel := conversion.UserReadFields.Select(paths, "id", "createdAt")
+
+	whr := where.NewWhere(where.WithLimit(rq.GetLimit()), where.WithClauses(conds...), where.WithClauses(order...), where.WithClauses(sel...))
+	// 分页令牌只能用于相同过滤条件的查询
+	scope := userPageScope + "\x00" + rq.GetFilter()
+	offset := rq.GetOffset()
+	var countWhr *where.Options
+	if rq.GetPageToken() != "" {
+		cursor, err := pagetoken.Decode(rq.GetPageToken(), scope)
+		if err != nil {
+			return nil, errno.ErrPageTokenInvalid
+		}
+		// 总数不包含游标条件，与偏移分页返回的总数一致
+		countWhr = &where.Options{Filters: whr.Filters, Clauses: slices.Clone(whr.Clauses), Queries: slices.Clone(whr.Queries)}
+		whr, offset = whr.C(pagetoken.After(cursor)), 0
+	}
+	whr = whr.O(int(offset))
+
+	count, userList, err := b.store.User().List(ctx, whr)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	totalCount := count
+	if countWhr != nil {
+		if totalCount, err = b.store.User().Count(ctx, countWhr); err != nil {
+			return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+		}
+	}
+
+	users := make([]*apiv1.User, 0, len(userList))
+	for _, userM := range userList {
+		user := conversion.UserModelToUserV1(userM)
+		fieldmask.Prune(user, paths)
+		users = append(users, user)
+	}
+
+	var nextPageToken string
+	if rq.GetOrderBy() == "" && len(userList) > 0 && offset+int64(len(userList)) < count {
+		last := userList[len(userList)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, scope)
+	}
+	return &apiv1.ListUsersResponse{TotalCount: totalCount, Users: users, NextPageToken: nextPageToken}, nil
+}
+
+// getUser 根据 userID 查询用户，并将记录不存在的错误转换为 errno.ErrUserNotFound.
+// conds 为额外的查询子句，例如只查询部分列.
+func (b *userBiz) getUser(ctx context.Context, userID string, conds ...clause.Expression) (*model.UserM, error) {
+	userM, err := b.store.User().Get(ctx, where.F("userID", userID).C(conds...))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return userM, nil
+}
+
+// authorize 校验当前请求的用户是否有权操作 userID 对应的用户.
+// 用户只能操作自己，管理员可以操作所有用户.
+func (b *userBiz) authorize(ctx context.Context, userID string) error {
+	if contextx.UserID(ctx) != userID && !b.authz.IsAdmin(contextx.UserID(ctx)) {
+		return errorsx.ErrPermissionDenied
+	}
+	return nil
+}
+
+// publish 将用户事件写入发件箱，需要在写入用户数据的同一个事务中调用.
+func (b *userBiz) publish(ctx context.Context, eventType string, userM *model.UserM) error {
+	evt, err := event.NewUserEvent(eventType, userM)
+	if err != nil {
+		return err
+	}
+	return b.store.Outbox().Create(ctx, evt)
+}
@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/redis/go-redis/v9"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/validation"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/authn"
+	"github.com/wshadm/miniblog/internal/pkg/authz"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/options"
+)
+
+// newTestBiz 创建使用内存存储和 miniredis 缓存的 userBiz，授权策略保存在内存 SQLite 中.
+func newTestBiz(t *testing.T) (*userBiz, store.IStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	opts := options.NewSQLiteOptions()
+	opts.Path = ":memory:"
+	db, err := opts.NewDB()
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	a, err := authz.NewAuthz(db, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create authz: %v", err)
+	}
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	s := store.NewCachedStore(store.NewMemoryStore(), rdb, time.Minute)
+	return New(s, validation.New(s), a), s, mr
+}
+
+// TestChangePasswordWithCachedUser 检查缓存中不保存密码哈希，用户被缓存后仍可以修改密码.
+func TestChangePasswordWithCachedUser(t *testing.T) {
+	b, s, mr := newTestBiz(t)
+	created, err := b.Create(context.Background(), &apiv1.CreateUserRequest{
+		Username: "alice",
+		Password: "miniblog1234",
+		Nickname: ptr("alice"),
+		Email:    "alice@qq.com",
+		Phone:    "18110000001",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	userID := created.GetUserID()
+	ctx := contextx.WithUserID(context.Background(), userID)
+
+	// 读取用户后用户被写入缓存
+	if _, err := b.Get(ctx, &apiv1.GetUserRequest{UserID: userID}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	cached, err := mr.Get("miniblog:user:" + userID)
+	if err != nil {
+		t.Fatalf("user is not cached: %v", err)
+	}
+	if strings.Contains(cached, "$2a$") || !strings.Contains(cached, `"password":""`) {
+		t.Errorf("cached user = %s, want no password hash", cached)
+	}
+
+	rq := &apiv1.ChangePasswordRequest{UserID: userID, OldPassword: "wrong-password", NewPassword: "miniblog5678"}
+	if _, err := b.ChangePassword(ctx, rq); !errors.Is(err, errno.ErrPasswordInvalid) {
+		t.Errorf("ChangePassword() with a wrong password error = %v, want %v", err, errno.ErrPasswordInvalid)
+	}
+	rq.OldPassword = "miniblog1234"
+	if _, err := b.ChangePassword(ctx, rq); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+
+	// 事务中读取数据库中的用户，校验密码已经修改
+	err = s.TX(ctx, func(ctx context.Context) error {
+		u, err := s.User().Get(ctx, where.F("userID", userID))
+		if err != nil {
+			return err
+		}
+		return authn.Compare(u.Password, "miniblog5678")
+	})
+	if err != nil {
+		t.Errorf("stored password does not match the new password: %v", err)
+	}
+	if _, err := b.ChangePassword(ctx, rq); !errors.Is(err, errno.ErrPasswordInvalid) {
+		t.Errorf("ChangePassword() with the old password error = %v, want %v", err, errno.ErrPasswordInvalid)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
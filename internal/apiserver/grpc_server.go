@@ -3,6 +3,8 @@ package apiserver
 import (
 	"context"
 
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	handler "github.com/wshadm/miniblog/internal/apiserver/handler/grpc"
 	mw "github.com/wshadm/miniblog/internal/pkg/middleware/grpc"
@@ -30,6 +32,14 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		grpc.ChainUnaryInterceptor(
 			//请求ID拦截器
 			mw.RequestIDInterceptor(),
+			//If-Match 拦截器，用于乐观并发控制
+			mw.IfMatchInterceptor(),
+			//认证拦截器，白名单中和允许匿名访问的接口无需认证
+			selector.UnaryServerInterceptor(mw.AuthnInterceptor(), NewAuthnMatcher()),
+			//可选认证拦截器，允许匿名访问的接口在携带了令牌时仍然需要认证
+			selector.UnaryServerInterceptor(mw.OptionalAuthnInterceptor(), NewAnonymousMatcher()),
+			//授权拦截器，白名单中的接口无需授权，匿名请求按匿名用户授权
+			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthnWhiteListMatcher()),
 		),
 	}
 	//创建grpc服务器
@@ -37,7 +47,7 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		c.cfg.GRPCOptions,
 		serverOptions,
 		func(s grpc.ServiceRegistrar) {
-			apiv1.RegisterMiniBlogServer(s, handler.NewHandler())
+			apiv1.RegisterMiniBlogServer(s, handler.NewHandler(c.biz))
 		})
 
 	if err != nil {
@@ -69,6 +79,45 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 	}, nil
 }
 
+// NewAuthnWhiteListMatcher 创建认证白名单匹配器，白名单中的接口不执行认证拦截器.
+func NewAuthnWhiteListMatcher() selector.Matcher {
+	whitelist := map[string]struct{}{
+		apiv1.MiniBlog_Healthz_FullMethodName:    {},
+		apiv1.MiniBlog_CreateUser_FullMethodName: {},
+		apiv1.MiniBlog_Login_FullMethodName:      {},
+	}
+	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
+		_, ok := whitelist[call.FullMethod()]
+		return !ok
+	})
+}
+
+// anonymousMethods 为允许匿名访问的接口，匿名用户只能读取和检索已发布的博客及其评论和标签.
+var anonymousMethods = map[string]struct{}{
+	apiv1.MiniBlog_GetPost_FullMethodName:      {},
+	apiv1.MiniBlog_ListPost_FullMethodName:     {},
+	apiv1.MiniBlog_ListComments_FullMethodName: {},
+	apiv1.MiniBlog_ListTags_FullMethodName:     {},
+	apiv1.MiniBlog_SearchPosts_FullMethodName:  {},
+}
+
+// NewAuthnMatcher 创建需要强制认证的接口匹配器，即不在白名单中且不允许匿名访问的接口.
+func NewAuthnMatcher() selector.Matcher {
+	whitelist := NewAuthnWhiteListMatcher()
+	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
+		_, ok := anonymousMethods[call.FullMethod()]
+		return !ok && whitelist.Match(ctx, call)
+	})
+}
+
+// NewAnonymousMatcher 创建允许匿名访问的接口匹配器.
+func NewAnonymousMatcher() selector.Matcher {
+	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
+		_, ok := anonymousMethods[call.FullMethod()]
+		return ok
+	})
+}
+
 func (s *grpcServer) RunOrDie() {
 	s.srv.RunOrDie()
 }
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"context"
+
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// CreateComment 评论博客.
+func (h *Handler) CreateComment(ctx context.Context, rq *apiv1.CreateCommentRequest) (*apiv1.CreateCommentResponse, error) {
+	return h.biz.CommentV1().Create(ctx, rq)
+}
+
+// ListComments 列出博客的评论.
+func (h *Handler) ListComments(ctx context.Context, rq *apiv1.ListCommentsRequest) (*apiv1.ListCommentsResponse, error) {
+	return h.biz.CommentV1().List(ctx, rq)
+}
+
+// UpdateComment 修改评论.
+func (h *Handler) UpdateComment(ctx context.Context, rq *apiv1.UpdateCommentRequest) (*apiv1.UpdateCommentResponse, error) {
+	return h.biz.CommentV1().Update(ctx, rq)
+}
+
+// DeleteComment 删除评论.
+func (h *Handler) DeleteComment(ctx context.Context, rq *apiv1.DeleteCommentRequest) (*apiv1.DeleteCommentResponse, error) {
+	return h.biz.CommentV1().Delete(ctx, rq)
+}
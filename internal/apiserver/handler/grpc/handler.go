@@ -1,14 +1,20 @@
 package grpc
 
 import (
+	"github.com/wshadm/miniblog/internal/apiserver/biz"
 	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
 )
 
 // Handler 负责处理博客模块的请求
 type Handler struct {
 	apiv1.UnimplementedMiniBlogServer
+
+	biz biz.IBiz
 }
 
-func NewHandler() *Handler {
-	return &Handler{}
+// NewHandler 创建一个新的 Handler 实例
+func NewHandler(biz biz.IBiz) *Handler {
+	return &Handler{
+		biz: biz,
+	}
 }
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// CreatePost 创建博客.
+func (h *Handler) CreatePost(ctx context.Context, rq *apiv1.CreatePostRequest) (*apiv1.CreatePostResponse, error) {
+	return h.biz.PostV1().Create(ctx, rq)
+}
+
+// UpdatePost 更新博客.
+func (h *Handler) UpdatePost(ctx context.Context, rq *apiv1.UpdatePostRequest) (*apiv1.UpdatePostResponse, error) {
+	return h.biz.PostV1().Update(ctx, rq)
+}
+
+// DeletePosts 批量删除博客.
+func (h *Handler) DeletePosts(ctx context.Context, rq *apiv1.DeletePostsRequest) (*apiv1.DeletePostsResponse, error) {
+	return h.biz.PostV1().Delete(ctx, rq)
+}
+
+// GetPost 获取博客详情.
+func (h *Handler) GetPost(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.GetPostResponse, error) {
+	return h.biz.PostV1().Get(ctx, rq)
+}
+
+// ListPost 列出博客.
+func (h *Handler) ListPost(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.ListPostResponse, error) {
+	return h.biz.PostV1().List(ctx, rq)
+}
+
+// SearchPosts 全文检索已发布的博客.
+func (h *Handler) SearchPosts(ctx context.Context, rq *apiv1.SearchPostsRequest) (*apiv1.SearchPostsResponse, error) {
+	return h.biz.PostV1().Search(ctx, rq)
+}
+
+// ListDeletedPosts 列出回收站中的博客.
+func (h *Handler) ListDeletedPosts(ctx context.Context, rq *apiv1.ListDeletedPostsRequest) (*apiv1.ListDeletedPostsResponse, error) {
+	return h.biz.PostV1().ListDeleted(ctx, rq)
+}
+
+// RestorePost 恢复回收站中的博客.
+func (h *Handler) RestorePost(ctx context.Context, rq *apiv1.RestorePostRequest) (*apiv1.RestorePostResponse, error) {
+	return h.biz.PostV1().Restore(ctx, rq)
+}
+
+// PurgePost 永久删除回收站中的博客.
+func (h *Handler) PurgePost(ctx context.Context, rq *apiv1.PurgePostRequest) (*apiv1.PurgePostResponse, error) {
+	return h.biz.PostV1().Purge(ctx, rq)
+}
+
+// PublishPost 发布博客.
+func (h *Handler) PublishPost(ctx context.Context, rq *apiv1.PublishPostRequest) (*apiv1.PublishPostResponse, error) {
+	return h.biz.PostV1().Publish(ctx, rq)
+}
+
+// UnpublishPost 撤回博客.
+func (h *Handler) UnpublishPost(ctx context.Context, rq *apiv1.UnpublishPostRequest) (*apiv1.UnpublishPostResponse, error) {
+	return h.biz.PostV1().Unpublish(ctx, rq)
+}
+
+// ListPostRevisions 列出博客的修订历史.
+func (h *Handler) ListPostRevisions(ctx context.Context, rq *apiv1.ListPostRevisionsRequest) (*apiv1.ListPostRevisionsResponse, error) {
+	return h.biz.PostV1().ListRevisions(ctx, rq)
+}
+
+// GetPostRevision 获取博客的某个修订.
+func (h *Handler) GetPostRevision(ctx context.Context, rq *apiv1.GetPostRevisionRequest) (*apiv1.GetPostRevisionResponse, error) {
+	return h.biz.PostV1().GetRevision(ctx, rq)
+}
+
+// RevertPost 将博客回滚到某个修订.
+func (h *Handler) RevertPost(ctx context.Context, rq *apiv1.RevertPostRequest) (*apiv1.RevertPostResponse, error) {
+	return h.biz.PostV1().Revert(ctx, rq)
+}
+
+// DiffPostRevisions 比较博客的两个修订.
+func (h *Handler) DiffPostRevisions(ctx context.Context, rq *apiv1.DiffPostRevisionsRequest) (*apiv1.DiffPostRevisionsResponse, error) {
+	return h.biz.PostV1().Diff(ctx, rq)
+}
+
+// LikePost 点赞博客.
+func (h *Handler) LikePost(ctx context.Context, rq *apiv1.LikePostRequest) (*apiv1.LikePostResponse, error) {
+	return h.biz.PostV1().Like(ctx, rq)
+}
+
+// UnlikePost 取消点赞博客.
+func (h *Handler) UnlikePost(ctx context.Context, rq *apiv1.UnlikePostRequest) (*apiv1.UnlikePostResponse, error) {
+	return h.biz.PostV1().Unlike(ctx, rq)
+}
+
+// BookmarkPost 收藏博客.
+func (h *Handler) BookmarkPost(ctx context.Context, rq *apiv1.BookmarkPostRequest) (*apiv1.BookmarkPostResponse, error) {
+	return h.biz.PostV1().Bookmark(ctx, rq)
+}
+
+// UnbookmarkPost 取消收藏博客.
+func (h *Handler) UnbookmarkPost(ctx context.Context, rq *apiv1.UnbookmarkPostRequest) (*apiv1.UnbookmarkPostResponse, error) {
+	return h.biz.PostV1().Unbookmark(ctx, rq)
+}
+
+// ListBookmarks 列出当前用户收藏的博客.
+func (h *Handler) ListBookmarks(ctx context.Context, rq *apiv1.ListBookmarksRequest) (*apiv1.ListBookmarksResponse, error) {
+	return h.biz.PostV1().ListBookmarks(ctx, rq)
+}
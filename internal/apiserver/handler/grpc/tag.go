@@ -0,0 +1,12 @@
+package grpc
+
+import (
+	"context"
+
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// ListTags 列出标签及其已发布的博客数.
+func (h *Handler) ListTags(ctx context.Context, rq *apiv1.ListTagsRequest) (*apiv1.ListTagsResponse, error) {
+	return h.biz.TagV1().List(ctx, rq)
+}
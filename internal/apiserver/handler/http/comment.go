@@ -0,0 +1,26 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wshadm/miniblog/internal/pkg/core"
+)
+
+// CreateComment 评论博客.
+func (h *Handler) CreateComment(c *gin.Context) {
+	core.HandleRequest(c, h.biz.CommentV1().Create)
+}
+
+// ListComments 列出博客的评论.
+func (h *Handler) ListComments(c *gin.Context) {
+	core.HandleRequest(c, h.biz.CommentV1().List)
+}
+
+// UpdateComment 修改评论.
+func (h *Handler) UpdateComment(c *gin.Context) {
+	core.HandleRequest(c, h.biz.CommentV1().Update)
+}
+
+// DeleteComment 删除评论.
+func (h *Handler) DeleteComment(c *gin.Context) {
+	core.HandleRequest(c, h.biz.CommentV1().Delete)
+}
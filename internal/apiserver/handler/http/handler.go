@@ -1,9 +1,13 @@
 package http
 
+import "github.com/wshadm/miniblog/internal/apiserver/biz"
+
 // Handler 处理博客模块的请求
-type Handler struct{}
+type Handler struct {
+	biz biz.IBiz
+}
 
 // NewHandler创建新的Handler示例
-func NewHandler() *Handler {
-	return &Handler{}
+func NewHandler(biz biz.IBiz) *Handler {
+	return &Handler{biz: biz}
 }
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wshadm/miniblog/internal/pkg/core"
+)
+
+// CreatePost 创建博客.
+func (h *Handler) CreatePost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Create)
+}
+
+// UpdatePost 更新博客.
+func (h *Handler) UpdatePost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Update)
+}
+
+// DeletePosts 批量删除博客.
+func (h *Handler) DeletePosts(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Delete)
+}
+
+// GetPost 获取博客详情.
+func (h *Handler) GetPost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Get)
+}
+
+// ListPost 列出博客.
+func (h *Handler) ListPost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().List)
+}
+
+// SearchPosts 全文检索已发布的博客.
+func (h *Handler) SearchPosts(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Search)
+}
+
+// ListDeletedPosts 列出回收站中的博客.
+func (h *Handler) ListDeletedPosts(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().ListDeleted)
+}
+
+// RestorePost 恢复回收站中的博客.
+func (h *Handler) RestorePost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Restore)
+}
+
+// PurgePost 永久删除回收站中的博客.
+func (h *Handler) PurgePost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Purge)
+}
+
+// PublishPost 发布博客.
+func (h *Handler) PublishPost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Publish)
+}
+
+// UnpublishPost 撤回博客.
+func (h *Handler) UnpublishPost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Unpublish)
+}
+
+// ListPostRevisions 列出博客的修订历史.
+func (h *Handler) ListPostRevisions(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().ListRevisions)
+}
+
+// GetPostRevision 获取博客的某个修订.
+func (h *Handler) GetPostRevision(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().GetRevision)
+}
+
+// RevertPost 将博客回滚到某个修订.
+func (h *Handler) RevertPost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Revert)
+}
+
+// DiffPostRevisions 比较博客的两个修订.
+func (h *Handler) DiffPostRevisions(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Diff)
+}
+
+// LikePost 点赞博客.
+func (h *Handler) LikePost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Like)
+}
+
+// UnlikePost 取消点赞博客.
+func (h *Handler) UnlikePost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Unlike)
+}
+
+// BookmarkPost 收藏博客.
+func (h *Handler) BookmarkPost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Bookmark)
+}
+
+// UnbookmarkPost 取消收藏博客.
+func (h *Handler) UnbookmarkPost(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().Unbookmark)
+}
+
+// ListBookmarks 列出当前用户收藏的博客.
+func (h *Handler) ListBookmarks(c *gin.Context) {
+	core.HandleRequest(c, h.biz.PostV1().ListBookmarks)
+}
@@ -0,0 +1,11 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wshadm/miniblog/internal/pkg/core"
+)
+
+// ListTags 列出标签及其已发布的博客数.
+func (h *Handler) ListTags(c *gin.Context) {
+	core.HandleRequest(c, h.biz.TagV1().List)
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wshadm/miniblog/internal/pkg/core"
+)
+
+// CreateUser 创建新用户.
+func (h *Handler) CreateUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Create)
+}
+
+// UpdateUser 更新用户信息.
+func (h *Handler) UpdateUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Update)
+}
+
+// DeleteUser 删除用户.
+func (h *Handler) DeleteUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Delete)
+}
+
+// GetUser 获取用户信息.
+func (h *Handler) GetUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Get)
+}
+
+// ListUsers 列出用户.
+func (h *Handler) ListUsers(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().List)
+}
+
+// Login 用户登录.
+func (h *Handler) Login(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Login)
+}
+
+// RefreshToken 刷新令牌.
+func (h *Handler) RefreshToken(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().RefreshToken)
+}
+
+// ChangePassword 修改用户密码.
+func (h *Handler) ChangePassword(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().ChangePassword)
+}
@@ -0,0 +1,22 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/wshadm/miniblog/internal/apiserver/outbox"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+)
+
+// HandleEvent 处理 relay 发布的领域事件，目前只记录事件日志，作为审计记录.
+func (h *Handler) HandleEvent(ctx context.Context, msg kafka.Message) error {
+	kvs := []any{"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset, "key", string(msg.Key)}
+	for _, header := range msg.Headers {
+		switch header.Key {
+		case outbox.HeaderEventID, outbox.HeaderEventType, outbox.HeaderAggregateType:
+			kvs = append(kvs, header.Key, string(header.Value))
+		}
+	}
+	log.W(ctx).Infow("Domain event received", kvs...)
+	return nil
+}
@@ -0,0 +1,17 @@
+package kafka
+
+import (
+	"github.com/wshadm/miniblog/internal/apiserver/biz"
+)
+
+// Handler 负责处理从 Kafka 消费到的消息
+type Handler struct {
+	biz biz.IBiz
+}
+
+// NewHandler 创建一个新的 Handler 实例
+func NewHandler(biz biz.IBiz) *Handler {
+	return &Handler{
+		biz: biz,
+	}
+}
@@ -2,13 +2,14 @@ package apiserver
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 	handler "github.com/wshadm/miniblog/internal/apiserver/handler/http"
+	"github.com/wshadm/miniblog/internal/pkg/core"
 	mw "github.com/wshadm/miniblog/internal/pkg/middleware/gin"
 	"github.com/wshadm/miniblog/internal/pkg/server"
+	"github.com/wshadm/miniblog/pkg/errorsx"
 )
 
 // ginServer定义一个使用Gin框架开发的HTTP服务器
@@ -38,18 +39,86 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	//注册业务无关的API接口
 	InstallGenericAPI(engine)
 	//创建核心业务处理器
-	handler := handler.NewHandler()
+	handler := handler.NewHandler(c.biz)
 	//注册健康检查接口
 	engine.GET("/healthz", handler.Healthz)
+
+	// 注册身份认证相关路由
+	engine.POST("/login", handler.Login)
+	// 需要认证和授权的接口使用的中间件
+	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(), mw.AuthzMiddleware(c.authz)}
+
+	// 刷新令牌需要先通过认证
+	engine.PUT("/refresh-token", append(authMiddlewares, handler.RefreshToken)...)
+
+	// 注册 v1 版本 API 路由分组，路由与 grpc-gateway 保持一致
+	v1 := engine.Group("/v1")
+	{
+		// 用户相关路由
+		userv1 := v1.Group("/users")
+		{
+			// 创建用户（注册）无需认证
+			userv1.POST("", handler.CreateUser)
+			userv1.Use(authMiddlewares...)
+			userv1.PUT(":userID", handler.UpdateUser)
+			userv1.PUT(":userID/change-password", handler.ChangePassword)
+			userv1.DELETE(":userID", handler.DeleteUser)
+			userv1.GET(":userID", handler.GetUser)
+			userv1.GET("", handler.ListUsers)
+		}
+
+		// 博客、评论和标签相关路由，读取博客、评论和标签允许匿名访问，匿名用户只能读取已发布的博客
+		anonymousMiddlewares := []gin.HandlerFunc{mw.OptionalAuthnMiddleware(), mw.AuthzMiddleware(c.authz)}
+		postv1 := v1.Group("/posts")
+		{
+			postv1.GET(":postID", append(anonymousMiddlewares, handler.GetPost)...)
+			postv1.GET("", append(anonymousMiddlewares, handler.ListPost)...)
+			postv1.GET(":postID/comments", append(anonymousMiddlewares, handler.ListComments)...)
+			postv1.Use(authMiddlewares...)
+			postv1.POST("", handler.CreatePost)
+			postv1.PUT(":postID", handler.UpdatePost)
+			postv1.PUT(":postID/publish", handler.PublishPost)
+			postv1.PUT(":postID/unpublish", handler.UnpublishPost)
+			postv1.GET(":postID/revisions", handler.ListPostRevisions)
+			postv1.GET(":postID/revisions/:revision", handler.GetPostRevision)
+			postv1.PUT(":postID/revert", handler.RevertPost)
+			postv1.GET(":postID/diff", handler.DiffPostRevisions)
+			postv1.POST(":postID/comments", handler.CreateComment)
+			postv1.PUT(":postID/comments/:commentID", handler.UpdateComment)
+			postv1.DELETE(":postID/comments/:commentID", handler.DeleteComment)
+			postv1.PUT(":postID/like", handler.LikePost)
+			postv1.DELETE(":postID/like", handler.UnlikePost)
+			postv1.PUT(":postID/bookmark", handler.BookmarkPost)
+			postv1.DELETE(":postID/bookmark", handler.UnbookmarkPost)
+			postv1.DELETE("", handler.DeletePosts)
+		}
+
+		// 标签相关路由，允许匿名访问
+		v1.GET("/tags", append(anonymousMiddlewares, handler.ListTags)...)
+
+		// 全文检索相关路由，允许匿名访问
+		v1.GET("/search/posts", append(anonymousMiddlewares, handler.SearchPosts)...)
+
+		// 收藏相关路由
+		v1.GET("/bookmarks", append(authMiddlewares, handler.ListBookmarks)...)
+
+		// 博客回收站相关路由
+		trashv1 := v1.Group("/trash/posts", authMiddlewares...)
+		{
+			trashv1.GET("", handler.ListDeletedPosts)
+			trashv1.PUT(":postID/restore", handler.RestorePost)
+			trashv1.DELETE(":postID", handler.PurgePost)
+		}
+	}
 }
 
 // InstallGenericAPI注册业务无关的路由，例如pprof、404处理等
 func InstallGenericAPI(engine *gin.Engine) {
 	//注册pprof路由
 	pprof.Register(engine)
-	//注册404 路由处理,未匹配到的路由将返回404响应
+	//注册404 路由处理,未匹配到的路由将返回404响应，错误格式与 API 错误保持一致
 	engine.NoRoute(func(c *gin.Context) {
-		c.JSON(http.StatusNotFound, "Page not found.")
+		core.WriteResponse(c, nil, errorsx.ErrNotFound.WithMessage("Page not found."))
 	})
 }
 
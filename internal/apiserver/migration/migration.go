@@ -0,0 +1,282 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// sqlFS 保存按数据库类型划分的 SQL 迁移文件，目录名与 options.DBOptions.Driver 一致.
+// 文件命名格式为 <version>_<name>.up.sql 和 <version>_<name>.down.sql.
+//
+//go:embed sql
+var sqlFS embed.FS
+
+// Migration 表示一个版本的数据库迁移.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status 表示某个版本迁移的执行状态.
+type Status struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// SchemaMigrationM 是迁移状态表，记录已经执行过的迁移版本.
+type SchemaMigrationM struct {
+	Version   uint      `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name;size:255;not null"`
+	AppliedAt time.Time `gorm:"column:appliedAt;not null"`
+}
+
+// TableName SchemaMigrationM's table name
+func (*SchemaMigrationM) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator 负责执行数据库迁移.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New 加载 driver 对应的迁移文件，并确保迁移状态表存在.
+func New(db *gorm.DB, driver string) (*Migrator, error) {
+	migrations, err := load(driver)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.AutoMigrate(&SchemaMigrationM{}); err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Latest 返回最新的迁移版本.
+func (m *Migrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up 执行所有尚未执行的迁移.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down 回滚最近一次执行的迁移.
+func (m *Migrator) Down(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[m.migrations[i].Version]; ok {
+			return m.revert(ctx, m.migrations[i])
+		}
+	}
+	return nil
+}
+
+// To 将数据库迁移到指定版本：执行不高于 version 的未执行迁移，回滚高于 version 的已执行迁移.
+// version 为 0 时回滚所有迁移.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	if version != 0 && !m.exists(version) {
+		return fmt.Errorf("migration version %d not found", version)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 先从高到低回滚，再从低到高执行
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mg := m.migrations[i]
+		if _, ok := applied[mg.Version]; ok && mg.Version > version {
+			if err := m.revert(ctx, mg); err != nil {
+				return err
+			}
+		}
+	}
+	for _, mg := range m.migrations {
+		if _, ok := applied[mg.Version]; !ok && mg.Version <= version {
+			if err := m.apply(ctx, mg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Status 返回所有迁移的执行状态.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]Status, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		st := Status{Version: mg.Version, Name: mg.Name}
+		if sm, ok := applied[mg.Version]; ok {
+			st.Applied = true
+			st.AppliedAt = &sm.AppliedAt
+		}
+		ret = append(ret, st)
+	}
+	return ret, nil
+}
+
+// apply 在事务中执行一次迁移，并写入迁移状态表.
+func (m *Migrator) apply(ctx context.Context, mg Migration) error {
+	logger.L().Info().Uint("version", mg.Version).Str("name", mg.Name).Msg("Applying migration")
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := exec(tx, mg.Up); err != nil {
+			return fmt.Errorf("apply migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+		return tx.Create(&SchemaMigrationM{Version: mg.Version, Name: mg.Name, AppliedAt: time.Now()}).Error
+	})
+}
+
+// revert 在事务中回滚一次迁移，并删除迁移状态表中的记录.
+func (m *Migrator) revert(ctx context.Context, mg Migration) error {
+	logger.L().Info().Uint("version", mg.Version).Str("name", mg.Name).Msg("Reverting migration")
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := exec(tx, mg.Down); err != nil {
+			return fmt.Errorf("revert migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+		return tx.Delete(&SchemaMigrationM{Version: mg.Version}).Error
+	})
+}
+
+// applied 返回已执行的迁移，key 为迁移版本.
+func (m *Migrator) applied(ctx context.Context) (map[uint]SchemaMigrationM, error) {
+	var list []SchemaMigrationM
+	if err := m.db.WithContext(ctx).Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	ret := make(map[uint]SchemaMigrationM, len(list))
+	for _, sm := range list {
+		ret[sm.Version] = sm
+	}
+	return ret, nil
+}
+
+// exists 判断指定版本的迁移是否存在.
+func (m *Migrator) exists(version uint) bool {
+	for _, mg := range m.migrations {
+		if mg.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// exec 依次执行 script 中以分号结尾的 SQL 语句.
+// 部分数据库驱动不支持在一次调用中执行多条语句，因此需要拆分后执行.
+func exec(tx *gorm.DB, script string) error {
+	for _, stmt := range split(script) {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// split 去掉 script 中的注释行，并按行尾的分号拆分为多条语句.
+func split(script string) []string {
+	var (
+		stmts []string
+		sb    strings.Builder
+	)
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSpace(sb.String()))
+			sb.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(sb.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+	return stmts
+}
+
+// load 从内嵌文件系统中读取 driver 对应的迁移文件，并按版本号升序排列.
+func load(driver string) ([]Migration, error) {
+	dir := path.Join("sql", driver)
+	entries, err := fs.ReadDir(sqlFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for db driver %q: %w", driver, err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionStr, title, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+		version, err := strconv.ParseUint(versionStr, 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", name)
+		}
+
+		content, err := fs.ReadFile(sqlFS, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		mg, ok := byVersion[uint(version)]
+		if !ok {
+			mg = &Migration{Version: uint(version), Name: title}
+			byVersion[uint(version)] = mg
+		}
+		if direction == "up" {
+			mg.Up = string(content)
+		} else {
+			mg.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mg := range byVersion {
+		migrations = append(migrations, *mg)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
@@ -0,0 +1,248 @@
+package migration
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/wshadm/miniblog/pkg/options"
+	"gorm.io/gorm"
+)
+
+// newTestMigrator 创建使用 SQLite 内存数据库的 Migrator.
+func newTestMigrator(t *testing.T) (*Migrator, *gorm.DB) {
+	t.Helper()
+
+	opts := options.NewSQLiteOptions()
+	opts.Path = ":memory:"
+	db, err := opts.NewDB()
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	m, err := New(db, options.DriverSQLite)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if len(m.migrations) < 3 {
+		t.Fatalf("loaded %d migrations, want at least 3", len(m.migrations))
+	}
+	return m, db
+}
+
+// checkApplied 检查 Status 和迁移状态表中已执行的迁移版本均为 want.
+func checkApplied(t *testing.T, m *Migrator, db *gorm.DB, want []uint) {
+	t.Helper()
+
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != len(m.migrations) {
+		t.Fatalf("Status() returned %d migrations, want %d", len(statuses), len(m.migrations))
+	}
+	var applied []uint
+	for i, st := range statuses {
+		if st.Version != m.migrations[i].Version || st.Name != m.migrations[i].Name {
+			t.Errorf("Status()[%d] = %d_%s, want %d_%s", i, st.Version, st.Name, m.migrations[i].Version, m.migrations[i].Name)
+		}
+		if st.Applied != (st.AppliedAt != nil) {
+			t.Errorf("version %d applied = %v but appliedAt = %v", st.Version, st.Applied, st.AppliedAt)
+		}
+		if st.Applied {
+			applied = append(applied, st.Version)
+		}
+	}
+	if !slices.Equal(applied, want) {
+		t.Errorf("applied versions = %v, want %v", applied, want)
+	}
+
+	var rows []SchemaMigrationM
+	if err := db.Order("version").Find(&rows).Error; err != nil {
+		t.Fatalf("failed to query schema_migrations: %v", err)
+	}
+	var recorded []uint
+	for _, row := range rows {
+		recorded = append(recorded, row.Version)
+		if mg := m.migrations[slices.IndexFunc(m.migrations, func(mg Migration) bool { return mg.Version == row.Version })]; row.Name != mg.Name {
+			t.Errorf("schema_migrations name of version %d = %q, want %q", row.Version, row.Name, mg.Name)
+		}
+		if row.AppliedAt.IsZero() {
+			t.Errorf("schema_migrations appliedAt of version %d is not set", row.Version)
+		}
+	}
+	if !slices.Equal(recorded, want) {
+		t.Errorf("schema_migrations versions = %v, want %v", recorded, want)
+	}
+}
+
+// schema 返回数据库中除迁移状态表之外的表和索引.
+func schema(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+
+	var names []string
+	err := db.Raw("SELECT type || ' ' || name FROM sqlite_master WHERE type IN ('table', 'index') " +
+		"AND tbl_name NOT IN ('schema_migrations', 'sqlite_sequence') AND name NOT LIKE 'sqlite_autoindex_%' ORDER BY type, name").
+		Scan(&names).Error
+	if err != nil {
+		t.Fatalf("failed to query schema: %v", err)
+	}
+	return names
+}
+
+// versions 返回 m 中不高于 n 的迁移版本.
+func versions(m *Migrator, n uint) []uint {
+	var ret []uint
+	for _, mg := range m.migrations {
+		if mg.Version <= n {
+			ret = append(ret, mg.Version)
+		}
+	}
+	return ret
+}
+
+func TestMigrateUpDownTo(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t)
+	latest := m.Latest()
+	previous := m.migrations[len(m.migrations)-2].Version
+	middle := m.migrations[len(m.migrations)/2].Version
+
+	checkApplied(t, m, db, nil)
+	if got := schema(t, db); len(got) != 0 {
+		t.Fatalf("schema before Up() = %v, want none", got)
+	}
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	checkApplied(t, m, db, versions(m, latest))
+	all := schema(t, db)
+	if !slices.Contains(all, "table user") || !slices.Contains(all, "index idx_post_userID_createdAt_id") {
+		t.Fatalf("schema after Up() = %v, want user, post and their indexes", all)
+	}
+	// 再次执行不做任何改动
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("second Up: %v", err)
+	}
+	checkApplied(t, m, db, versions(m, latest))
+
+	// Down 每次只回滚最近一次执行的迁移
+	if err := m.Down(ctx); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	checkApplied(t, m, db, versions(m, previous))
+
+	if err := m.To(ctx, middle); err != nil {
+		t.Fatalf("To(%d): %v", middle, err)
+	}
+	checkApplied(t, m, db, versions(m, middle))
+
+	// 回滚后可以重新执行，得到相同的表和索引
+	if err := m.To(ctx, latest); err != nil {
+		t.Fatalf("To(%d): %v", latest, err)
+	}
+	checkApplied(t, m, db, versions(m, latest))
+	if got := schema(t, db); !slices.Equal(got, all) {
+		t.Errorf("schema after To(%d) = %v, want %v", latest, got, all)
+	}
+
+	if err := m.To(ctx, 0); err != nil {
+		t.Fatalf("To(0): %v", err)
+	}
+	checkApplied(t, m, db, nil)
+	if got := schema(t, db); len(got) != 0 {
+		t.Errorf("schema after To(0) = %v, want none", got)
+	}
+	// 没有已执行的迁移时 Down 不做任何改动
+	if err := m.Down(ctx); err != nil {
+		t.Fatalf("Down without applied migrations: %v", err)
+	}
+	checkApplied(t, m, db, nil)
+}
+
+// TestMigrateEachVersion 依次执行每个迁移，并检查其回滚脚本能撤销执行脚本的改动.
+func TestMigrateEachVersion(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t)
+
+	for _, mg := range m.migrations {
+		before := schema(t, db)
+		if err := m.To(ctx, mg.Version); err != nil {
+			t.Fatalf("To(%d): %v", mg.Version, err)
+		}
+		checkApplied(t, m, db, versions(m, mg.Version))
+		after := schema(t, db)
+
+		if err := m.Down(ctx); err != nil {
+			t.Fatalf("Down from %d: %v", mg.Version, err)
+		}
+		checkApplied(t, m, db, versions(m, mg.Version-1))
+		if got := schema(t, db); !slices.Equal(got, before) {
+			t.Errorf("schema after reverting %d_%s = %v, want %v", mg.Version, mg.Name, got, before)
+		}
+
+		if err := m.To(ctx, mg.Version); err != nil {
+			t.Fatalf("To(%d) after Down: %v", mg.Version, err)
+		}
+		if got := schema(t, db); !slices.Equal(got, after) {
+			t.Errorf("schema after reapplying %d_%s = %v, want %v", mg.Version, mg.Name, got, after)
+		}
+	}
+	checkApplied(t, m, db, versions(m, m.Latest()))
+}
+
+func TestMigrateToUnknownVersion(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t)
+
+	if err := m.To(ctx, m.Latest()+1); err == nil {
+		t.Errorf("To(%d) should fail", m.Latest()+1)
+	}
+	checkApplied(t, m, db, nil)
+}
+
+// TestLoadDrivers 检查各数据库的迁移版本一致，且每个版本都有执行和回滚脚本.
+func TestLoadDrivers(t *testing.T) {
+	want, err := load(options.DriverSQLite)
+	if err != nil {
+		t.Fatalf("load(%s): %v", options.DriverSQLite, err)
+	}
+	for _, driver := range []string{options.DriverMySQL, options.DriverPostgreSQL, options.DriverSQLite} {
+		migrations, err := load(driver)
+		if err != nil {
+			t.Fatalf("load(%s): %v", driver, err)
+		}
+		if len(migrations) != len(want) {
+			t.Errorf("load(%s) returned %d migrations, want %d", driver, len(migrations), len(want))
+			continue
+		}
+		for i, mg := range migrations {
+			if mg.Version != want[i].Version || mg.Name != want[i].Name {
+				t.Errorf("%s migration %d = %d_%s, want %d_%s", driver, i, mg.Version, mg.Name, want[i].Version, want[i].Name)
+			}
+			if len(split(mg.Up)) == 0 || len(split(mg.Down)) == 0 {
+				t.Errorf("%s migration %d_%s should have both up and down statements", driver, mg.Version, mg.Name)
+			}
+		}
+	}
+}
+
+func TestLoadUnknownDriver(t *testing.T) {
+	if _, err := load("oracle"); err == nil {
+		t.Error("load(oracle) should fail")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	script := "-- comment\nCREATE TABLE a (\n  id INTEGER\n);\n\nDROP TABLE b;\nSELECT 1"
+	want := []string{"CREATE TABLE a (\n  id INTEGER\n);", "DROP TABLE b;", "SELECT 1"}
+	if got := split(script); !slices.Equal(got, want) {
+		t.Errorf("split() = %q, want %q", got, want)
+	}
+}
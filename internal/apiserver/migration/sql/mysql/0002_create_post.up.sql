@@ -0,0 +1,13 @@
+-- 博文表
+CREATE TABLE IF NOT EXISTS `post` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
+  `title` varchar(256) NOT NULL DEFAULT '' COMMENT '博文标题',
+  `content` longtext NOT NULL COMMENT '博文内容',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '博文创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '博文最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_post_postID` (`postID`),
+  KEY `idx_post_userID` (`userID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='博文表';
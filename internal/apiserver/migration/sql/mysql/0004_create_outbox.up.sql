@@ -0,0 +1,15 @@
+-- 事务发件箱表，领域事件与业务数据在同一个事务中写入，由后台 relay 发布到 Kafka
+CREATE TABLE IF NOT EXISTS `outbox` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `eventID` varchar(36) NOT NULL DEFAULT '' COMMENT '事件唯一 ID',
+  `eventType` varchar(64) NOT NULL DEFAULT '' COMMENT '事件类型，例如 post.created',
+  `aggregateType` varchar(32) NOT NULL DEFAULT '' COMMENT '聚合类型，例如 post',
+  `aggregateID` varchar(36) NOT NULL DEFAULT '' COMMENT '聚合 ID，作为 Kafka 消息的 key',
+  `payload` longtext NOT NULL COMMENT '事件内容（JSON）',
+  `published` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否已发布',
+  `publishedAt` datetime DEFAULT NULL COMMENT '事件发布时间',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '事件创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_outbox_eventID` (`eventID`),
+  KEY `idx_outbox_published` (`published`, `id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='事务发件箱表';
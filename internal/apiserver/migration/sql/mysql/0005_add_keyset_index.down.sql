@@ -0,0 +1,2 @@
+DROP INDEX `idx_post_userID_createdAt_id` ON `post`;
+DROP INDEX `idx_user_createdAt_id` ON `user`;
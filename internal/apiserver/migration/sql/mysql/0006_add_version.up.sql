@@ -0,0 +1,3 @@
+-- 为用户表和博文表增加乐观锁版本号，每次更新加 1，用于生成 ETag
+ALTER TABLE `user` ADD COLUMN `version` bigint unsigned NOT NULL DEFAULT 1 COMMENT '乐观锁版本号，每次更新加 1';
+ALTER TABLE `post` ADD COLUMN `version` bigint unsigned NOT NULL DEFAULT 1 COMMENT '乐观锁版本号，每次更新加 1';
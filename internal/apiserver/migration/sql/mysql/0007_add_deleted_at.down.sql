@@ -0,0 +1,4 @@
+DROP INDEX `idx_post_deletedAt` ON `post`;
+DROP INDEX `idx_user_deletedAt` ON `user`;
+ALTER TABLE `post` DROP COLUMN `deletedAt`;
+ALTER TABLE `user` DROP COLUMN `deletedAt`;
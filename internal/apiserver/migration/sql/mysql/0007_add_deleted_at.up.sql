@@ -0,0 +1,5 @@
+-- 为用户表和博文表增加软删除时间，未删除的记录为 NULL，回收站中的记录过期后被永久删除
+ALTER TABLE `user` ADD COLUMN `deletedAt` datetime NULL DEFAULT NULL COMMENT '用户删除时间';
+ALTER TABLE `post` ADD COLUMN `deletedAt` datetime NULL DEFAULT NULL COMMENT '博文删除时间';
+CREATE INDEX `idx_user_deletedAt` ON `user` (`deletedAt`);
+CREATE INDEX `idx_post_deletedAt` ON `post` (`deletedAt`);
@@ -0,0 +1,3 @@
+DROP INDEX `idx_post_status_publishAt` ON `post`;
+ALTER TABLE `post` DROP COLUMN `publishAt`;
+ALTER TABLE `post` DROP COLUMN `status`;
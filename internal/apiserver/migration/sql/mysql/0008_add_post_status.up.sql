@@ -0,0 +1,5 @@
+-- 为博文表增加发布状态和发布时间，已有的博文均视为已发布，发布时间为创建时间
+ALTER TABLE `post` ADD COLUMN `status` varchar(16) NOT NULL DEFAULT 'published' COMMENT '博文状态：draft、scheduled、published 或 archived';
+ALTER TABLE `post` ADD COLUMN `publishAt` datetime NULL DEFAULT NULL COMMENT '博文发布时间，定时发布的博文为计划发布时间';
+UPDATE `post` SET `publishAt` = `createdAt` WHERE `publishAt` IS NULL;
+CREATE INDEX `idx_post_status_publishAt` ON `post` (`status`, `publishAt`);
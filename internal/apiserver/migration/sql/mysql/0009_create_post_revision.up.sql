@@ -0,0 +1,15 @@
+-- 博文修订历史表，每次修改博文标题或内容时保存修改后的快照，修订号为博文修改后的版本号
+-- 已有的博文以当前内容作为第一条修订
+CREATE TABLE IF NOT EXISTS `post_revision` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
+  `revision` bigint NOT NULL DEFAULT 1 COMMENT '修订号，与博文的版本号一致',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '修改博文的用户唯一 ID',
+  `title` varchar(256) NOT NULL DEFAULT '' COMMENT '博文标题',
+  `content` longtext NOT NULL COMMENT '博文内容',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '修订创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_post_revision_postID_revision` (`postID`, `revision`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='博文修订历史表';
+INSERT INTO `post_revision` (`postID`, `revision`, `userID`, `title`, `content`, `createdAt`)
+  SELECT `postID`, `version`, `userID`, `title`, `content`, `updatedAt` FROM `post`;
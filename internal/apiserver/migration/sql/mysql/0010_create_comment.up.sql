@@ -0,0 +1,15 @@
+-- 评论表，parentID 为空的评论为顶层评论，回复的 rootID 为所在讨论串的顶层评论 ID
+CREATE TABLE IF NOT EXISTS `comment` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `commentID` varchar(35) NOT NULL DEFAULT '' COMMENT '评论唯一 ID',
+  `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '评论者的用户唯一 ID',
+  `rootID` varchar(35) NOT NULL DEFAULT '' COMMENT '所在讨论串的顶层评论 ID，顶层评论为空',
+  `parentID` varchar(35) NOT NULL DEFAULT '' COMMENT '回复的评论 ID，顶层评论为空',
+  `content` text NOT NULL COMMENT '评论内容',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '评论创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '评论最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_comment_commentID` (`commentID`),
+  KEY `idx_comment_postID_rootID` (`postID`, `rootID`, `createdAt`, `id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评论表';
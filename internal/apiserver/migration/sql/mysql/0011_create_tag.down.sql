@@ -0,0 +1,5 @@
+DROP INDEX `idx_post_categoryID` ON `post`;
+ALTER TABLE `post` DROP COLUMN `categoryID`;
+DROP TABLE IF EXISTS `post_tag`;
+DROP TABLE IF EXISTS `category`;
+DROP TABLE IF EXISTS `tag`;
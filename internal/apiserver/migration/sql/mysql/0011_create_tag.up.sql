@@ -0,0 +1,28 @@
+-- 标签表和分类表，名称统一为小写且唯一
+CREATE TABLE IF NOT EXISTS `tag` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(32) NOT NULL DEFAULT '' COMMENT '标签名',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '标签创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tag_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='标签表';
+CREATE TABLE IF NOT EXISTS `category` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(32) NOT NULL DEFAULT '' COMMENT '分类名',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '分类创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_category_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='分类表';
+-- 博文与标签的多对多关联表
+CREATE TABLE IF NOT EXISTS `post_tag` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
+  `tagID` bigint unsigned NOT NULL DEFAULT 0 COMMENT '标签 ID',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '关联创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_post_tag_postID_tagID` (`postID`, `tagID`),
+  KEY `idx_post_tag_tagID` (`tagID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='博文标签关联表';
+-- 博文所属的分类，0 表示未分类
+ALTER TABLE `post` ADD COLUMN `categoryID` bigint unsigned NOT NULL DEFAULT 0 COMMENT '分类 ID，0 表示未分类';
+CREATE INDEX `idx_post_categoryID` ON `post` (`categoryID`);
@@ -0,0 +1,4 @@
+ALTER TABLE `post` DROP COLUMN `viewCount`;
+ALTER TABLE `post` DROP COLUMN `likeCount`;
+DROP TABLE IF EXISTS `bookmark`;
+DROP TABLE IF EXISTS `post_like`;
@@ -0,0 +1,23 @@
+-- 博文点赞表，每个用户对同一篇博文只能点赞一次
+CREATE TABLE IF NOT EXISTS `post_like` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '点赞用户的唯一 ID',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '点赞时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_post_like_postID_userID` (`postID`, `userID`),
+  KEY `idx_post_like_userID` (`userID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='博文点赞表';
+-- 博文收藏表，每个用户对同一篇博文只能收藏一次
+CREATE TABLE IF NOT EXISTS `bookmark` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '收藏用户的唯一 ID',
+  `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '收藏时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_bookmark_userID_postID` (`userID`, `postID`),
+  KEY `idx_bookmark_postID` (`postID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='博文收藏表';
+-- 博文的点赞数和浏览数
+ALTER TABLE `post` ADD COLUMN `likeCount` bigint unsigned NOT NULL DEFAULT 0 COMMENT '点赞数';
+ALTER TABLE `post` ADD COLUMN `viewCount` bigint unsigned NOT NULL DEFAULT 0 COMMENT '浏览数';
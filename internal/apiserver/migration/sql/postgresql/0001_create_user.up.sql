@@ -0,0 +1,15 @@
+-- 用户表
+CREATE TABLE IF NOT EXISTS "user" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "userID" VARCHAR(36) NOT NULL DEFAULT '',
+  "username" VARCHAR(255) NOT NULL DEFAULT '',
+  "password" VARCHAR(255) NOT NULL DEFAULT '',
+  "nickname" VARCHAR(30) NOT NULL DEFAULT '',
+  "email" VARCHAR(256) NOT NULL DEFAULT '',
+  "phone" VARCHAR(16) NOT NULL DEFAULT '',
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_userID" ON "user" ("userID");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_username" ON "user" ("username");
+CREATE INDEX IF NOT EXISTS "idx_user_phone" ON "user" ("phone");
@@ -0,0 +1,12 @@
+-- 博文表
+CREATE TABLE IF NOT EXISTS "post" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "userID" VARCHAR(36) NOT NULL DEFAULT '',
+  "postID" VARCHAR(35) NOT NULL DEFAULT '',
+  "title" VARCHAR(256) NOT NULL DEFAULT '',
+  "content" TEXT NOT NULL DEFAULT '',
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_post_postID" ON "post" ("postID");
+CREATE INDEX IF NOT EXISTS "idx_post_userID" ON "post" ("userID");
@@ -0,0 +1,14 @@
+-- 事务发件箱表，领域事件与业务数据在同一个事务中写入，由后台 relay 发布到 Kafka
+CREATE TABLE IF NOT EXISTS "outbox" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "eventID" VARCHAR(36) NOT NULL DEFAULT '',
+  "eventType" VARCHAR(64) NOT NULL DEFAULT '',
+  "aggregateType" VARCHAR(32) NOT NULL DEFAULT '',
+  "aggregateID" VARCHAR(36) NOT NULL DEFAULT '',
+  "payload" TEXT NOT NULL DEFAULT '',
+  "published" BOOLEAN NOT NULL DEFAULT FALSE,
+  "publishedAt" TIMESTAMPTZ,
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_outbox_eventID" ON "outbox" ("eventID");
+CREATE INDEX IF NOT EXISTS "idx_outbox_published" ON "outbox" ("published", "id");
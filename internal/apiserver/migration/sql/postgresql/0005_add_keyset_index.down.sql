@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_post_userID_createdAt_id";
+DROP INDEX IF EXISTS "idx_user_createdAt_id";
@@ -0,0 +1,3 @@
+-- 列表按 (createdAt, id) 倒序分页，为 keyset 分页增加索引
+CREATE INDEX IF NOT EXISTS "idx_user_createdAt_id" ON "user" ("createdAt", "id");
+CREATE INDEX IF NOT EXISTS "idx_post_userID_createdAt_id" ON "post" ("userID", "createdAt", "id");
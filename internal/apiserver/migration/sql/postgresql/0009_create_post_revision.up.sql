@@ -0,0 +1,14 @@
+-- 博文修订历史表，每次修改博文标题或内容时保存修改后的快照，修订号为博文修改后的版本号
+-- 已有的博文以当前内容作为第一条修订
+CREATE TABLE IF NOT EXISTS "post_revision" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "postID" VARCHAR(35) NOT NULL DEFAULT '',
+  "revision" BIGINT NOT NULL DEFAULT 1,
+  "userID" VARCHAR(36) NOT NULL DEFAULT '',
+  "title" VARCHAR(256) NOT NULL DEFAULT '',
+  "content" TEXT NOT NULL DEFAULT '',
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_post_revision_postID_revision" ON "post_revision" ("postID", "revision");
+INSERT INTO "post_revision" ("postID", "revision", "userID", "title", "content", "createdAt")
+  SELECT "postID", "version", "userID", "title", "content", "updatedAt" FROM "post";
@@ -0,0 +1,14 @@
+-- 评论表，parentID 为空的评论为顶层评论，回复的 rootID 为所在讨论串的顶层评论 ID
+CREATE TABLE IF NOT EXISTS "comment" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "commentID" VARCHAR(35) NOT NULL DEFAULT '',
+  "postID" VARCHAR(35) NOT NULL DEFAULT '',
+  "userID" VARCHAR(36) NOT NULL DEFAULT '',
+  "rootID" VARCHAR(35) NOT NULL DEFAULT '',
+  "parentID" VARCHAR(35) NOT NULL DEFAULT '',
+  "content" TEXT NOT NULL DEFAULT '',
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_comment_commentID" ON "comment" ("commentID");
+CREATE INDEX IF NOT EXISTS "idx_comment_postID_rootID" ON "comment" ("postID", "rootID", "createdAt", "id");
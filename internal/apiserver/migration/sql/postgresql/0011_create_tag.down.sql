@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_post_categoryID";
+ALTER TABLE "post" DROP COLUMN IF EXISTS "categoryID";
+DROP TABLE IF EXISTS "post_tag";
+DROP TABLE IF EXISTS "category";
+DROP TABLE IF EXISTS "tag";
@@ -0,0 +1,4 @@
+ALTER TABLE "post" DROP COLUMN IF EXISTS "viewCount";
+ALTER TABLE "post" DROP COLUMN IF EXISTS "likeCount";
+DROP TABLE IF EXISTS "bookmark";
+DROP TABLE IF EXISTS "post_like";
@@ -0,0 +1,21 @@
+-- 博文点赞表，每个用户对同一篇博文只能点赞一次
+CREATE TABLE IF NOT EXISTS "post_like" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "postID" VARCHAR(35) NOT NULL DEFAULT '',
+  "userID" VARCHAR(36) NOT NULL DEFAULT '',
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_post_like_postID_userID" ON "post_like" ("postID", "userID");
+CREATE INDEX IF NOT EXISTS "idx_post_like_userID" ON "post_like" ("userID");
+-- 博文收藏表，每个用户对同一篇博文只能收藏一次
+CREATE TABLE IF NOT EXISTS "bookmark" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "userID" VARCHAR(36) NOT NULL DEFAULT '',
+  "postID" VARCHAR(35) NOT NULL DEFAULT '',
+  "createdAt" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_bookmark_userID_postID" ON "bookmark" ("userID", "postID");
+CREATE INDEX IF NOT EXISTS "idx_bookmark_postID" ON "bookmark" ("postID");
+-- 博文的点赞数和浏览数
+ALTER TABLE "post" ADD COLUMN IF NOT EXISTS "likeCount" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "post" ADD COLUMN IF NOT EXISTS "viewCount" BIGINT NOT NULL DEFAULT 0;
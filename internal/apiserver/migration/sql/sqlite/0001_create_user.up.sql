@@ -0,0 +1,15 @@
+-- 用户表
+CREATE TABLE IF NOT EXISTS `user` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `userID` TEXT NOT NULL DEFAULT '',
+  `username` TEXT NOT NULL DEFAULT '',
+  `password` TEXT NOT NULL DEFAULT '',
+  `nickname` TEXT NOT NULL DEFAULT '',
+  `email` TEXT NOT NULL DEFAULT '',
+  `phone` TEXT NOT NULL DEFAULT '',
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_userID` ON `user` (`userID`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_username` ON `user` (`username`);
+CREATE INDEX IF NOT EXISTS `idx_user_phone` ON `user` (`phone`);
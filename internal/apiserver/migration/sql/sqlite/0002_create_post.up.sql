@@ -0,0 +1,12 @@
+-- 博文表
+CREATE TABLE IF NOT EXISTS `post` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `userID` TEXT NOT NULL DEFAULT '',
+  `postID` TEXT NOT NULL DEFAULT '',
+  `title` TEXT NOT NULL DEFAULT '',
+  `content` TEXT NOT NULL DEFAULT '',
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_post_postID` ON `post` (`postID`);
+CREATE INDEX IF NOT EXISTS `idx_post_userID` ON `post` (`userID`);
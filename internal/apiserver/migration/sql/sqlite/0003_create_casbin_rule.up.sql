@@ -0,0 +1,4 @@
+-- Casbin 授权策略表
+-- 表结构与 gorm-adapter 自动生成的一致，保证 gorm-adapter 启动时能够正确解析已有的表结构
+CREATE TABLE IF NOT EXISTS `casbin_rule` (`id` integer PRIMARY KEY AUTOINCREMENT,`ptype` text,`v0` text,`v1` text,`v2` text,`v3` text,`v4` text,`v5` text);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_casbin_rule` ON `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`);
@@ -0,0 +1,14 @@
+-- 事务发件箱表，领域事件与业务数据在同一个事务中写入，由后台 relay 发布到 Kafka
+CREATE TABLE IF NOT EXISTS `outbox` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `eventID` TEXT NOT NULL DEFAULT '',
+  `eventType` TEXT NOT NULL DEFAULT '',
+  `aggregateType` TEXT NOT NULL DEFAULT '',
+  `aggregateID` TEXT NOT NULL DEFAULT '',
+  `payload` TEXT NOT NULL DEFAULT '',
+  `published` NUMERIC NOT NULL DEFAULT false,
+  `publishedAt` DATETIME,
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_outbox_eventID` ON `outbox` (`eventID`);
+CREATE INDEX IF NOT EXISTS `idx_outbox_published` ON `outbox` (`published`, `id`);
@@ -0,0 +1,2 @@
+ALTER TABLE `post` DROP COLUMN `version`;
+ALTER TABLE `user` DROP COLUMN `version`;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `idx_post_deletedAt`;
+DROP INDEX IF EXISTS `idx_user_deletedAt`;
+ALTER TABLE `post` DROP COLUMN `deletedAt`;
+ALTER TABLE `user` DROP COLUMN `deletedAt`;
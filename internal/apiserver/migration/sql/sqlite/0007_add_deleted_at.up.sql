@@ -0,0 +1,5 @@
+-- 为用户表和博文表增加软删除时间，未删除的记录为 NULL，回收站中的记录过期后被永久删除
+ALTER TABLE `user` ADD COLUMN `deletedAt` DATETIME NULL DEFAULT NULL;
+ALTER TABLE `post` ADD COLUMN `deletedAt` DATETIME NULL DEFAULT NULL;
+CREATE INDEX IF NOT EXISTS `idx_user_deletedAt` ON `user` (`deletedAt`);
+CREATE INDEX IF NOT EXISTS `idx_post_deletedAt` ON `post` (`deletedAt`);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS `idx_post_status_publishAt`;
+ALTER TABLE `post` DROP COLUMN `publishAt`;
+ALTER TABLE `post` DROP COLUMN `status`;
@@ -0,0 +1,5 @@
+-- 为博文表增加发布状态和发布时间，已有的博文均视为已发布，发布时间为创建时间
+ALTER TABLE `post` ADD COLUMN `status` TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE `post` ADD COLUMN `publishAt` DATETIME NULL DEFAULT NULL;
+UPDATE `post` SET `publishAt` = `createdAt` WHERE `publishAt` IS NULL;
+CREATE INDEX IF NOT EXISTS `idx_post_status_publishAt` ON `post` (`status`, `publishAt`);
@@ -0,0 +1,14 @@
+-- 博文修订历史表，每次修改博文标题或内容时保存修改后的快照，修订号为博文修改后的版本号
+-- 已有的博文以当前内容作为第一条修订
+CREATE TABLE IF NOT EXISTS `post_revision` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `postID` TEXT NOT NULL DEFAULT '',
+  `revision` INTEGER NOT NULL DEFAULT 1,
+  `userID` TEXT NOT NULL DEFAULT '',
+  `title` TEXT NOT NULL DEFAULT '',
+  `content` TEXT NOT NULL DEFAULT '',
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_post_revision_postID_revision` ON `post_revision` (`postID`, `revision`);
+INSERT INTO `post_revision` (`postID`, `revision`, `userID`, `title`, `content`, `createdAt`)
+  SELECT `postID`, `version`, `userID`, `title`, `content`, `updatedAt` FROM `post`;
@@ -0,0 +1,14 @@
+-- 评论表，parentID 为空的评论为顶层评论，回复的 rootID 为所在讨论串的顶层评论 ID
+CREATE TABLE IF NOT EXISTS `comment` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `commentID` TEXT NOT NULL DEFAULT '',
+  `postID` TEXT NOT NULL DEFAULT '',
+  `userID` TEXT NOT NULL DEFAULT '',
+  `rootID` TEXT NOT NULL DEFAULT '',
+  `parentID` TEXT NOT NULL DEFAULT '',
+  `content` TEXT NOT NULL DEFAULT '',
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_comment_commentID` ON `comment` (`commentID`);
+CREATE INDEX IF NOT EXISTS `idx_comment_postID_rootID` ON `comment` (`postID`, `rootID`, `createdAt`, `id`);
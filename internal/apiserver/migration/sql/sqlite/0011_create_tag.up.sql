@@ -0,0 +1,25 @@
+-- 标签表和分类表，名称统一为小写且唯一
+CREATE TABLE IF NOT EXISTS `tag` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` TEXT NOT NULL DEFAULT '',
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_tag_name` ON `tag` (`name`);
+CREATE TABLE IF NOT EXISTS `category` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` TEXT NOT NULL DEFAULT '',
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_category_name` ON `category` (`name`);
+-- 博文与标签的多对多关联表
+CREATE TABLE IF NOT EXISTS `post_tag` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `postID` TEXT NOT NULL DEFAULT '',
+  `tagID` INTEGER NOT NULL DEFAULT 0,
+  `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_post_tag_postID_tagID` ON `post_tag` (`postID`, `tagID`);
+CREATE INDEX IF NOT EXISTS `idx_post_tag_tagID` ON `post_tag` (`tagID`);
+-- 博文所属的分类，0 表示未分类
+ALTER TABLE `post` ADD COLUMN `categoryID` INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS `idx_post_categoryID` ON `post` (`categoryID`);
@@ -1,18 +1,70 @@
 package model
 
 import (
+	"github.com/wshadm/miniblog/internal/pkg/authn"
 	"github.com/wshadm/miniblog/internal/pkg/rid"
 	"gorm.io/gorm"
 )
 
-// AfterCreate 在创建数据库记录之后生成postID
-func (m *PostM) AfterCreate(tx *gorm.DB) error {
-	m.PostID = rid.PostID.New(uint64(m.ID))
-	return tx.Save(m).Error
+// BeforeCreate 在创建数据库记录之前生成 postID.
+// ID 在插入前生成，因此批量插入时每批只需要一次数据库写入.
+func (m *PostM) BeforeCreate(tx *gorm.DB) error {
+	if m.PostID == "" {
+		m.PostID = rid.PostID.Next()
+	}
+	// MySQL 不会回填列的默认值，这里显式设置初始版本号和状态
+	if m.Version == 0 {
+		m.Version = 1
+	}
+	if m.Status == "" {
+		m.Status = PostStatusPublished
+	}
+	return nil
 }
 
-// AfterCreate 在创建数据库记录之后生成 userID.
-func (m *UserM) AfterCreate(tx *gorm.DB) error {
-	m.UserID = rid.UserID.New(uint64(m.ID))
-	return tx.Save(m).Error
+// BeforeCreate 在创建数据库记录之前生成 commentID.
+func (m *CommentM) BeforeCreate(tx *gorm.DB) error {
+	if m.CommentID == "" {
+		m.CommentID = rid.CommentID.Next()
+	}
+	return nil
+}
+
+// BeforeCreate 在创建数据库记录之前生成 eventID.
+func (m *OutboxM) BeforeCreate(tx *gorm.DB) error {
+	if m.EventID == "" {
+		m.EventID = rid.EventID.Next()
+	}
+	return nil
+}
+
+// BeforeCreate 在创建数据库记录之前生成 userID，并加密明文密码.
+func (m *UserM) BeforeCreate(tx *gorm.DB) error {
+	if m.UserID == "" {
+		m.UserID = rid.UserID.Next()
+	}
+	// MySQL 不会回填列的默认值，这里显式设置初始版本号
+	if m.Version == 0 {
+		m.Version = 1
+	}
+	return m.encryptPassword()
+}
+
+// BeforeUpdate 在更新数据库记录之前加密明文密码，已加密的密码保持不变.
+func (m *UserM) BeforeUpdate(tx *gorm.DB) error {
+	return m.encryptPassword()
+}
+
+// encryptPassword 保证写入数据库的密码一定是 bcrypt 加密后的密码.
+func (m *UserM) encryptPassword() error {
+	if m.Password == "" || authn.IsEncrypted(m.Password) {
+		return nil
+	}
+
+	hashed, err := authn.Encrypt(m.Password)
+	if err != nil {
+		return err
+	}
+	m.Password = hashed
+	return nil
 }
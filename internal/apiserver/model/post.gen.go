@@ -6,19 +6,28 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 const TableNamePostM = "post"
 
 // PostM 博文表
 type PostM struct {
-	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	UserID    string    `gorm:"column:userID;not null;comment:用户唯一 ID" json:"userID"`                                  // 用户唯一 ID
-	PostID    string    `gorm:"column:postID;not null;uniqueIndex:idx_post_postID;comment:博文唯一 ID" json:"postID"`      // 博文唯一 ID
-	Title     string    `gorm:"column:title;not null;comment:博文标题" json:"title"`                                       // 博文标题
-	Content   string    `gorm:"column:content;not null;comment:博文内容" json:"content"`                                   // 博文内容
-	CreatedAt time.Time `gorm:"column:createdAt;not null;default:current_timestamp;comment:博文创建时间" json:"createdAt"`   // 博文创建时间
-	UpdatedAt time.Time `gorm:"column:updatedAt;not null;default:current_timestamp;comment:博文最后修改时间" json:"updatedAt"` // 博文最后修改时间
+	ID         int64          `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	UserID     string         `gorm:"column:userID;not null;comment:用户唯一 ID" json:"userID"`                                                                                                // 用户唯一 ID
+	PostID     string         `gorm:"column:postID;not null;uniqueIndex:idx_post_postID;comment:博文唯一 ID" json:"postID"`                                                                    // 博文唯一 ID
+	Title      string         `gorm:"column:title;not null;comment:博文标题" json:"title"`                                                                                                     // 博文标题
+	Content    string         `gorm:"column:content;not null;comment:博文内容" json:"content"`                                                                                                 // 博文内容
+	CreatedAt  time.Time      `gorm:"column:createdAt;not null;default:current_timestamp;comment:博文创建时间" json:"createdAt"`                                                                 // 博文创建时间
+	UpdatedAt  time.Time      `gorm:"column:updatedAt;not null;default:current_timestamp;comment:博文最后修改时间" json:"updatedAt"`                                                               // 博文最后修改时间
+	Version    int64          `gorm:"column:version;not null;default:1;comment:乐观锁版本号，每次更新加 1" json:"version"`                                                                             // 乐观锁版本号，每次更新加 1
+	Status     string         `gorm:"column:status;not null;default:published;index:idx_post_status_publishAt,priority:1;comment:博文状态：draft、scheduled、published 或 archived" json:"status"` // 博文状态：draft、scheduled、published 或 archived
+	PublishAt  *time.Time     `gorm:"column:publishAt;index:idx_post_status_publishAt,priority:2;comment:博文发布时间，定时发布的博文为计划发布时间" json:"publishAt"`                                          // 博文发布时间，定时发布的博文为计划发布时间
+	CategoryID int64          `gorm:"column:categoryID;not null;default:0;index:idx_post_categoryID;comment:分类 ID，0 表示未分类" json:"categoryID"`                                              // 分类 ID，0 表示未分类
+	LikeCount  int64          `gorm:"column:likeCount;not null;default:0;comment:点赞数" json:"likeCount"`                                                                                    // 点赞数
+	ViewCount  int64          `gorm:"column:viewCount;not null;default:0;comment:浏览数" json:"viewCount"`                                                                                    // 浏览数
+	DeletedAt  gorm.DeletedAt `gorm:"column:deletedAt;index:idx_post_deletedAt;comment:博文删除时间，NULL 表示未删除" json:"deletedAt"`                                                                // 博文删除时间，NULL 表示未删除
 }
 
 // TableName PostM's table name
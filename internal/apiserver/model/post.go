@@ -0,0 +1,13 @@
+package model
+
+// 博文状态. 只有已发布的博文对匿名用户可见.
+const (
+	// PostStatusDraft 表示草稿，只有作者可见.
+	PostStatusDraft = "draft"
+	// PostStatusScheduled 表示定时发布，到达 PublishAt 后由调度器发布.
+	PostStatusScheduled = "scheduled"
+	// PostStatusPublished 表示已发布.
+	PostStatusPublished = "published"
+	// PostStatusArchived 表示已归档，发布后被撤回但保留发布时间.
+	PostStatusArchived = "archived"
+)
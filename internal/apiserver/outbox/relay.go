@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"github.com/wshadm/miniblog/internal/pkg/server"
+)
+
+const (
+	// maxBackoff 为发布失败后重试的最大等待时间.
+	maxBackoff = time.Minute
+
+	// 事件元数据通过 Kafka 消息头传递.
+	HeaderEventID       = "event-id"
+	HeaderEventType     = "event-type"
+	HeaderAggregateType = "aggregate-type"
+)
+
+// Writer 定义了 relay 发布消息所需的方法，*kafka.Writer 实现了该接口.
+// 测试时可以替换为假的实现.
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Relay 定期从发件箱中读取未发布的事件并发布到 Kafka.
+// 事件发布成功后才会被标记为已发布，标记失败或进程退出时事件会被重新发布，
+// 因此投递语义为至少一次（at-least-once），消费者需要根据 event-id 去重.
+type Relay struct {
+	store     store.IStore
+	writer    Writer
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// 确保 Relay 实现了 server.Server 接口.
+var _ server.Server = (*Relay)(nil)
+
+// NewRelay 创建一个 Relay 实例. writer 需要同步写入，保证 WriteMessages 返回时消息已被 Kafka 确认.
+func NewRelay(store store.IStore, writer Writer, interval time.Duration, batchSize int) *Relay {
+	return &Relay{
+		store:     store,
+		writer:    writer,
+		interval:  interval,
+		batchSize: batchSize,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// RunOrDie 循环发布事件，直到 GracefulStop 被调用.
+// 发布失败时按指数退避重试，一批事件数量达到 batchSize 时立即发布下一批.
+func (r *Relay) RunOrDie() {
+	logger.L().Info().Dur("interval", r.interval).Int("batch-size", r.batchSize).Msg("Start outbox relay")
+	defer close(r.done)
+
+	wait := r.interval
+	for {
+		n, err := r.relay(context.Background())
+		wait = r.backoff(wait, n, err)
+		if err != nil {
+			logger.L().Warn().Err(err).Dur("retry-after", wait).Msg("Failed to relay outbox events")
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// GracefulStop 等待正在发布的一批事件完成后停止 relay，并关闭 writer.
+func (r *Relay) GracefulStop(ctx context.Context) {
+	logger.L().Info().Msg("Gracefully stop outbox relay")
+	close(r.stop)
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		logger.L().Warn().Err(ctx.Err()).Msg("Outbox relay did not stop in time")
+	}
+	if err := r.writer.Close(); err != nil {
+		logger.L().Error().Err(err).Msg("Failed to close outbox writer")
+	}
+}
+
+// backoff 根据上一次的等待时间 wait 和发布结果返回下一次发布前的等待时间.
+func (r *Relay) backoff(wait time.Duration, n int, err error) time.Duration {
+	switch {
+	case err != nil:
+		return min(max(wait*2, r.interval), maxBackoff)
+	case n == r.batchSize:
+		return 0
+	default:
+		return r.interval
+	}
+}
+
+// relay 发布一批未发布的事件，返回发布的事件数.
+func (r *Relay) relay(ctx context.Context) (int, error) {
+	events, err := r.store.Outbox().ListUnpublished(ctx, r.batchSize)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	msgs := make([]kafka.Message, 0, len(events))
+	ids := make([]int64, 0, len(events))
+	for _, evt := range events {
+		msgs = append(msgs, Message(evt))
+		ids = append(ids, evt.ID)
+	}
+	if err := r.writer.WriteMessages(ctx, msgs...); err != nil {
+		return 0, err
+	}
+	if err := r.store.Outbox().MarkPublished(ctx, ids); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+// Message 将发件箱中的事件转换为 Kafka 消息，使用聚合 ID 作为 key，保证同一资源的事件写入同一分区.
+func Message(evt *model.OutboxM) kafka.Message {
+	return kafka.Message{
+		Key:   []byte(evt.AggregateID),
+		Value: []byte(evt.Payload),
+		Time:  evt.CreatedAt,
+		Headers: []kafka.Header{
+			{Key: HeaderEventID, Value: []byte(evt.EventID)},
+			{Key: HeaderEventType, Value: []byte(evt.EventType)},
+			{Key: HeaderAggregateType, Value: []byte(evt.AggregateType)},
+		},
+	}
+}
@@ -0,0 +1,253 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+)
+
+var errWrite = errors.New("kafka unavailable")
+
+// fakeWriter 记录写入的消息，前 fail 次写入返回 errWrite.
+type fakeWriter struct {
+	mu      sync.Mutex
+	fail    int
+	calls   []time.Time
+	batches [][]kafka.Message
+	closed  bool
+	// onWrite 在每次写入时调用，用于检查写入时事件的状态
+	onWrite func(msgs []kafka.Message)
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.calls = append(w.calls, time.Now())
+	if w.onWrite != nil {
+		w.onWrite(msgs)
+	}
+	if w.fail > 0 {
+		w.fail--
+		return errWrite
+	}
+	w.batches = append(w.batches, msgs)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	return nil
+}
+
+// createEvents 向发件箱中写入 n 个事件，事件 ID 依次为 evt-1 到 evt-n.
+func createEvents(t *testing.T, s store.IStore, n int) {
+	t.Helper()
+
+	for i := 1; i <= n; i++ {
+		evt := &model.OutboxM{
+			EventID:       fmt.Sprintf("evt-%d", i),
+			EventType:     "post.created",
+			AggregateType: "post",
+			AggregateID:   fmt.Sprintf("post-%d", i),
+			Payload:       "{}",
+		}
+		if err := s.Outbox().Create(context.Background(), evt); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+	}
+}
+
+// eventIDs 返回消息头中的事件 ID.
+func eventIDs(msgs []kafka.Message) []string {
+	ids := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		for _, h := range msg.Headers {
+			if h.Key == HeaderEventID {
+				ids = append(ids, string(h.Value))
+			}
+		}
+	}
+	return ids
+}
+
+// unpublished 返回未发布的事件数.
+func unpublished(t *testing.T, s store.IStore) int {
+	t.Helper()
+
+	events, err := s.Outbox().ListUnpublished(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("ListUnpublished: %v", err)
+	}
+	return len(events)
+}
+
+func TestRelayMarksPublishedAfterWrite(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	createEvents(t, s, 3)
+
+	w := &fakeWriter{}
+	// 写入 Kafka 时事件还没有被标记为已发布
+	w.onWrite = func(msgs []kafka.Message) {
+		if n := unpublished(t, s); n != len(msgs) {
+			t.Errorf("unpublished events during write = %d, want %d", n, len(msgs))
+		}
+	}
+	r := NewRelay(s, w, time.Second, 10)
+
+	n, err := r.relay(ctx)
+	if err != nil || n != 3 {
+		t.Fatalf("relay() = %d, %v, want 3", n, err)
+	}
+	if n := unpublished(t, s); n != 0 {
+		t.Errorf("unpublished events after relay = %d, want 0", n)
+	}
+	_, events, err := s.Outbox().List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	for _, evt := range events {
+		if !evt.Published || evt.PublishedAt == nil {
+			t.Errorf("event %s published = %v, publishedAt = %v", evt.EventID, evt.Published, evt.PublishedAt)
+		}
+	}
+}
+
+func TestRelayWriteFailureKeepsEventsUnpublished(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	createEvents(t, s, 3)
+
+	w := &fakeWriter{fail: 1}
+	r := NewRelay(s, w, time.Second, 10)
+
+	if n, err := r.relay(ctx); !errors.Is(err, errWrite) || n != 0 {
+		t.Fatalf("relay() = %d, %v, want 0, %v", n, err, errWrite)
+	}
+	if n := unpublished(t, s); n != 3 {
+		t.Fatalf("unpublished events after failed write = %d, want 3", n)
+	}
+
+	// 重试时重新发布同一批事件
+	if n, err := r.relay(ctx); err != nil || n != 3 {
+		t.Fatalf("relay() retry = %d, %v, want 3", n, err)
+	}
+	if n := unpublished(t, s); n != 0 {
+		t.Errorf("unpublished events after retry = %d, want 0", n)
+	}
+	if len(w.batches) != 1 || !slices.Equal(eventIDs(w.batches[0]), []string{"evt-1", "evt-2", "evt-3"}) {
+		t.Errorf("written batches = %v, want one batch of evt-1 to evt-3", w.batches)
+	}
+}
+
+func TestRelayPreservesOrder(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	createEvents(t, s, 5)
+
+	w := &fakeWriter{}
+	r := NewRelay(s, w, time.Second, 2)
+	for {
+		n, err := r.relay(ctx)
+		if err != nil {
+			t.Fatalf("relay: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+	}
+
+	var ids, keys []string
+	var sizes []int
+	for _, batch := range w.batches {
+		sizes = append(sizes, len(batch))
+		ids = append(ids, eventIDs(batch)...)
+		for _, msg := range batch {
+			keys = append(keys, string(msg.Key))
+		}
+	}
+	if !slices.Equal(sizes, []int{2, 2, 1}) {
+		t.Errorf("batch sizes = %v, want [2 2 1]", sizes)
+	}
+	if want := []string{"evt-1", "evt-2", "evt-3", "evt-4", "evt-5"}; !slices.Equal(ids, want) {
+		t.Errorf("event IDs = %v, want %v", ids, want)
+	}
+	if want := []string{"post-1", "post-2", "post-3", "post-4", "post-5"}; !slices.Equal(keys, want) {
+		t.Errorf("message keys = %v, want %v", keys, want)
+	}
+}
+
+func TestRelayBackoff(t *testing.T) {
+	r := NewRelay(store.NewMemoryStore(), &fakeWriter{}, time.Second, 10)
+
+	tests := []struct {
+		name string
+		wait time.Duration
+		n    int
+		err  error
+		want time.Duration
+	}{
+		{"first failure", time.Second, 0, errWrite, 2 * time.Second},
+		{"repeated failure", 4 * time.Second, 0, errWrite, 8 * time.Second},
+		{"max backoff", 40 * time.Second, 0, errWrite, maxBackoff},
+		{"failure after full batch", 0, 0, errWrite, time.Second},
+		{"full batch", time.Second, 10, nil, 0},
+		{"partial batch", 8 * time.Second, 3, nil, time.Second},
+		{"no events", 0, 0, nil, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.backoff(tt.wait, tt.n, tt.err); got != tt.want {
+				t.Errorf("backoff(%v, %d, %v) = %v, want %v", tt.wait, tt.n, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelayRetriesWithBackoff(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	s := store.NewMemoryStore()
+	createEvents(t, s, 3)
+
+	w := &fakeWriter{fail: 2}
+	r := NewRelay(s, w, interval, 10)
+	go r.RunOrDie()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for unpublished(t, s) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("events were not published after retries")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	r.GracefulStop(ctx)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.closed {
+		t.Error("writer should be closed after GracefulStop")
+	}
+	if len(w.calls) < 3 {
+		t.Fatalf("write calls = %d, want at least 3", len(w.calls))
+	}
+	// 每次失败后的等待时间加倍
+	for i, want := range []time.Duration{2 * interval, 4 * interval} {
+		if gap := w.calls[i+1].Sub(w.calls[i]); gap < want {
+			t.Errorf("retry %d after %v, want at least %v", i+1, gap, want)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package conversion
+
+import (
+	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// BookmarkModelToBookmarkV1 将模型层的 BookmarkM 转换为 Protobuf 层的 Bookmark，不包含收藏的博客.
+func BookmarkModelToBookmarkV1(bookmarkModel *model.BookmarkM) *apiv1.Bookmark {
+	var protoBookmark apiv1.Bookmark
+	_ = core.CopyWithConverters(&protoBookmark, bookmarkModel)
+	return &protoBookmark
+}
@@ -0,0 +1,14 @@
+package conversion
+
+import (
+	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// CommentModelToCommentV1 将模型层的 CommentM 转换为 Protobuf 层的 Comment.
+func CommentModelToCommentV1(commentModel *model.CommentM) *apiv1.Comment {
+	var protoComment apiv1.Comment
+	_ = core.CopyWithConverters(&protoComment, commentModel)
+	return &protoComment
+}
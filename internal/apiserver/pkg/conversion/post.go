@@ -0,0 +1,76 @@
+package conversion
+
+import (
+	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/etag"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PostUpdateFields 为更新博客时 updateMask 允许的字段及其对应的数据库列.
+var PostUpdateFields = fieldmask.Fields{
+	"title":    "title",
+	"content":  "content",
+	"tags":     "",
+	"category": "categoryID",
+}
+
+// PostReadFields 为获取博客时 readMask 允许的字段及其对应的数据库列.
+var PostReadFields = fieldmask.Fields{
+	"postID":    "postID",
+	"userID":    "userID",
+	"title":     "title",
+	"content":   "content",
+	"createdAt": "createdAt",
+	"updatedAt": "updatedAt",
+	"status":    "status",
+	"publishAt": "publishAt",
+	"etag":      "version",
+	"tags":      "",
+	"category":  "categoryID",
+	"likeCount": "likeCount",
+	"viewCount": "viewCount",
+}
+
+// PostTags 返回更新博客请求中的标签名，忽略不是字符串的元素.
+func PostTags(tags *structpb.ListValue) []string {
+	names := make([]string, 0, len(tags.GetValues()))
+	for _, v := range tags.GetValues() {
+		if name, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+			names = append(names, name.StringValue)
+		}
+	}
+	return names
+}
+
+// PostModelToPostV1 将模型层的 PostM 转换为 Protobuf 层的 Post.
+func PostModelToPostV1(postModel *model.PostM) *apiv1.Post {
+	var protoPost apiv1.Post
+	_ = core.CopyWithConverters(&protoPost, postModel)
+	protoPost.Etag = etag.Format(postModel.Version)
+	protoPost.DeletedAt, protoPost.PublishAt = nil, nil
+	if postModel.DeletedAt.Valid {
+		protoPost.DeletedAt = timestamppb.New(postModel.DeletedAt.Time)
+	}
+	if postModel.PublishAt != nil {
+		protoPost.PublishAt = timestamppb.New(*postModel.PublishAt)
+	}
+	return &protoPost
+}
+
+// PostV1ToPostModel 将 Protobuf 层的 Post 转换为模型层的 PostM.
+func PostV1ToPostModel(protoPost *apiv1.Post) *model.PostM {
+	var postModel model.PostM
+	_ = core.CopyWithConverters(&postModel, protoPost)
+	return &postModel
+}
+
+// PostRevisionModelToPostRevisionV1 将模型层的 PostRevisionM 转换为 Protobuf 层的 PostRevision.
+func PostRevisionModelToPostRevisionV1(revisionModel *model.PostRevisionM) *apiv1.PostRevision {
+	var protoRevision apiv1.PostRevision
+	_ = core.CopyWithConverters(&protoRevision, revisionModel)
+	return &protoRevision
+}
@@ -0,0 +1,45 @@
+package conversion
+
+import (
+	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/etag"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+)
+
+// UserUpdateFields 为更新用户时 updateMask 允许的字段及其对应的数据库列.
+var UserUpdateFields = fieldmask.Fields{
+	"username": "username",
+	"nickname": "nickname",
+	"email":    "email",
+	"phone":    "phone",
+}
+
+// UserReadFields 为获取用户时 readMask 允许的字段及其对应的数据库列.
+var UserReadFields = fieldmask.Fields{
+	"userID":    "userID",
+	"username":  "username",
+	"nickname":  "nickname",
+	"email":     "email",
+	"phone":     "phone",
+	"createdAt": "createdAt",
+	"updatedAt": "updatedAt",
+	"etag":      "version",
+}
+
+// UserModelToUserV1 将模型层的 UserM 转换为 Protobuf 层的 User.
+// 注意：密码字段不会被拷贝到返回结果中.
+func UserModelToUserV1(userModel *model.UserM) *apiv1.User {
+	var protoUser apiv1.User
+	_ = core.CopyWithConverters(&protoUser, userModel)
+	protoUser.Etag = etag.Format(userModel.Version)
+	return &protoUser
+}
+
+// UserV1ToUserModel 将 Protobuf 层的 User 转换为模型层的 UserM.
+func UserV1ToUserModel(protoUser *apiv1.User) *model.UserM {
+	var userModel model.UserM
+	_ = core.CopyWithConverters(&userModel, protoUser)
+	return &userModel
+}
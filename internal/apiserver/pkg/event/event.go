@@ -0,0 +1,61 @@
+package event
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// 领域事件类型，格式为 <聚合类型>.<动作>.
+const (
+	UserCreated         = "user.created"
+	UserUpdated         = "user.updated"
+	UserPasswordChanged = "user.password_changed"
+	UserDeleted         = "user.deleted"
+
+	PostCreated     = "post.created"
+	PostUpdated     = "post.updated"
+	PostDeleted     = "post.deleted"
+	PostRestored    = "post.restored"
+	PostPublished   = "post.published"
+	PostUnpublished = "post.unpublished"
+)
+
+// New 创建一条待写入发件箱的领域事件，aggregateID 会作为 Kafka 消息的 key，保证同一资源的事件有序.
+// payload 为 proto.Message 时使用 protojson 序列化，否则使用 encoding/json 序列化.
+func New(eventType string, aggregateID string, payload any) (*model.OutboxM, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if msg, ok := payload.(proto.Message); ok {
+		data, err = protojson.Marshal(msg)
+	} else {
+		data, err = json.Marshal(payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	aggregateType, _, _ := strings.Cut(eventType, ".")
+	return &model.OutboxM{
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+	}, nil
+}
+
+// NewUserEvent 创建用户事件，事件内容为 v1.User，不包含密码.
+func NewUserEvent(eventType string, userM *model.UserM) (*model.OutboxM, error) {
+	return New(eventType, userM.UserID, conversion.UserModelToUserV1(userM))
+}
+
+// NewPostEvent 创建博客事件，事件内容为 v1.Post.
+func NewPostEvent(eventType string, postM *model.PostM) (*model.OutboxM, error) {
+	return New(eventType, postM.PostID, conversion.PostModelToPostV1(postM))
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"context"
+	"unicode/utf8"
+
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+// maxCommentLength 为评论内容的最大字符数.
+const maxCommentLength = 5000
+
+// ValidateCreateCommentRequest 校验创建评论请求.
+func (v *Validator) ValidateCreateCommentRequest(ctx context.Context, rq *apiv1.CreateCommentRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return validCommentContent(rq.GetContent())
+}
+
+// ValidateListCommentsRequest 校验列出评论请求.
+func (v *Validator) ValidateListCommentsRequest(ctx context.Context, rq *apiv1.ListCommentsRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	return nil
+}
+
+// ValidateUpdateCommentRequest 校验修改评论请求.
+func (v *Validator) ValidateUpdateCommentRequest(ctx context.Context, rq *apiv1.UpdateCommentRequest) error {
+	if rq.GetPostID() == "" || rq.GetCommentID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID and commentID cannot be empty")
+	}
+	return validCommentContent(rq.GetContent())
+}
+
+// ValidateDeleteCommentRequest 校验删除评论请求.
+func (v *Validator) ValidateDeleteCommentRequest(ctx context.Context, rq *apiv1.DeleteCommentRequest) error {
+	if rq.GetPostID() == "" || rq.GetCommentID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID and commentID cannot be empty")
+	}
+	return nil
+}
+
+// validCommentContent 校验评论内容不能为空且不能过长.
+func validCommentContent(content string) error {
+	if content == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("content cannot be empty")
+	}
+	if utf8.RuneCountInString(content) > maxCommentLength {
+		return errorsx.ErrInvalidArgument.WithMessage("content must be at most %d characters", maxCommentLength)
+	}
+	return nil
+}
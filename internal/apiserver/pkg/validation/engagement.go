@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"context"
+
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+// ValidateLikePostRequest 校验点赞博客请求.
+func (v *Validator) ValidateLikePostRequest(ctx context.Context, rq *apiv1.LikePostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateUnlikePostRequest 校验取消点赞请求.
+func (v *Validator) ValidateUnlikePostRequest(ctx context.Context, rq *apiv1.UnlikePostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateBookmarkPostRequest 校验收藏博客请求.
+func (v *Validator) ValidateBookmarkPostRequest(ctx context.Context, rq *apiv1.BookmarkPostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateUnbookmarkPostRequest 校验取消收藏请求.
+func (v *Validator) ValidateUnbookmarkPostRequest(ctx context.Context, rq *apiv1.UnbookmarkPostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateListBookmarksRequest 校验列出收藏请求.
+func (v *Validator) ValidateListBookmarksRequest(ctx context.Context, rq *apiv1.ListBookmarksRequest) error {
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	return nil
+}
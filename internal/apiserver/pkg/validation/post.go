@@ -0,0 +1,201 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+const (
+	// maxSearchQueryLength 为全文检索语句的最大字符数.
+	maxSearchQueryLength = 256
+	// maxSearchLimit 为全文检索每页的最大数量.
+	maxSearchLimit = 100
+)
+
+// ValidateCreatePostRequest 校验创建博客请求.
+func (v *Validator) ValidateCreatePostRequest(ctx context.Context, rq *apiv1.CreatePostRequest) error {
+	if rq.GetTitle() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("title cannot be empty")
+	}
+	switch rq.GetStatus() {
+	case "", model.PostStatusPublished:
+	case model.PostStatusDraft:
+		if rq.PublishAt != nil {
+			return errorsx.ErrInvalidArgument.WithMessage("publishAt cannot be set on a draft")
+		}
+	default:
+		return errorsx.ErrInvalidArgument.WithMessage("status must be %q or %q", model.PostStatusDraft, model.PostStatusPublished)
+	}
+	if rq.PublishAt != nil && !rq.GetPublishAt().IsValid() {
+		return errorsx.ErrInvalidArgument.WithMessage("publishAt is not a valid timestamp")
+	}
+	if err := validTags(rq.GetTags()); err != nil {
+		return err
+	}
+	return validCategory(rq.GetCategory())
+}
+
+// ValidateUpdatePostRequest 校验更新博客请求.
+func (v *Validator) ValidateUpdatePostRequest(ctx context.Context, rq *apiv1.UpdatePostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	paths, err := validMask(conversion.PostUpdateFields, rq.GetUpdateMask())
+	if err != nil {
+		return err
+	}
+	// 设置了 updateMask 时，掩码中未设置值的字段会被更新为空值，同样需要校验
+	if fieldmask.Has(paths, "title", rq.Title != nil) && rq.GetTitle() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("title cannot be empty")
+	}
+	if err := validTagList(rq.GetTags()); err != nil {
+		return err
+	}
+	return validCategory(rq.GetCategory())
+}
+
+// ValidateDeletePostsRequest 校验批量删除博客请求.
+func (v *Validator) ValidateDeletePostsRequest(ctx context.Context, rq *apiv1.DeletePostsRequest) error {
+	for _, postID := range rq.GetPostIDs() {
+		if postID == "" {
+			return errorsx.ErrInvalidArgument.WithMessage("postIDs cannot contain empty value")
+		}
+	}
+	return nil
+}
+
+// ValidateGetPostRequest 校验获取博客请求.
+func (v *Validator) ValidateGetPostRequest(ctx context.Context, rq *apiv1.GetPostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	_, err := validMask(conversion.PostReadFields, rq.GetReadMask())
+	return err
+}
+
+// ValidateListPostRequest 校验博客列表请求.
+func (v *Validator) ValidateListPostRequest(ctx context.Context, rq *apiv1.ListPostRequest) error {
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	// 分页令牌中的游标基于默认排序，不能用于自定义排序
+	if rq.GetPageToken() != "" && rq.GetOrderBy() != "" {
+		return errorsx.ErrInvalidArgument.WithMessage("pageToken cannot be used with orderBy")
+	}
+	_, err := validMask(conversion.PostReadFields, rq.GetReadMask())
+	return err
+}
+
+// ValidatePublishPostRequest 校验发布博客请求.
+func (v *Validator) ValidatePublishPostRequest(ctx context.Context, rq *apiv1.PublishPostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	if rq.PublishAt != nil && !rq.GetPublishAt().IsValid() {
+		return errorsx.ErrInvalidArgument.WithMessage("publishAt is not a valid timestamp")
+	}
+	return nil
+}
+
+// ValidateUnpublishPostRequest 校验撤回博客请求.
+func (v *Validator) ValidateUnpublishPostRequest(ctx context.Context, rq *apiv1.UnpublishPostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateSearchPostsRequest 校验全文检索博客请求.
+func (v *Validator) ValidateSearchPostsRequest(ctx context.Context, rq *apiv1.SearchPostsRequest) error {
+	if strings.TrimSpace(rq.GetQuery()) == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("query cannot be empty")
+	}
+	if utf8.RuneCountInString(rq.GetQuery()) > maxSearchQueryLength {
+		return errorsx.ErrInvalidArgument.WithMessage("query must be at most %d characters", maxSearchQueryLength)
+	}
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	if rq.GetLimit() > maxSearchLimit {
+		return errorsx.ErrInvalidArgument.WithMessage("limit must be at most %d", maxSearchLimit)
+	}
+	return nil
+}
+
+// ValidateListDeletedPostsRequest 校验回收站博客列表请求.
+func (v *Validator) ValidateListDeletedPostsRequest(ctx context.Context, rq *apiv1.ListDeletedPostsRequest) error {
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	return nil
+}
+
+// ValidateRestorePostRequest 校验恢复博客请求.
+func (v *Validator) ValidateRestorePostRequest(ctx context.Context, rq *apiv1.RestorePostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return nil
+}
+
+// ValidatePurgePostRequest 校验永久删除博客请求.
+func (v *Validator) ValidatePurgePostRequest(ctx context.Context, rq *apiv1.PurgePostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateListPostRevisionsRequest 校验列出博客修订请求.
+func (v *Validator) ValidateListPostRevisionsRequest(ctx context.Context, rq *apiv1.ListPostRevisionsRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	return nil
+}
+
+// ValidateGetPostRevisionRequest 校验获取博客修订请求.
+func (v *Validator) ValidateGetPostRevisionRequest(ctx context.Context, rq *apiv1.GetPostRevisionRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	if rq.GetRevision() <= 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("revision must be positive")
+	}
+	return nil
+}
+
+// ValidateRevertPostRequest 校验回滚博客请求.
+func (v *Validator) ValidateRevertPostRequest(ctx context.Context, rq *apiv1.RevertPostRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	if rq.GetRevision() <= 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("revision must be positive")
+	}
+	return nil
+}
+
+// ValidateDiffPostRevisionsRequest 校验比较博客修订请求.
+func (v *Validator) ValidateDiffPostRevisionsRequest(ctx context.Context, rq *apiv1.DiffPostRevisionsRequest) error {
+	if rq.GetPostID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	if rq.GetFrom() < 0 || rq.GetTo() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("from and to cannot be negative")
+	}
+	if rq.GetTo() != 0 && rq.GetFrom() >= rq.GetTo() {
+		return errorsx.ErrInvalidArgument.WithMessage("from must be less than to")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// maxTags 为每篇博客的最大标签数.
+	maxTags = 10
+	// maxNameLength 为标签名和分类名的最大字符数.
+	maxNameLength = 32
+)
+
+// ValidateListTagsRequest 校验列出标签请求.
+func (v *Validator) ValidateListTagsRequest(ctx context.Context, rq *apiv1.ListTagsRequest) error {
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	return nil
+}
+
+// validTags 校验博客的标签.
+func validTags(tags []string) error {
+	if len(tags) > maxTags {
+		return errorsx.ErrInvalidArgument.WithMessage("a post can have at most %d tags", maxTags)
+	}
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return errorsx.ErrInvalidArgument.WithMessage("tags cannot contain empty value")
+		}
+		if utf8.RuneCountInString(strings.TrimSpace(tag)) > maxNameLength {
+			return errorsx.ErrInvalidArgument.WithMessage("tag must be at most %d characters", maxNameLength)
+		}
+	}
+	return nil
+}
+
+// validTagList 校验更新博客请求中的标签，元素必须是字符串.
+func validTagList(tags *structpb.ListValue) error {
+	for _, v := range tags.GetValues() {
+		if _, ok := v.GetKind().(*structpb.Value_StringValue); !ok {
+			return errorsx.ErrInvalidArgument.WithMessage("tags must be strings")
+		}
+	}
+	return validTags(conversion.PostTags(tags))
+}
+
+// validCategory 校验博客的分类，空字符串表示未分类.
+func validCategory(category string) error {
+	if utf8.RuneCountInString(strings.TrimSpace(category)) > maxNameLength {
+		return errorsx.ErrInvalidArgument.WithMessage("category must be at most %d characters", maxNameLength)
+	}
+	return nil
+}
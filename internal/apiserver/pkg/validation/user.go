@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+// ValidateLoginRequest 校验登录请求.
+func (v *Validator) ValidateLoginRequest(ctx context.Context, rq *apiv1.LoginRequest) error {
+	if rq.GetUsername() == "" || rq.GetPassword() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("username and password cannot be empty")
+	}
+	return nil
+}
+
+// ValidateCreateUserRequest 校验创建用户请求.
+func (v *Validator) ValidateCreateUserRequest(ctx context.Context, rq *apiv1.CreateUserRequest) error {
+	if err := validUsername(rq.GetUsername()); err != nil {
+		return err
+	}
+	if err := validPassword(rq.GetPassword()); err != nil {
+		return err
+	}
+	if err := validEmail(rq.GetEmail()); err != nil {
+		return err
+	}
+	if err := validPhone(rq.GetPhone()); err != nil {
+		return err
+	}
+	return v.checkUsernameAvailable(ctx, rq.GetUsername())
+}
+
+// ValidateUpdateUserRequest 校验更新用户请求.
+func (v *Validator) ValidateUpdateUserRequest(ctx context.Context, rq *apiv1.UpdateUserRequest) error {
+	if rq.GetUserID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("userID cannot be empty")
+	}
+	paths, err := validMask(conversion.UserUpdateFields, rq.GetUpdateMask())
+	if err != nil {
+		return err
+	}
+	// 设置了 updateMask 时，掩码中未设置值的字段会被更新为空值，同样需要校验
+	if fieldmask.Has(paths, "username", rq.Username != nil) {
+		if err := validUsername(rq.GetUsername()); err != nil {
+			return err
+		}
+	}
+	if fieldmask.Has(paths, "email", rq.Email != nil) {
+		if err := validEmail(rq.GetEmail()); err != nil {
+			return err
+		}
+	}
+	if fieldmask.Has(paths, "phone", rq.Phone != nil) {
+		if err := validPhone(rq.GetPhone()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateChangePasswordRequest 校验修改密码请求.
+func (v *Validator) ValidateChangePasswordRequest(ctx context.Context, rq *apiv1.ChangePasswordRequest) error {
+	if rq.GetUserID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("userID cannot be empty")
+	}
+	if rq.GetOldPassword() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("old password cannot be empty")
+	}
+	return validPassword(rq.GetNewPassword())
+}
+
+// ValidateDeleteUserRequest 校验删除用户请求.
+func (v *Validator) ValidateDeleteUserRequest(ctx context.Context, rq *apiv1.DeleteUserRequest) error {
+	if rq.GetUserID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("userID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateGetUserRequest 校验获取用户请求.
+func (v *Validator) ValidateGetUserRequest(ctx context.Context, rq *apiv1.GetUserRequest) error {
+	if rq.GetUserID() == "" {
+		return errorsx.ErrInvalidArgument.WithMessage("userID cannot be empty")
+	}
+	_, err := validMask(conversion.UserReadFields, rq.GetReadMask())
+	return err
+}
+
+// ValidateListUsersRequest 校验用户列表请求.
+func (v *Validator) ValidateListUsersRequest(ctx context.Context, rq *apiv1.ListUsersRequest) error {
+	if rq.GetOffset() < 0 || rq.GetLimit() < 0 {
+		return errorsx.ErrInvalidArgument.WithMessage("offset and limit cannot be negative")
+	}
+	// 分页令牌中的游标基于默认排序，不能用于自定义排序
+	if rq.GetPageToken() != "" && rq.GetOrderBy() != "" {
+		return errorsx.ErrInvalidArgument.WithMessage("pageToken cannot be used with orderBy")
+	}
+	_, err := validMask(conversion.UserReadFields, rq.GetReadMask())
+	return err
+}
+
+// checkUsernameAvailable 检查用户名是否已被占用. 被软删除的用户在 Purge 之前仍然占用用户名.
+func (v *Validator) checkUsernameAvailable(ctx context.Context, username string) error {
+	exists, err := v.store.User().Exists(ctx, where.F("username", username))
+	if err != nil {
+		return errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	if exists {
+		return errno.ErrUserAlreadyExists
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"regexp"
+
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/authn"
+	"github.com/wshadm/miniblog/internal/pkg/fieldmask"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Validator 负责校验 API 请求参数.
+type Validator struct {
+	// 有些校验逻辑需要查询数据库，例如用户名是否已存在
+	store store.IStore
+}
+
+// 使用预编译的全局正则表达式，避免重复编译.
+var (
+	usernameRegex = regexp.MustCompile(`^[A-Za-z0-9_]{3,20}$`)                             // 3 到 20 位字母、数字或下划线
+	letterRegex   = regexp.MustCompile(`[A-Za-z]`)                                         // 至少包含一个字母
+	numberRegex   = regexp.MustCompile(`\d`)                                               // 至少包含一个数字
+	emailRegex    = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`) // 邮箱格式
+	phoneRegex    = regexp.MustCompile(`^1[3-9]\d{9}$`)                                    // 中国大陆手机号
+)
+
+// New 创建一个新的 Validator 实例.
+func New(store store.IStore) *Validator {
+	return &Validator{store: store}
+}
+
+// validMask 校验字段掩码中的字段是否都在 fields 中，并返回掩码中的字段名.
+func validMask(fields fieldmask.Fields, mask *fieldmaskpb.FieldMask) ([]string, error) {
+	paths, err := fields.Paths(mask)
+	if err != nil {
+		return nil, errorsx.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+	return paths, nil
+}
+
+// validUsername 校验用户名是否合法.
+func validUsername(username string) error {
+	if !usernameRegex.MatchString(username) {
+		return errorsx.ErrInvalidArgument.WithMessage("username must be 3-20 characters of letters, digits or underscores")
+	}
+	return nil
+}
+
+// validPassword 校验密码是否满足复杂度要求.
+func validPassword(password string) error {
+	switch {
+	case password == "":
+		return errorsx.ErrInvalidArgument.WithMessage("password cannot be empty")
+	case len(password) < 6:
+		return errorsx.ErrInvalidArgument.WithMessage("password must be at least 6 characters long")
+	case !letterRegex.MatchString(password):
+		return errorsx.ErrInvalidArgument.WithMessage("password must contain at least one letter")
+	case !numberRegex.MatchString(password):
+		return errorsx.ErrInvalidArgument.WithMessage("password must contain at least one number")
+	// 形如 bcrypt 密文的密码不会被再次加密，因此不允许使用
+	case authn.IsEncrypted(password):
+		return errorsx.ErrInvalidArgument.WithMessage("password cannot be a bcrypt hash")
+	}
+	return nil
+}
+
+// validEmail 校验电子邮箱格式，空值表示未设置.
+func validEmail(email string) error {
+	if email != "" && !emailRegex.MatchString(email) {
+		return errorsx.ErrInvalidArgument.WithMessage("invalid email format")
+	}
+	return nil
+}
+
+// validPhone 校验手机号格式，空值表示未设置.
+func validPhone(phone string) error {
+	if phone != "" && !phoneRegex.MatchString(phone) {
+		return errorsx.ErrInvalidArgument.WithMessage("invalid phone format")
+	}
+	return nil
+}
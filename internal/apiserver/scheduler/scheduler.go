@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/pkg/event"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"github.com/wshadm/miniblog/internal/pkg/server"
+	"gorm.io/gorm/clause"
+)
+
+// batchSize 为每次查询的定时发布博客数量.
+const batchSize = 100
+
+// Scheduler 定期将到达发布时间的定时发布博客改为已发布.
+// 博客通过版本号更新，多个实例同时运行时同一篇博客只会被其中一个实例发布.
+type Scheduler struct {
+	store    store.IStore
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// 确保 Scheduler 实现了 server.Server 接口.
+var _ server.Server = (*Scheduler)(nil)
+
+// NewScheduler 创建一个 Scheduler 实例.
+func NewScheduler(store store.IStore, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// RunOrDie 每隔 interval 发布一次到期的博客，直到 GracefulStop 被调用.
+// 发布失败时只记录日志，等待下一次发布.
+func (s *Scheduler) RunOrDie() {
+	logger.L().Info().Dur("interval", s.interval).Msg("Start publish scheduler")
+	defer close(s.done)
+
+	for {
+		s.publishDue(context.Background())
+
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(s.interval):
+		}
+	}
+}
+
+// GracefulStop 等待正在进行的发布完成后停止 scheduler.
+func (s *Scheduler) GracefulStop(ctx context.Context) {
+	logger.L().Info().Msg("Gracefully stop publish scheduler")
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		logger.L().Warn().Err(ctx.Err()).Msg("Publish scheduler did not stop in time")
+	}
+}
+
+// publishDue 分批发布发布时间不晚于当前时间的博客.
+func (s *Scheduler) publishDue(ctx context.Context) {
+	// publishAt 以 UTC 写入，在 SQLite 中与查询参数使用相同的时间格式，可以直接比较
+	now := time.Now().UTC()
+	whr := where.F("status", model.PostStatusScheduled).
+		C(clause.Lte{Column: clause.Column{Name: "publishAt"}, Value: now}).
+		L(batchSize)
+
+	for {
+		_, posts, err := s.store.Post().List(ctx, whr)
+		if err != nil {
+			logger.L().Warn().Err(err).Msg("Failed to list scheduled posts")
+			return
+		}
+
+		published := 0
+		for _, postM := range posts {
+			if err := s.publish(ctx, postM); err != nil {
+				// 博客在查询后被修改时跳过，下一次发布时重新判断
+				if !errors.Is(err, store.ErrVersionConflict) {
+					logger.L().Warn().Err(err).Str("postID", postM.PostID).Msg("Failed to publish scheduled post")
+				}
+				continue
+			}
+			published++
+		}
+		if published > 0 {
+			logger.L().Info().Int("count", published).Msg("Published scheduled posts")
+		}
+
+		// 没有成功发布任何博客时停止，避免反复查询同一批博客
+		if len(posts) < batchSize || published == 0 {
+			return
+		}
+	}
+}
+
+// publish 将博客改为已发布并写入发布事件.
+func (s *Scheduler) publish(ctx context.Context, postM *model.PostM) error {
+	postM.Status = model.PostStatusPublished
+	return s.store.TX(ctx, func(ctx context.Context) error {
+		if err := s.store.Post().UpdateColumns(ctx, postM, "status"); err != nil {
+			return err
+		}
+		evt, err := event.NewPostEvent(event.PostPublished, postM)
+		if err != nil {
+			return err
+		}
+		return s.store.Outbox().Create(ctx, evt)
+	})
+}
@@ -0,0 +1,218 @@
+// Package search 实现了基于 bleve 的博客全文索引.
+// 索引只收录已发布且未被删除的博客，标题和正文使用 CJK 分词器，支持中文检索.
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// docType 为索引中博客文档的类型.
+	docType = "post"
+	// batchSize 为重建索引时每批读取和写入的博客数量.
+	batchSize = 500
+)
+
+// ErrInvalidQuery 表示检索语句无法解析.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// Index 是博客的全文索引，可以被多个 goroutine 并发使用.
+type Index struct {
+	index bleve.Index
+}
+
+// document 是索引中的博客文档.
+type document struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	UserID  string `json:"userID"`
+}
+
+// Type 实现 bleve 的 mapping.Classifier 接口.
+func (document) Type() string {
+	return docType
+}
+
+// Request 是一次全文检索的请求.
+type Request struct {
+	// Query 为 bleve 查询字符串语法的检索语句，使用双引号检索短语，例如 "全文 检索" 博客
+	Query  string
+	Offset int
+	Limit  int
+}
+
+// Result 是全文检索的结果，Hits 按相关度倒序排列.
+type Result struct {
+	Total uint64
+	Hits  []*Hit
+}
+
+// Hit 是一条命中的博客.
+type Hit struct {
+	PostID string
+	Score  float64
+	// Fragments 为 title、content 字段中命中的片段，命中的词使用 <mark></mark> 标记，其余内容经过 HTML 转义
+	Fragments map[string][]string
+}
+
+// Open 打开 path 处的索引，path 不存在时创建新的索引，path 为空时使用内存索引.
+// created 为 true 表示索引是新创建的，需要调用 Rebuild 从数据库中导入博客.
+func Open(path string) (idx *Index, created bool, err error) {
+	if path == "" {
+		index, err := bleve.NewMemOnly(newMapping())
+		if err != nil {
+			return nil, false, err
+		}
+		return &Index{index: index}, true, nil
+	}
+
+	index, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = bleve.New(path, newMapping())
+		created = true
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open search index %q: %w", path, err)
+	}
+	return &Index{index: index}, created, nil
+}
+
+// Reindex 从 s 中重新构建 path 处的索引并返回索引的博客数.
+// 新索引先写入临时目录，构建完成后再替换旧索引，因此需要在 mb-apiserver 停止时执行.
+func Reindex(ctx context.Context, path string, s store.IStore) (int64, error) {
+	tmp := path + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return 0, err
+	}
+	index, err := bleve.New(tmp, newMapping())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create search index %q: %w", tmp, err)
+	}
+
+	idx := &Index{index: index}
+	count, err := idx.Rebuild(ctx, s)
+	if cerr := idx.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return 0, err
+	}
+	return count, os.Rename(tmp, path)
+}
+
+// newMapping 返回博客索引的映射，标题和正文使用 CJK 分词器并保存原文和词向量，用于高亮.
+func newMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = cjk.AnalyzerName
+
+	// userID 只用于按作者过滤，例如 userID:user-xxx，不参与未指定字段的检索
+	userID := bleve.NewKeywordFieldMapping()
+	userID.IncludeInAll = false
+
+	post := bleve.NewDocumentStaticMapping()
+	post.AddFieldMappingsAt("title", text)
+	post.AddFieldMappingsAt("content", text)
+	post.AddFieldMappingsAt("userID", userID)
+
+	m := bleve.NewIndexMapping()
+	// 未指定字段的检索词在 _all 字段中查询，使用与标题和正文相同的分词器
+	m.DefaultAnalyzer = cjk.AnalyzerName
+	m.AddDocumentMapping(docType, post)
+	return m
+}
+
+// Index 将博客写入索引. 博客未发布或已被删除时从索引中移除.
+func (i *Index) Index(post *model.PostM) error {
+	if post.Status != model.PostStatusPublished || post.DeletedAt.Valid {
+		return i.Delete(post.PostID)
+	}
+	return i.index.Index(post.PostID, newDocument(post))
+}
+
+// Delete 从索引中移除博客，博客不在索引中时不返回错误.
+func (i *Index) Delete(postID string) error {
+	return i.index.Delete(postID)
+}
+
+// Rebuild 将 s 中所有已发布的博客分批写入索引，返回写入的博客数.
+func (i *Index) Rebuild(ctx context.Context, s store.IStore) (int64, error) {
+	var count int64
+	var lastID int64
+	for {
+		whr := where.F("status", model.PostStatusPublished).
+			C(clause.Gt{Column: clause.Column{Name: "id"}, Value: lastID}).
+			C(clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "id"}}}}).
+			L(batchSize)
+		_, posts, err := s.Post().List(ctx, whr)
+		if err != nil {
+			return count, err
+		}
+
+		batch := i.index.NewBatch()
+		for _, post := range posts {
+			if err := batch.Index(post.PostID, newDocument(post)); err != nil {
+				return count, err
+			}
+			lastID = post.ID
+		}
+		if err := i.index.Batch(batch); err != nil {
+			return count, err
+		}
+		count += int64(len(posts))
+
+		if len(posts) < batchSize {
+			logger.L().Info().Int64("count", count).Msg("Rebuilt search index")
+			return count, nil
+		}
+	}
+}
+
+// Search 按相关度检索博客，并返回标题和正文中命中的片段.
+func (i *Index) Search(ctx context.Context, rq *Request) (*Result, error) {
+	q := bleve.NewQueryStringQuery(rq.Query)
+	if _, err := q.Parse(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+
+	srq := bleve.NewSearchRequestOptions(q, rq.Limit, rq.Offset, false)
+	srq.Highlight = bleve.NewHighlightWithStyle(html.Name)
+	srq.Highlight.AddField("title")
+	srq.Highlight.AddField("content")
+	res, err := i.index.SearchInContext(ctx, srq)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Result{Total: res.Total, Hits: make([]*Hit, 0, len(res.Hits))}
+	for _, hit := range res.Hits {
+		ret.Hits = append(ret.Hits, &Hit{PostID: hit.ID, Score: hit.Score, Fragments: hit.Fragments})
+	}
+	return ret, nil
+}
+
+// Close 关闭索引.
+func (i *Index) Close() error {
+	return i.index.Close()
+}
+
+// newDocument 将博客转换为索引文档.
+func newDocument(post *model.PostM) document {
+	return document{Title: post.Title, Content: post.Content, UserID: post.UserID}
+}
@@ -0,0 +1,393 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"gorm.io/gorm"
+)
+
+// newTestIndex 创建内存索引，并写入 posts.
+func newTestIndex(t *testing.T, posts ...*model.PostM) *Index {
+	t.Helper()
+
+	idx, created, err := Open("")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !created {
+		t.Error("Open(\"\") should create a new index")
+	}
+	t.Cleanup(func() { _ = idx.Close() })
+
+	for _, post := range posts {
+		if err := idx.Index(post); err != nil {
+			t.Fatalf("Index(%s): %v", post.PostID, err)
+		}
+	}
+	return idx
+}
+
+// published 返回已发布的博客.
+func published(postID, title, content string) *model.PostM {
+	return &model.PostM{PostID: postID, UserID: "user-a", Title: title, Content: content, Status: model.PostStatusPublished}
+}
+
+// search 返回命中 query 的博客 ID，按 ID 排序.
+func search(t *testing.T, idx *Index, query string) []string {
+	t.Helper()
+
+	res, err := idx.Search(context.Background(), &Request{Query: query, Limit: 100})
+	if err != nil {
+		t.Fatalf("Search(%q): %v", query, err)
+	}
+	ids := make([]string, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.PostID)
+	}
+	if res.Total != uint64(len(ids)) {
+		t.Errorf("Search(%q) total = %d, want %d", query, res.Total, len(ids))
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	other := published("post-3", "Rust notes", "ownership and borrowing")
+	other.UserID = "user-b"
+	idx := newTestIndex(t,
+		published("post-1", "Go concurrency", "goroutines and channels"),
+		published("post-2", "Database", "Go with MySQL and SQLite"),
+		other,
+	)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"go", []string{"post-1", "post-2"}},
+		{"GOROUTINES", []string{"post-1"}},
+		{"title:go", []string{"post-1"}},
+		{"content:go", []string{"post-2"}},
+		{"rust ownership", []string{"post-3"}},
+		{`"MySQL and SQLite"`, []string{"post-2"}},
+		{`"SQLite and MySQL"`, []string{}},
+		{"+go +channels", []string{"post-1"}},
+		{"go -channels", []string{"post-2"}},
+		{"userID:user-b", []string{"post-3"}},
+		// userID 不参与未指定字段的检索
+		{"user-b", []string{}},
+		{"python", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := search(t, idx, tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchCJK(t *testing.T) {
+	idx := newTestIndex(t,
+		published("post-1", "全文检索", "使用 bleve 为博客建立索引"),
+		published("post-2", "数据库", "博客保存在数据库中，支持全文检索和 SQL 查询"),
+		published("post-3", "日本語", "東京の天気"),
+	)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// 中文没有空格，按相邻两个字切分后检索
+		{"检索", []string{"post-1", "post-2"}},
+		{"title:检索", []string{"post-1"}},
+		{"建立索引", []string{"post-1"}},
+		{"数据库", []string{"post-2"}},
+		{`"全文检索"`, []string{"post-1", "post-2"}},
+		{`"检索全文"`, []string{}},
+		{"+博客 +数据", []string{"post-2"}},
+		{"东京", []string{}},
+		{"東京", []string{"post-3"}},
+		{"天气", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := search(t, idx, tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIndexExcludesUnpublished 检查索引只收录已发布且未被删除的博客.
+func TestIndexExcludesUnpublished(t *testing.T) {
+	draft := published("post-2", "golang draft", "")
+	draft.Status = model.PostStatusDraft
+	scheduled := published("post-3", "golang scheduled", "")
+	scheduled.Status = model.PostStatusScheduled
+	trashed := published("post-4", "golang trashed", "")
+	trashed.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	idx := newTestIndex(t, published("post-1", "golang published", ""), draft, scheduled, trashed)
+
+	if got := search(t, idx, "golang"); !slices.Equal(got, []string{"post-1"}) {
+		t.Fatalf("Search() = %v, want [post-1]", got)
+	}
+
+	// 已索引的博客变为草稿、归档或移入回收站后从索引中移除
+	for _, change := range []func(*model.PostM){
+		func(p *model.PostM) { p.Status = model.PostStatusDraft },
+		func(p *model.PostM) { p.Status = model.PostStatusArchived },
+		func(p *model.PostM) { p.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true} },
+	} {
+		post := published("post-1", "golang published", "")
+		if err := idx.Index(post); err != nil {
+			t.Fatalf("Index: %v", err)
+		}
+		change(post)
+		if err := idx.Index(post); err != nil {
+			t.Fatalf("Index: %v", err)
+		}
+		if got := search(t, idx, "golang"); len(got) != 0 {
+			t.Errorf("Search() after unpublishing = %v, want none", got)
+		}
+	}
+
+	// 博客不在索引中时 Delete 不返回错误
+	if err := idx.Delete("post-unknown"); err != nil {
+		t.Errorf("Delete(unknown) = %v, want nil", err)
+	}
+}
+
+func TestSearchHighlight(t *testing.T) {
+	idx := newTestIndex(t,
+		published("post-1", "全文检索入门", "<script>alert(1)</script> 介绍 Go 的全文检索"),
+	)
+
+	res, err := idx.Search(context.Background(), &Request{Query: "检索", Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(res.Hits) != 1 || res.Hits[0].PostID != "post-1" || res.Hits[0].Score <= 0 {
+		t.Fatalf("Search() hits = %+v, want post-1", res.Hits)
+	}
+
+	fragments := res.Hits[0].Fragments
+	for _, field := range []string{"title", "content"} {
+		if len(fragments[field]) == 0 || !strings.Contains(fragments[field][0], "<mark>检索</mark>") {
+			t.Errorf("%s fragments = %q, want 检索 marked", field, fragments[field])
+		}
+	}
+	// 正文中的 HTML 被转义，只保留 <mark> 标记
+	if content := strings.Join(fragments["content"], ""); strings.Contains(content, "<script>") || !strings.Contains(content, "&lt;script&gt;") {
+		t.Errorf("content fragments = %q, want HTML escaped", content)
+	}
+}
+
+func TestSearchPaging(t *testing.T) {
+	var posts []*model.PostM
+	for i := range 5 {
+		posts = append(posts, published(fmt.Sprintf("post-%d", i), "golang", ""))
+	}
+	idx := newTestIndex(t, posts...)
+
+	seen := make(map[string]bool)
+	for offset := 0; offset < 6; offset += 2 {
+		res, err := idx.Search(context.Background(), &Request{Query: "golang", Offset: offset, Limit: 2})
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if res.Total != 5 {
+			t.Errorf("offset %d total = %d, want 5", offset, res.Total)
+		}
+		if want := min(2, 5-offset); len(res.Hits) != want {
+			t.Errorf("offset %d hits = %d, want %d", offset, len(res.Hits), want)
+		}
+		for _, hit := range res.Hits {
+			if seen[hit.PostID] {
+				t.Errorf("post %s is returned twice", hit.PostID)
+			}
+			seen[hit.PostID] = true
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("returned %d posts, want 5", len(seen))
+	}
+}
+
+func TestSearchInvalidQuery(t *testing.T) {
+	idx := newTestIndex(t)
+
+	for _, query := range []string{`"unterminated`, "+", "title:"} {
+		if _, err := idx.Search(context.Background(), &Request{Query: query, Limit: 10}); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Search(%q) error = %v, want %v", query, err, ErrInvalidQuery)
+		}
+	}
+}
+
+// newTestStore 创建内存存储，并写入 n 篇已发布的博客和一篇草稿、一篇回收站中的博客.
+func newTestStore(t *testing.T, n int) store.IStore {
+	t.Helper()
+
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	posts := make([]*model.PostM, 0, n+2)
+	for i := range n {
+		posts = append(posts, &model.PostM{UserID: "user-a", Title: fmt.Sprintf("golang %d", i), Status: model.PostStatusPublished})
+	}
+	posts = append(posts,
+		&model.PostM{UserID: "user-a", Title: "golang draft", Status: model.PostStatusDraft},
+		&model.PostM{UserID: "user-a", Title: "golang trashed", Status: model.PostStatusPublished},
+	)
+	if err := s.Post().CreateInBatches(ctx, posts, len(posts)); err != nil {
+		t.Fatalf("CreateInBatches: %v", err)
+	}
+	if err := s.Post().Delete(ctx, where.F("postID", posts[len(posts)-1].PostID)); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	return s
+}
+
+// TestRebuild 检查 Rebuild 分批导入所有已发布的博客.
+func TestRebuild(t *testing.T) {
+	n := 2*batchSize + 1
+	s := newTestStore(t, n)
+	idx := newTestIndex(t)
+
+	count, err := idx.Rebuild(context.Background(), s)
+	if err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	if count != int64(n) {
+		t.Errorf("Rebuild() = %d, want %d", count, n)
+	}
+	res, err := idx.Search(context.Background(), &Request{Query: "golang", Limit: 1})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if res.Total != uint64(n) {
+		t.Errorf("Search() total = %d, want %d", res.Total, n)
+	}
+}
+
+// TestReindex 检查 Reindex 替换磁盘上的旧索引，重新打开后可以检索.
+func TestReindex(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "posts.bleve")
+
+	// 旧索引中的博客在数据库中已不存在
+	old, created, err := Open(path)
+	if err != nil || !created {
+		t.Fatalf("Open() = %v, %v, want a new index", created, err)
+	}
+	if err := old.Index(published("post-stale", "golang stale", "")); err != nil {
+		t.Fatalf("Index: %v", err)
+	}
+	if err := old.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	count, err := Reindex(ctx, path, newTestStore(t, 3))
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Reindex() = %d, want 3", count)
+	}
+
+	idx, created, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer idx.Close()
+	if created {
+		t.Error("Open() after Reindex should open the existing index")
+	}
+	got := search(t, idx, "golang")
+	if len(got) != 3 || slices.Contains(got, "post-stale") {
+		t.Errorf("Search() after Reindex = %v, want the 3 published posts", got)
+	}
+}
+
+// TestIndexedStore 检查博客被修改、移入回收站、恢复后同步到索引，回滚的事务不会写入索引.
+func TestIndexedStore(t *testing.T) {
+	ctx := context.Background()
+	idx := newTestIndex(t)
+	s := store.NewIndexedStore(store.NewMemoryStore(), idx)
+
+	post := &model.PostM{UserID: "user-a", Title: "golang", Status: model.PostStatusPublished}
+	if err := s.Post().Create(ctx, post); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	byID := where.F("postID", post.PostID)
+	check := func(step string, query string, want ...string) {
+		t.Helper()
+		if got := search(t, idx, query); !slices.Equal(got, want) {
+			t.Errorf("Search(%q) after %s = %v, want %v", query, step, got, want)
+		}
+	}
+	check("Create", "golang", post.PostID)
+
+	post.Title = "rust"
+	if err := s.Post().Update(ctx, post); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	check("Update", "golang")
+	check("Update", "rust", post.PostID)
+
+	if err := s.Post().Delete(ctx, byID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	check("Delete", "rust")
+
+	if n, err := s.Post().Restore(ctx, byID); err != nil || n != 1 {
+		t.Fatalf("Restore() = %d, %v, want 1", n, err)
+	}
+	check("Restore", "rust", post.PostID)
+
+	// 没有 postID 条件时按条件查询受影响的博客
+	if err := s.Post().Delete(ctx, where.F("userID", "user-a")); err != nil {
+		t.Fatalf("Delete by userID: %v", err)
+	}
+	check("Delete by userID", "rust")
+	if n, err := s.Post().Restore(ctx, where.F("userID", "user-a")); err != nil || n != 1 {
+		t.Fatalf("Restore by userID = %d, %v, want 1", n, err)
+	}
+	check("Restore by userID", "rust", post.PostID)
+
+	// 事务回滚时索引保持不变
+	errRollback := errors.New("rollback")
+	err := s.TX(ctx, func(ctx context.Context) error {
+		post.Status = model.PostStatusDraft
+		if err := s.Post().UpdateColumns(ctx, post, "status"); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("TX() = %v, want %v", err, errRollback)
+	}
+	check("rolled back TX", "rust", post.PostID)
+
+	// 回滚后重新读取博客，得到数据库中的版本号
+	post, err = s.Post().Get(ctx, byID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	post.Status = model.PostStatusDraft
+	if err := s.TX(ctx, func(ctx context.Context) error {
+		return s.Post().UpdateColumns(ctx, post, "status")
+	}); err != nil {
+		t.Fatalf("TX: %v", err)
+	}
+	check("unpublishing", "rust")
+}
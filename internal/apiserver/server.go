@@ -7,9 +7,27 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/onexstack/onexstack/pkg/token"
+	"github.com/redis/go-redis/v9"
+	"github.com/wshadm/miniblog/internal/apiserver/biz"
+	kafkahandler "github.com/wshadm/miniblog/internal/apiserver/handler/kafka"
+	"github.com/wshadm/miniblog/internal/apiserver/migration"
+	"github.com/wshadm/miniblog/internal/apiserver/outbox"
+	"github.com/wshadm/miniblog/internal/apiserver/scheduler"
+	"github.com/wshadm/miniblog/internal/apiserver/search"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/apiserver/trash"
+	"github.com/wshadm/miniblog/internal/apiserver/viewcount"
+	"github.com/wshadm/miniblog/internal/pkg/authn"
+	"github.com/wshadm/miniblog/internal/pkg/authz"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/known"
 	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"github.com/wshadm/miniblog/internal/pkg/pagetoken"
 	"github.com/wshadm/miniblog/internal/pkg/server"
 	"github.com/wshadm/miniblog/pkg/options"
+	"gorm.io/gorm"
 )
 
 const (
@@ -24,33 +42,106 @@ const (
 	GinServerMode = "gin"
 )
 
+const (
+	// DBStore 定义数据库存储，数据保存在 db 配置指定的数据库中.
+	DBStore = "db"
+	// MemoryStore 定义内存存储，数据只保存在进程内存中，用于单元测试和本地演示.
+	MemoryStore = "memory"
+)
+
+const (
+	// MemoryViewCountBuffer 定义在进程内存中缓冲博客的浏览次数，进程异常退出时会丢失尚未写入数据库的浏览次数.
+	MemoryViewCountBuffer = "memory"
+	// RedisViewCountBuffer 定义在 Redis 中缓冲博客的浏览次数，多个实例共享同一个缓冲.
+	RedisViewCountBuffer = "redis"
+)
+
 // Config 配置结构体，用于存储应用相关的配置
 // 不用viper.Get 是因为这种方式能更加清晰的知道应用提供了哪些配置项
 type Config struct {
 	ServerMode  string
+	Store       string
 	JWTKey      string
 	Expiration  time.Duration
 	GRPCOptions *options.GRPCOptions
 	HTTPOptions *options.HTTPOptions
+	DBOptions   *options.DBOptions
+	// RedisOptions 为 Redis 配置，EnableCache 为 true 或 ViewCountBuffer 为 redis 时使用
+	RedisOptions *options.RedisOptions
+	// EnableCache 为 true 时，使用 Redis 缓存用户和博客的查询结果
+	EnableCache bool
+	// CacheTTL 为缓存的过期时间
+	CacheTTL time.Duration
+	// KafkaOptions 为 Kafka 配置，EnableOutboxRelay 为 true 时使用
+	KafkaOptions *options.KafkaOptions
+	// EnableOutboxRelay 为 true 时，启动后台 relay 将发件箱中的事件发布到 Kafka
+	EnableOutboxRelay bool
+	// OutboxRelayInterval 为 relay 轮询发件箱的时间间隔
+	OutboxRelayInterval time.Duration
+	// EnableWorker 为 true 时，启动 Kafka 消费者处理 KafkaOptions.Topic 中的领域事件
+	EnableWorker bool
+	// WorkerMaxRetries 为消息处理失败后的最大重试次数，超过后写入死信主题
+	WorkerMaxRetries int
+	// WorkerRetryBackoff 为消息处理失败后第一次重试前的等待时间
+	WorkerRetryBackoff time.Duration
+	// TrashRetention 为回收站中的博客和已删除用户的保留期限，超过后被永久删除，为 0 时不清理
+	TrashRetention time.Duration
+	// TrashPurgeInterval 为清理回收站的时间间隔
+	TrashPurgeInterval time.Duration
+	// PublishSchedulerInterval 为检查定时发布博客的时间间隔，为 0 时不启动定时发布
+	PublishSchedulerInterval time.Duration
+	// SearchIndexPath 为博客全文索引的目录，为空时使用内存索引并在启动时从数据库重建
+	SearchIndexPath string
+	// ViewCountBuffer 为缓冲博客浏览次数的位置，memory 或 redis
+	ViewCountBuffer string
+	// ViewCountFlushInterval 为将缓冲的浏览次数批量写入数据库的时间间隔
+	ViewCountFlushInterval time.Duration
+	// AutoMigrate 为 true 时，启动时自动执行数据库迁移
+	AutoMigrate bool
+	// BcryptCost 为加密用户密码时 bcrypt 的计算代价
+	BcryptCost int
+	// PolicyReloadInterval 为授权策略从数据库重新加载的时间间隔
+	PolicyReloadInterval time.Duration
 }
 
 // UnionServer 定义一个联合服务器，根据ServerMode 决定要启动的服务器类型。
 type UnionServer struct {
 	srv server.Server
+	// workers 为与 srv 一同运行的后台任务，例如发件箱 relay 和回收站清理
+	workers []server.Server
+	// index 为博客的全文索引，在所有服务停止后关闭
+	index *search.Index
 }
 
-// ServerConfig
+// ServerConfig 包含服务器的核心依赖和配置
 type ServerConfig struct {
-	cfg *Config
+	cfg     *Config
+	biz     biz.IBiz
+	authz   *authz.Authz
+	workers []server.Server
+	index   *search.Index
 }
 
 // NewUnionServer 根据配置创建联合服务器
 func (c *Config) NewUnionServer() (*UnionServer, error) {
+	// 注册租户解析函数，where.T(ctx) 会使用当前请求的用户 ID 作为过滤条件，
+	// 从而将博客等资源的访问范围限定在当前用户下
+	where.RegisterTenant("userID", func(ctx context.Context) string {
+		return contextx.UserID(ctx)
+	})
+
+	// 初始化 token 包的签名密钥、认证 Key 及 Token 默认过期时间
+	token.Init(c.JWTKey, known.XUserID, c.Expiration)
+	// 分页令牌使用由 JWT 密钥派生的密钥签名
+	pagetoken.Init(c.JWTKey)
+	// 设置用户密码加密强度
+	authn.SetCost(c.BcryptCost)
+
 	serverConfig, err := c.NewServerConfig()
 	if err != nil {
 		return nil, err
 	}
-	logger.L().Info().Msgf("Initializing federation server", "server-mode", c.ServerMode)
+	logger.L().Info().Str("server-mode", c.ServerMode).Msg("Initializing federation server")
 	// 根据服务模式创建对应的服务实例
 	// 实际企业开发中，可以根据需要只选择一种服务器模式.
 	// 这里为了方便给你展示，通过 cfg.ServerMode 同时支持了 Gin 和 GRPC 2 种服务器模式.
@@ -65,12 +156,15 @@ func (c *Config) NewUnionServer() (*UnionServer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &UnionServer{srv: srv}, nil
+	return &UnionServer{srv: srv, workers: serverConfig.workers, index: serverConfig.index}, nil
 }
 
 // Run运行应用
 func (s *UnionServer) Run() error {
 	go s.srv.RunOrDie()
+	for _, worker := range s.workers {
+		go worker.RunOrDie()
+	}
 	//创建一个os.Signal类型的channel，用于接收系统信号
 	quit := make(chan os.Signal, 1)
 	// 当执行 kill 命令时（不带参数），默认会发送 syscall.SIGTERM 信号
@@ -85,11 +179,153 @@ func (s *UnionServer) Run() error {
 	defer cancel()
 	//先关闭依赖的服务，再光比被依赖的服务
 	s.srv.GracefulStop(ctx)
+	for _, worker := range s.workers {
+		worker.GracefulStop(ctx)
+	}
+	if err := s.index.Close(); err != nil {
+		logger.L().Warn().Err(err).Msg("Failed to close search index")
+	}
 	return nil
 }
 
+// NewServerConfig 创建数据库连接等服务器依赖，并返回 ServerConfig
 func (c *Config) NewServerConfig() (*ServerConfig, error) {
+	db, s, err := c.newStore()
+	if err != nil {
+		return nil, err
+	}
+	authz, err := authz.NewAuthz(db, c.PolicyReloadInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := c.newSearchIndex(s)
+	if err != nil {
+		return nil, err
+	}
+	s = store.NewIndexedStore(s, index)
+
+	var rdb *redis.Client
+	if c.EnableCache || c.ViewCountBuffer == RedisViewCountBuffer {
+		logger.L().Info().Str("addr", c.RedisOptions.Addr).Msg("Initializing redis client")
+		if rdb, err = c.RedisOptions.NewClient(); err != nil {
+			return nil, err
+		}
+	}
+	if c.EnableCache {
+		logger.L().Info().Dur("ttl", c.CacheTTL).Msg("Initializing redis cache")
+		s = store.NewCachedStore(s, rdb, c.CacheTTL)
+	}
+
+	// 浏览次数先写入缓冲，由 Flusher 定期批量写入数据库，避免热门博客的每次浏览都更新 post 表
+	views := viewcount.NewMemoryBuffer()
+	if c.ViewCountBuffer == RedisViewCountBuffer {
+		views = viewcount.NewRedisBuffer(rdb)
+	}
+
+	var workers []server.Server
+	if c.EnableOutboxRelay {
+		relay, err := c.newOutboxRelay(s)
+		if err != nil {
+			return nil, err
+		}
+		workers = append(workers, relay)
+	}
+
+	if c.TrashRetention > 0 {
+		workers = append(workers, trash.NewPurger(s, c.TrashRetention, c.TrashPurgeInterval))
+	}
+	if c.PublishSchedulerInterval > 0 {
+		workers = append(workers, scheduler.NewScheduler(s, c.PublishSchedulerInterval))
+	}
+	workers = append(workers, viewcount.NewFlusher(views, s, c.ViewCountFlushInterval))
+
+	ibiz := biz.NewBiz(s, authz, index, views)
+	if c.EnableWorker {
+		worker, err := c.newKafkaWorker(ibiz)
+		if err != nil {
+			return nil, err
+		}
+		workers = append(workers, worker)
+	}
 	return &ServerConfig{
-		cfg: c,
+		cfg:     c,
+		biz:     ibiz,
+		authz:   authz,
+		workers: workers,
+		index:   index,
 	}, nil
 }
+
+// newStore 根据 Store 配置创建存储层，并返回授权策略使用的数据库连接.
+func (c *Config) newStore() (*gorm.DB, store.IStore, error) {
+	if c.Store == MemoryStore {
+		logger.L().Info().Msg("Initializing memory store, data will be lost after exit")
+		// 内存模式不依赖外部数据库，授权策略保存在内存 SQLite 中
+		opts := options.NewSQLiteOptions()
+		opts.Path = ":memory:"
+		db, err := opts.NewDB()
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, store.NewMemoryStore(), nil
+	}
+
+	logger.L().Info().Str("type", c.DBOptions.Driver).Str("addr", c.DBOptions.Addr()).Msg("Initializing database connection")
+	db, err := c.DBOptions.NewDB()
+	if err != nil {
+		return nil, nil, err
+	}
+	// SQLite 主要用于本地开发和 CI，总是自动执行迁移，无需手动初始化数据库
+	if c.AutoMigrate || c.DBOptions.Driver == options.DriverSQLite {
+		m, err := migration.New(db, c.DBOptions.Driver)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := m.Up(context.Background()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return db, store.NewStore(db), nil
+}
+
+// newSearchIndex 打开博客的全文索引，新创建的索引从 s 中导入所有已发布的博客.
+func (c *Config) newSearchIndex(s store.IStore) (*search.Index, error) {
+	logger.L().Info().Str("path", c.SearchIndexPath).Msg("Initializing search index")
+	index, created, err := search.Open(c.SearchIndexPath)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		if _, err := index.Rebuild(context.Background(), s); err != nil {
+			_ = index.Close()
+			return nil, err
+		}
+	}
+	return index, nil
+}
+
+// newOutboxRelay 创建将发件箱事件发布到 Kafka 的 relay.
+func (c *Config) newOutboxRelay(s store.IStore) (*outbox.Relay, error) {
+	logger.L().Info().Strs("brokers", c.KafkaOptions.Brokers).Str("topic", c.KafkaOptions.Topic).Msg("Initializing outbox relay")
+	writer, err := c.KafkaOptions.Writer()
+	if err != nil {
+		return nil, err
+	}
+	// 只有同步写入才能在消息被 Kafka 确认后再标记事件为已发布
+	writer.Async = false
+	return outbox.NewRelay(s, writer, c.OutboxRelayInterval, c.KafkaOptions.WriterOptions.BatchSize), nil
+}
+
+// newKafkaWorker 创建 Kafka 消费者并注册各主题的处理函数.
+func (c *Config) newKafkaWorker(biz biz.IBiz) (*server.KafkaWorker, error) {
+	logger.L().Info().Strs("brokers", c.KafkaOptions.Brokers).Str("group-id", c.KafkaOptions.ReaderOptions.GroupID).Msg("Initializing kafka worker")
+	worker, err := server.NewKafkaWorker(c.KafkaOptions, c.WorkerMaxRetries, c.WorkerRetryBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := kafkahandler.NewHandler(biz)
+	worker.Register(c.KafkaOptions.Topic, handler.HandleEvent)
+	return worker, nil
+}
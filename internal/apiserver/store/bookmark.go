@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm/clause"
+)
+
+// BookmarkStore 定义了博客收藏在 store 层所实现的方法.
+// 博客被永久删除时，其收藏随之删除.
+type BookmarkStore interface {
+	// CreateIfNotExist 在用户尚未收藏时插入 obj，已收藏时保持不变.
+	CreateIfNotExist(ctx context.Context, obj *model.BookmarkM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	List(ctx context.Context, opts *where.Options) (int64, []*model.BookmarkM, error)
+}
+
+// bookmarkStore 是 BookmarkStore 接口的实现.
+type bookmarkStore struct {
+	store *datastore
+}
+
+// 确保 bookmarkStore 实现了 BookmarkStore 接口.
+var _ BookmarkStore = (*bookmarkStore)(nil)
+
+// newBookmarkStore 创建 bookmarkStore 的实例.
+func newBookmarkStore(store *datastore) *bookmarkStore {
+	return &bookmarkStore{store: store}
+}
+
+// CreateIfNotExist 插入一条收藏记录，同一用户重复收藏时不会返回错误.
+func (s *bookmarkStore) CreateIfNotExist(ctx context.Context, obj *model.BookmarkM) error {
+	if err := s.store.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(obj).Error; err != nil {
+		log.Errorw("Failed to insert bookmark into database", "err", err, "bookmark", obj)
+		return err
+	}
+	return nil
+}
+
+// Delete 根据条件删除收藏记录.
+func (s *bookmarkStore) Delete(ctx context.Context, opts *where.Options) error {
+	if err := s.store.DB(ctx, opts).Delete(&model.BookmarkM{}).Error; err != nil {
+		log.Errorw("Failed to delete bookmarks from database", "err", err, "conditions", opts)
+		return err
+	}
+	return nil
+}
+
+// List 返回收藏列表和总数，按收藏时间倒序排列.
+func (s *bookmarkStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.BookmarkM, err error) {
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list bookmarks from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
@@ -0,0 +1,341 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/redis/go-redis/v9"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+const (
+	// userCacheKeyPrefix 为用户缓存键前缀，完整的键为 miniblog:user:<userID>.
+	userCacheKeyPrefix = "miniblog:user:"
+	// postCacheKeyPrefix 为博客缓存键前缀，完整的键为 miniblog:post:<postID>.
+	postCacheKeyPrefix = "miniblog:post:"
+)
+
+// pendingKey 用于在 context.Context 中存储事务内待失效的缓存键.
+type pendingKey struct{}
+
+// cachedStore 是带 Redis 读缓存的 IStore 装饰器.
+// 只缓存按 userID / postID 精确查询的 Get 结果，Update / Delete 时删除对应缓存.
+type cachedStore struct {
+	IStore
+	rdb   redis.Cmdable
+	ttl   time.Duration
+	group singleflight.Group
+}
+
+// 确保 cachedStore 实现了 IStore 接口.
+var _ IStore = (*cachedStore)(nil)
+
+// NewCachedStore 使用 Redis 为 IStore 增加读缓存.
+// rdb 使用 redis.Cmdable 接口，便于替换为其他兼容 Redis 协议的实现.
+func NewCachedStore(s IStore, rdb redis.Cmdable, ttl time.Duration) IStore {
+	return &cachedStore{IStore: s, rdb: rdb, ttl: ttl}
+}
+
+// TX 在事务中执行 fn，事务提交成功后再删除事务中涉及的缓存，
+// 避免并发读在事务提交前把旧数据重新写回缓存.
+func (c *cachedStore) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTX(ctx) {
+		return c.IStore.TX(ctx, fn)
+	}
+
+	var keys []string
+	err := c.IStore.TX(context.WithValue(ctx, pendingKey{}, &keys), fn)
+	if err == nil {
+		c.invalidate(ctx, keys...)
+	}
+	return err
+}
+
+// User 返回带缓存的 UserStore.
+func (c *cachedStore) User() UserStore {
+	return &cachedUserStore{UserStore: c.IStore.User(), cache: c}
+}
+
+// Post 返回带缓存的 PostStore.
+func (c *cachedStore) Post() PostStore {
+	return &cachedPostStore{PostStore: c.IStore.Post(), cache: c}
+}
+
+// load 从缓存读取 key 对应的记录，未命中时通过 fn 查询数据库并回填缓存.
+// 同一个 key 的并发未命中只会查询一次数据库.
+// 事务内的查询直接访问数据库，保证能读到本事务中的修改.
+func (c *cachedStore) load(ctx context.Context, key string, obj any, fn func() (any, error)) error {
+	if inTX(ctx) {
+		return c.query(fn, obj)
+	}
+
+	data, err := c.rdb.Get(ctx, key).Bytes()
+	if err == nil {
+		return json.Unmarshal(data, obj)
+	}
+	if !errors.Is(err, redis.Nil) {
+		log.W(ctx).Warnw("Failed to read from cache", "err", err, "key", key)
+	}
+
+	v, err, _ := c.group.Do(key, func() (any, error) {
+		ret, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(ret)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.rdb.Set(context.WithoutCancel(ctx), key, data, c.ttl).Err(); err != nil {
+			log.W(ctx).Warnw("Failed to write to cache", "err", err, "key", key)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+	// 每个调用方各自反序列化，避免多个请求共享同一个对象
+	return json.Unmarshal(v.([]byte), obj)
+}
+
+// query 直接通过 fn 查询数据库，并将结果复制到 obj 中.
+func (c *cachedStore) query(fn func() (any, error), obj any) error {
+	ret, err := fn()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(ret)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+// inTX 判断 ctx 是否处于 TX 开启的事务中.
+func inTX(ctx context.Context) bool {
+	_, ok := ctx.Value(pendingKey{}).(*[]string)
+	return ok
+}
+
+// invalidate 删除缓存. 在事务中时只记录待删除的键，由 TX 在提交后统一删除.
+func (c *cachedStore) invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if pending, ok := ctx.Value(pendingKey{}).(*[]string); ok {
+		*pending = append(*pending, keys...)
+		return
+	}
+	if err := c.rdb.Del(context.WithoutCancel(ctx), keys...).Err(); err != nil {
+		log.W(ctx).Errorw("Failed to invalidate cache", "err", err, "keys", keys)
+	}
+}
+
+// cachedUserStore 是带缓存的 UserStore 实现.
+type cachedUserStore struct {
+	UserStore
+	cache *cachedStore
+}
+
+// 确保 cachedUserStore 实现了 UserStore 接口.
+var _ UserStore = (*cachedUserStore)(nil)
+
+// Update 更新用户并删除该用户的缓存.
+func (s *cachedUserStore) Update(ctx context.Context, obj *model.UserM) error {
+	if err := s.UserStore.Update(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, userCacheKeyPrefix+obj.UserID)
+	return nil
+}
+
+// UpdateColumns 更新用户的部分列并删除该用户的缓存.
+func (s *cachedUserStore) UpdateColumns(ctx context.Context, obj *model.UserM, columns ...string) error {
+	if err := s.UserStore.UpdateColumns(ctx, obj, columns...); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, userCacheKeyPrefix+obj.UserID)
+	return nil
+}
+
+// Delete 删除用户并删除被删除用户的缓存.
+func (s *cachedUserStore) Delete(ctx context.Context, opts *where.Options) error {
+	ids, ok := filterValues(opts, "userID")
+	if !ok {
+		_, users, err := s.UserStore.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			ids = append(ids, user.UserID)
+		}
+	}
+
+	if err := s.UserStore.Delete(ctx, opts); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, cacheKeys(userCacheKeyPrefix, ids)...)
+	return nil
+}
+
+// Get 查询用户. 只有按 userID 精确查询时才使用缓存.
+// 缓存中不保存密码哈希，从缓存返回的用户 Password 为空；需要校验密码时在事务中查询，事务内直接读取数据库.
+func (s *cachedUserStore) Get(ctx context.Context, opts *where.Options) (*model.UserM, error) {
+	userID, ok := lookupKey(opts, "userID")
+	if !ok || inTX(ctx) {
+		return s.UserStore.Get(ctx, opts)
+	}
+
+	var obj model.UserM
+	err := s.cache.load(ctx, userCacheKeyPrefix+userID, &obj, func() (any, error) {
+		userM, err := s.UserStore.Get(ctx, where.F("userID", userID))
+		if err != nil {
+			return nil, err
+		}
+		userM.Password = ""
+		return userM, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// cachedPostStore 是带缓存的 PostStore 实现.
+type cachedPostStore struct {
+	PostStore
+	cache *cachedStore
+}
+
+// 确保 cachedPostStore 实现了 PostStore 接口.
+var _ PostStore = (*cachedPostStore)(nil)
+
+// Update 更新博客并删除该博客的缓存.
+func (s *cachedPostStore) Update(ctx context.Context, obj *model.PostM) error {
+	if err := s.PostStore.Update(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, postCacheKeyPrefix+obj.PostID)
+	return nil
+}
+
+// UpdateColumns 更新帖子的部分列并删除该帖子的缓存.
+func (s *cachedPostStore) UpdateColumns(ctx context.Context, obj *model.PostM, columns ...string) error {
+	if err := s.PostStore.UpdateColumns(ctx, obj, columns...); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, postCacheKeyPrefix+obj.PostID)
+	return nil
+}
+
+// Increment 更新博客的计数并删除该博客的缓存.
+// viewCount 由 viewcount.Flusher 定期批量写入，读取博客时会加上缓冲中尚未写入的浏览次数，
+// 因此写入 viewCount 时不删除缓存，避免被浏览的博客在每次写入后都缓存失效、回源数据库.
+// 代价是缓存中的 viewCount 最多滞后 ttl，写入后到缓存过期前返回的浏览数会暂时少于写入前.
+func (s *cachedPostStore) Increment(ctx context.Context, postID string, column string, delta int64) error {
+	if err := s.PostStore.Increment(ctx, postID, column, delta); err != nil {
+		return err
+	}
+	if column != "viewCount" {
+		s.cache.invalidate(ctx, postCacheKeyPrefix+postID)
+	}
+	return nil
+}
+
+// Delete 删除博客并删除被删除博客的缓存.
+// 条件中没有 postID 时（例如按 userID 删除），先查询出受影响的博客.
+func (s *cachedPostStore) Delete(ctx context.Context, opts *where.Options) error {
+	ids, ok := filterValues(opts, "postID")
+	if !ok {
+		_, posts, err := s.PostStore.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, post := range posts {
+			ids = append(ids, post.PostID)
+		}
+	}
+
+	if err := s.PostStore.Delete(ctx, opts); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, cacheKeys(postCacheKeyPrefix, ids)...)
+	return nil
+}
+
+// Get 查询博客. 只有按 postID（可带租户条件 userID 和发布状态 status）精确查询时才使用缓存，
+// 命中后再校验 userID 和 status，保证缓存不会绕过租户隔离，匿名用户读取已发布博客时也能使用缓存.
+func (s *cachedPostStore) Get(ctx context.Context, opts *where.Options) (*model.PostM, error) {
+	postID, ok := lookupKey(opts, "postID", "userID", "status")
+	if !ok {
+		return s.PostStore.Get(ctx, opts)
+	}
+
+	var obj model.PostM
+	err := s.cache.load(ctx, postCacheKeyPrefix+postID, &obj, func() (any, error) {
+		return s.PostStore.Get(ctx, where.F("postID", postID))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userID, ok := opts.Filters["userID"]; ok && userID != obj.UserID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if status, ok := opts.Filters["status"]; ok && status != obj.Status {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &obj, nil
+}
+
+// lookupKey 判断 opts 是否为按 key 的单值等值查询，允许额外出现 allowed 中的过滤字段.
+// 带有自定义查询子句或其他过滤字段的条件不走缓存.
+func lookupKey(opts *where.Options, key string, allowed ...string) (string, bool) {
+	if opts == nil || len(opts.Clauses) != 0 || len(opts.Queries) != 0 {
+		return "", false
+	}
+	for k, v := range opts.Filters {
+		if k == key {
+			continue
+		}
+		name, ok := k.(string)
+		if !ok || !slices.Contains(allowed, name) {
+			return "", false
+		}
+		if _, ok := v.(string); !ok {
+			return "", false
+		}
+	}
+	id, ok := opts.Filters[key].(string)
+	return id, ok && id != ""
+}
+
+// filterValues 返回 opts 中 key 过滤字段的取值，支持单个值和切片.
+func filterValues(opts *where.Options, key string) ([]string, bool) {
+	if opts == nil {
+		return nil, false
+	}
+	switch v := opts.Filters[key].(type) {
+	case string:
+		return []string{v}, true
+	case []string:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// cacheKeys 为 ids 加上缓存键前缀.
+func cacheKeys(prefix string, ids []string) []string {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, prefix+id)
+	}
+	return keys
+}
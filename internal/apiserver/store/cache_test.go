@@ -0,0 +1,372 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/redis/go-redis/v9"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"gorm.io/gorm"
+)
+
+// countingStore 统计对底层 PostStore.Get 的调用次数，gate 不为空时 Get 会等待 gate 关闭.
+type countingStore struct {
+	IStore
+	gets atomic.Int64
+	gate chan struct{}
+}
+
+func (s *countingStore) Post() PostStore {
+	return &countingPostStore{PostStore: s.IStore.Post(), store: s}
+}
+
+type countingPostStore struct {
+	PostStore
+	store *countingStore
+}
+
+func (s *countingPostStore) Get(ctx context.Context, opts *where.Options) (*model.PostM, error) {
+	s.store.gets.Add(1)
+	if s.store.gate != nil {
+		<-s.store.gate
+	}
+	return s.PostStore.Get(ctx, opts)
+}
+
+// newCachedTestStore 创建以 SQLite 为底层存储、miniredis 为缓存的 cachedStore.
+func newCachedTestStore(t *testing.T) (*cachedStore, *countingStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	db := &countingStore{IStore: newSQLiteStore(t)}
+	return NewCachedStore(db, rdb, time.Minute).(*cachedStore), db, mr
+}
+
+// createTestPost 在底层存储中创建一篇已发布的博客.
+func createTestPost(t *testing.T, s IStore) *model.PostM {
+	t.Helper()
+
+	post := &model.PostM{UserID: "user-a", Title: "title", Content: "content"}
+	if err := s.Post().Create(context.Background(), post); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	return post
+}
+
+func TestCachedPostStoreGetReadThrough(t *testing.T) {
+	ctx := context.Background()
+	s, db, mr := newCachedTestStore(t)
+	post := createTestPost(t, db.IStore)
+	key := postCacheKeyPrefix + post.PostID
+
+	got, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "title" || !mr.Exists(key) {
+		t.Fatalf("first Get should load the post into cache, got title %q, cached %v", got.Title, mr.Exists(key))
+	}
+
+	// 绕过缓存直接修改数据库，缓存命中时仍返回旧数据
+	if err := db.IStore.DB(ctx).Model(&model.PostM{}).Where("postID = ?", post.PostID).UpdateColumn("title", "changed").Error; err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.Post().Get(ctx, where.F("postID", post.PostID))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "title" {
+		t.Errorf("second Get should be served from cache, got title %q", got.Title)
+	}
+	if n := db.gets.Load(); n != 1 {
+		t.Errorf("underlying Get called %d times, want 1", n)
+	}
+}
+
+func TestCachedPostStoreGetFilters(t *testing.T) {
+	ctx := context.Background()
+	s, db, mr := newCachedTestStore(t)
+	post := createTestPost(t, db.IStore)
+
+	tests := []struct {
+		name    string
+		opts    *where.Options
+		wantErr error
+		cached  bool
+	}{
+		{"tenant", where.F("postID", post.PostID, "userID", "user-a"), nil, true},
+		{"other tenant", where.F("postID", post.PostID, "userID", "user-b"), gorm.ErrRecordNotFound, true},
+		{"published", where.F("postID", post.PostID, "status", model.PostStatusPublished), nil, true},
+		{"draft", where.F("postID", post.PostID, "status", model.PostStatusDraft), gorm.ErrRecordNotFound, true},
+		{"other filter", where.F("postID", post.PostID, "title", "title"), nil, false},
+		{"custom query", where.F("postID", post.PostID).Q("title = ?", "title"), nil, false},
+		{"multiple ids", where.F("postID", []string{post.PostID}), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr.FlushAll()
+			_, err := s.Post().Get(ctx, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, want %v", err, tt.wantErr)
+			}
+			if cached := mr.Exists(postCacheKeyPrefix + post.PostID); cached != tt.cached {
+				t.Errorf("cached = %v, want %v", cached, tt.cached)
+			}
+		})
+	}
+}
+
+func TestCachedPostStoreInvalidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(ctx context.Context, s IStore, post *model.PostM) error
+	}{
+		{"Update", func(ctx context.Context, s IStore, post *model.PostM) error {
+			post.Title = "changed"
+			return s.Post().Update(ctx, post)
+		}},
+		{"UpdateColumns", func(ctx context.Context, s IStore, post *model.PostM) error {
+			post.Title = "changed"
+			return s.Post().UpdateColumns(ctx, post, "title")
+		}},
+		{"Increment", func(ctx context.Context, s IStore, post *model.PostM) error {
+			return s.Post().Increment(ctx, post.PostID, "likeCount", 1)
+		}},
+		{"Delete", func(ctx context.Context, s IStore, post *model.PostM) error {
+			return s.Post().Delete(ctx, where.F("postID", post.PostID))
+		}},
+		{"Delete by userID", func(ctx context.Context, s IStore, post *model.PostM) error {
+			return s.Post().Delete(ctx, where.F("userID", post.UserID))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, db, mr := newCachedTestStore(t)
+			post := createTestPost(t, db.IStore)
+			key := postCacheKeyPrefix + post.PostID
+
+			cached, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if err := tt.write(ctx, s, cached); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if mr.Exists(key) {
+				t.Fatalf("cache key %s should be removed after %s", key, tt.name)
+			}
+
+			want, wantErr := db.IStore.Post().Get(ctx, where.F("postID", post.PostID))
+			got, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+			if !errors.Is(err, wantErr) {
+				t.Fatalf("Get() error = %v, want %v", err, wantErr)
+			}
+			if err == nil && (got.Title != want.Title || got.LikeCount != want.LikeCount || got.Version != want.Version) {
+				t.Errorf("Get() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestCachedPostStoreKeepsCacheOnViewCount 检查写入浏览数时不删除缓存，缓存中的 viewCount 在过期前保持不变.
+func TestCachedPostStoreKeepsCacheOnViewCount(t *testing.T) {
+	ctx := context.Background()
+	s, db, mr := newCachedTestStore(t)
+	post := createTestPost(t, db.IStore)
+	key := postCacheKeyPrefix + post.PostID
+
+	if _, err := s.Post().Get(ctx, where.F("postID", post.PostID)); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := s.TX(ctx, func(ctx context.Context) error {
+		return s.Post().Increment(ctx, post.PostID, "viewCount", 10)
+	}); err != nil {
+		t.Fatalf("Increment: %v", err)
+	}
+	if !mr.Exists(key) {
+		t.Fatal("cache key should be kept after incrementing viewCount")
+	}
+
+	stored, err := db.IStore.Post().Get(ctx, where.F("postID", post.PostID))
+	if err != nil || stored.ViewCount != 10 {
+		t.Fatalf("stored viewCount = %v, %v, want 10", stored, err)
+	}
+	gets := db.gets.Load()
+	got, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+	if err != nil || got.ViewCount != 0 || db.gets.Load() != gets {
+		t.Errorf("Get() = %v, %v, want the cached post with viewCount 0", got, err)
+	}
+
+	// 缓存过期后读到写入的浏览数
+	mr.FastForward(time.Minute)
+	if got, err := s.Post().Get(ctx, where.F("postID", post.PostID)); err != nil || got.ViewCount != 10 {
+		t.Errorf("Get() after the cache expires = %v, %v, want viewCount 10", got, err)
+	}
+}
+
+func TestCachedUserStoreInvalidation(t *testing.T) {
+	ctx := context.Background()
+	s, db, mr := newCachedTestStore(t)
+	user := &model.UserM{Username: "alice", Password: "miniblog1234", Nickname: "alice", Email: "alice@qq.com", Phone: "18110000001"}
+	if err := db.IStore.User().Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	key := userCacheKeyPrefix + user.UserID
+
+	cached, err := s.User().Get(ctx, where.F("userID", user.UserID))
+	if err != nil || !mr.Exists(key) {
+		t.Fatalf("Get should load the user into cache, err = %v", err)
+	}
+	cached.Nickname = "changed"
+	if err := s.User().UpdateColumns(ctx, cached, "nickname"); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists(key) {
+		t.Fatal("cache key should be removed after UpdateColumns")
+	}
+	got, err := s.User().Get(ctx, where.F("userID", user.UserID))
+	if err != nil || got.Nickname != "changed" {
+		t.Errorf("Get() = %v, %v, want nickname changed", got, err)
+	}
+}
+
+// TestCachedUserStoreOmitsPassword 检查缓存中不保存密码哈希，事务内的查询仍能读到密码.
+func TestCachedUserStoreOmitsPassword(t *testing.T) {
+	ctx := context.Background()
+	s, db, mr := newCachedTestStore(t)
+	user := &model.UserM{Username: "alice", Password: "miniblog1234", Nickname: "alice", Email: "alice@qq.com", Phone: "18110000001"}
+	if err := db.IStore.User().Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.User().Get(ctx, where.F("userID", user.UserID))
+	if err != nil || got.Password != "" || got.Username != "alice" {
+		t.Fatalf("Get() = %+v, %v, want alice without password", got, err)
+	}
+	cached, err := mr.Get(userCacheKeyPrefix + user.UserID)
+	if err != nil || strings.Contains(cached, user.Password) {
+		t.Errorf("cached user = %s, %v, want no password hash", cached, err)
+	}
+	if got, err := s.User().Get(ctx, where.F("userID", user.UserID)); err != nil || got.Password != "" {
+		t.Errorf("cached Get() password = %q, %v, want empty", got.Password, err)
+	}
+
+	err = s.TX(ctx, func(ctx context.Context) error {
+		got, err := s.User().Get(ctx, where.F("userID", user.UserID))
+		if err == nil && got.Password != user.Password {
+			err = errors.New("password in transaction = " + got.Password)
+		}
+		return err
+	})
+	if err != nil {
+		t.Errorf("Get() in TX: %v", err)
+	}
+}
+
+func TestCachedStoreTXDefersInvalidation(t *testing.T) {
+	ctx := context.Background()
+	s, db, mr := newCachedTestStore(t)
+	post := createTestPost(t, db.IStore)
+	key := postCacheKeyPrefix + post.PostID
+
+	load := func() *model.PostM {
+		t.Helper()
+		got, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		return got
+	}
+	load()
+
+	err := s.TX(ctx, func(ctx context.Context) error {
+		if err := s.Post().Increment(ctx, post.PostID, "likeCount", 1); err != nil {
+			return err
+		}
+		if !mr.Exists(key) {
+			t.Error("cache key should be kept until the transaction commits")
+		}
+		// 事务内的查询不经过缓存，能读到本事务中的修改
+		got, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+		if err != nil {
+			return err
+		}
+		if got.LikeCount != 1 {
+			t.Errorf("Get in transaction likeCount = %d, want 1", got.LikeCount)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TX: %v", err)
+	}
+	if mr.Exists(key) {
+		t.Error("cache key should be removed after the transaction commits")
+	}
+	if got := load(); got.LikeCount != 1 {
+		t.Errorf("likeCount = %d, want 1", got.LikeCount)
+	}
+
+	rollback := errors.New("rollback")
+	err = s.TX(ctx, func(ctx context.Context) error {
+		if err := s.Post().Increment(ctx, post.PostID, "likeCount", 1); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("TX() error = %v, want %v", err, rollback)
+	}
+	if !mr.Exists(key) {
+		t.Error("cache key should be kept when the transaction is rolled back")
+	}
+	if got := load(); got.LikeCount != 1 {
+		t.Errorf("likeCount after rollback = %d, want 1", got.LikeCount)
+	}
+}
+
+func TestCachedPostStoreSingleflight(t *testing.T) {
+	ctx := context.Background()
+	s, db, _ := newCachedTestStore(t)
+	post := createTestPost(t, db.IStore)
+	db.gate = make(chan struct{})
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+			if err == nil && got.PostID != post.PostID {
+				err = errors.New("unexpected post " + got.PostID)
+			}
+			errs <- err
+		}()
+	}
+	// 等待所有请求都在等待第一次数据库查询的结果
+	time.Sleep(100 * time.Millisecond)
+	close(db.gate)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Get: %v", err)
+		}
+	}
+	if got := db.gets.Load(); got != 1 {
+		t.Errorf("underlying Get called %d times for concurrent misses, want 1", got)
+	}
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CategoryStore 定义了分类在 store 层所实现的方法.
+type CategoryStore interface {
+	Get(ctx context.Context, opts *where.Options) (*model.CategoryM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.CategoryM, error)
+	CategoryExpansion
+}
+
+// CategoryExpansion 定义了分类操作的附加方法.
+type CategoryExpansion interface {
+	// CreateIfNotExist 在同名分类不存在时插入 obj，已存在时保持不变.
+	CreateIfNotExist(ctx context.Context, obj *model.CategoryM) error
+}
+
+// categoryStore 是 CategoryStore 接口的实现.
+type categoryStore struct {
+	store *datastore
+}
+
+// 确保 categoryStore 实现了 CategoryStore 接口.
+var _ CategoryStore = (*categoryStore)(nil)
+
+// newCategoryStore 创建 categoryStore 的实例.
+func newCategoryStore(store *datastore) *categoryStore {
+	return &categoryStore{store: store}
+}
+
+// CreateIfNotExist 在同名分类不存在时插入 obj，并发创建同名分类时不会返回错误.
+func (s *categoryStore) CreateIfNotExist(ctx context.Context, obj *model.CategoryM) error {
+	if err := s.store.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(obj).Error; err != nil {
+		log.Errorw("Failed to insert category into database", "err", err, "category", obj)
+		return err
+	}
+	return nil
+}
+
+// Get 根据条件查询分类记录.
+func (s *categoryStore) Get(ctx context.Context, opts *where.Options) (*model.CategoryM, error) {
+	var obj model.CategoryM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorw("Failed to retrieve category from database", "err", err, "conditions", opts)
+		}
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// List 返回分类列表和总数.
+func (s *categoryStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.CategoryM, err error) {
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list categories from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm"
+)
+
+// CommentStore 定义了评论在 store 层所实现的方法.
+// 博客被永久删除时，其评论随之删除.
+type CommentStore interface {
+	Create(ctx context.Context, obj *model.CommentM) error
+	Update(ctx context.Context, obj *model.CommentM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.CommentM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.CommentM, error)
+}
+
+// commentStore 是 CommentStore 接口的实现.
+type commentStore struct {
+	store *datastore
+}
+
+// 确保 commentStore 实现了 CommentStore 接口.
+var _ CommentStore = (*commentStore)(nil)
+
+// newCommentStore 创建 commentStore 的实例.
+func newCommentStore(store *datastore) *commentStore {
+	return &commentStore{store: store}
+}
+
+// Create 插入一条评论记录.
+func (s *commentStore) Create(ctx context.Context, obj *model.CommentM) error {
+	if err := s.store.DB(ctx).Create(obj).Error; err != nil {
+		log.Errorw("Failed to insert comment into database", "err", err, "comment", obj)
+		return err
+	}
+	return nil
+}
+
+// Update 更新评论数据库记录.
+func (s *commentStore) Update(ctx context.Context, obj *model.CommentM) error {
+	if err := s.store.DB(ctx).Save(obj).Error; err != nil {
+		log.Errorw("Failed to update comment in database", "err", err, "comment", obj)
+		return err
+	}
+	return nil
+}
+
+// Delete 根据条件删除评论记录.
+func (s *commentStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(&model.CommentM{}).Error
+	if err != nil {
+		log.Errorw("Failed to delete comment from database", "err", err, "conditions", opts)
+		return err
+	}
+	return nil
+}
+
+// Get 根据条件查询评论记录.
+func (s *commentStore) Get(ctx context.Context, opts *where.Options) (*model.CommentM, error) {
+	var obj model.CommentM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorw("Failed to retrieve comment from database", "err", err, "conditions", opts)
+		}
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// List 返回评论列表和总数，默认按创建时间倒序排列，opts 中的排序子句优先.
+func (s *commentStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.CommentM, err error) {
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list comments from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm"
+)
+
+// PostIndexer 定义了博客全文索引需要实现的方法.
+type PostIndexer interface {
+	// Index 将博客写入索引，博客不应被检索到时从索引中移除.
+	Index(post *model.PostM) error
+	// Delete 从索引中移除博客.
+	Delete(postID string) error
+}
+
+// indexPendingKey 用于在 context.Context 中存储事务内待同步到索引的博客 ID.
+type indexPendingKey struct{}
+
+// indexedStore 是同步博客全文索引的 IStore 装饰器.
+// 博客被创建、修改、删除或恢复后，重新读取博客并写入索引，同步失败时只记录日志，可以通过 reindex 命令重建索引.
+type indexedStore struct {
+	IStore
+	index PostIndexer
+}
+
+// 确保 indexedStore 实现了 IStore 接口.
+var _ IStore = (*indexedStore)(nil)
+
+// NewIndexedStore 在 IStore 的博客写操作之后同步全文索引.
+func NewIndexedStore(s IStore, index PostIndexer) IStore {
+	return &indexedStore{IStore: s, index: index}
+}
+
+// TX 在事务中执行 fn，事务提交成功后再同步事务中涉及的博客，回滚的修改不会写入索引.
+func (s *indexedStore) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(indexPendingKey{}).(*[]string); ok {
+		return s.IStore.TX(ctx, fn)
+	}
+
+	var ids []string
+	err := s.IStore.TX(context.WithValue(ctx, indexPendingKey{}, &ids), fn)
+	if err == nil {
+		s.sync(ctx, ids...)
+	}
+	return err
+}
+
+// Post 返回同步全文索引的 PostStore.
+func (s *indexedStore) Post() PostStore {
+	return &indexedPostStore{PostStore: s.IStore.Post(), index: s}
+}
+
+// sync 从数据库读取博客的最新状态并同步到索引，博客不存在时从索引中移除.
+// 在事务中时只记录博客 ID，由 TX 在提交后统一同步.
+func (s *indexedStore) sync(ctx context.Context, ids ...string) {
+	if pending, ok := ctx.Value(indexPendingKey{}).(*[]string); ok {
+		*pending = append(*pending, ids...)
+		return
+	}
+
+	for _, id := range ids {
+		var err error
+		post, gerr := s.IStore.Post().Get(ctx, where.F("postID", id))
+		switch {
+		case errors.Is(gerr, gorm.ErrRecordNotFound):
+			err = s.index.Delete(id)
+		case gerr != nil:
+			err = gerr
+		default:
+			err = s.index.Index(post)
+		}
+		if err != nil {
+			log.W(ctx).Errorw("Failed to sync post to search index", "err", err, "postID", id)
+		}
+	}
+}
+
+// indexedPostStore 是同步全文索引的 PostStore 实现.
+type indexedPostStore struct {
+	PostStore
+	index *indexedStore
+}
+
+// 确保 indexedPostStore 实现了 PostStore 接口.
+var _ PostStore = (*indexedPostStore)(nil)
+
+// Create 创建博客并写入索引.
+func (s *indexedPostStore) Create(ctx context.Context, obj *model.PostM) error {
+	if err := s.PostStore.Create(ctx, obj); err != nil {
+		return err
+	}
+	s.index.sync(ctx, obj.PostID)
+	return nil
+}
+
+// CreateInBatches 批量创建博客并写入索引.
+func (s *indexedPostStore) CreateInBatches(ctx context.Context, objs []*model.PostM, batchSize int) error {
+	if err := s.PostStore.CreateInBatches(ctx, objs, batchSize); err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		ids = append(ids, obj.PostID)
+	}
+	s.index.sync(ctx, ids...)
+	return nil
+}
+
+// Update 更新博客并同步索引.
+func (s *indexedPostStore) Update(ctx context.Context, obj *model.PostM) error {
+	if err := s.PostStore.Update(ctx, obj); err != nil {
+		return err
+	}
+	s.index.sync(ctx, obj.PostID)
+	return nil
+}
+
+// UpdateColumns 更新博客的部分列并同步索引，博客的发布状态变化也会同步到索引.
+func (s *indexedPostStore) UpdateColumns(ctx context.Context, obj *model.PostM, columns ...string) error {
+	if err := s.PostStore.UpdateColumns(ctx, obj, columns...); err != nil {
+		return err
+	}
+	s.index.sync(ctx, obj.PostID)
+	return nil
+}
+
+// Delete 将博客移入回收站并从索引中移除.
+// 条件中没有 postID 时（例如按 userID 删除），先查询出受影响的博客.
+func (s *indexedPostStore) Delete(ctx context.Context, opts *where.Options) error {
+	ids, ok := filterValues(opts, "postID")
+	if !ok {
+		_, posts, err := s.PostStore.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, post := range posts {
+			ids = append(ids, post.PostID)
+		}
+	}
+
+	if err := s.PostStore.Delete(ctx, opts); err != nil {
+		return err
+	}
+	s.index.sync(ctx, ids...)
+	return nil
+}
+
+// Restore 恢复回收站中的博客并重新写入索引.
+// 回收站中的博客已不在索引中，因此 Purge 不需要同步索引.
+func (s *indexedPostStore) Restore(ctx context.Context, opts *where.Options) (int64, error) {
+	ids, ok := filterValues(opts, "postID")
+	if !ok {
+		_, posts, err := s.PostStore.ListDeleted(ctx, opts)
+		if err != nil {
+			return 0, err
+		}
+		for _, post := range posts {
+			ids = append(ids, post.PostID)
+		}
+	}
+
+	n, err := s.PostStore.Restore(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	s.index.sync(ctx, ids...)
+	return n, nil
+}
@@ -1,8 +1,6 @@
 package store
 
 import (
-	"strings"
-
 	"github.com/wshadm/miniblog/internal/pkg/log"
 )
 
@@ -14,4 +12,4 @@ func NewLogger() *Logger {
 
 func (l *Logger) Error(err error, msg string, kvs ...any) {
 	log.Errorw(msg, append(kvs, err)...)
-}
\ No newline at end of file
+}
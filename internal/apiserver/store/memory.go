@@ -0,0 +1,1415 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/filtering"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// memoryTxKey 用于在 context.Context 中标记内存存储的事务.
+type memoryTxKey struct{}
+
+// queryPattern 匹配内存存储支持的查询语句，例如 "title like ?"、"userID = ?".
+var queryPattern = regexp.MustCompile(`(?i)^\s*(\w+)\s*(=|!=|<>|like)\s*\?\s*$`)
+
+// memoryStore 是基于内存的 IStore 实现，用于单元测试和本地演示.
+// 有 deletedAt 列的表与 GORM 一样使用软删除，查询默认只返回未被删除的记录.
+// 查询条件支持 where.Options 中的 Filters（等值及切片 IN 匹配）、Offset/Limit、
+// "<column> =|!=|like ?" 形式的 Queries，以及比较、IN、LIKE 和 AND/OR/NOT 形式的 GORM 子句. 错误语义与 GORM 保持一致，
+// 例如记录不存在时返回 gorm.ErrRecordNotFound，违反唯一索引时返回 gorm.ErrDuplicatedKey.
+type memoryStore struct {
+	mu         sync.RWMutex
+	users      *table[model.UserM]
+	posts      *table[model.PostM]
+	revisions  *table[model.PostRevisionM]
+	comments   *table[model.CommentM]
+	tags       *table[model.TagM]
+	categories *table[model.CategoryM]
+	postTags   *table[model.PostTagM]
+	likes      *table[model.PostLikeM]
+	bookmarks  *table[model.BookmarkM]
+	outboxs    *table[model.OutboxM]
+}
+
+// 确保 memoryStore 实现了 IStore 接口.
+var _ IStore = (*memoryStore)(nil)
+
+// NewMemoryStore 创建一个空的内存存储.
+func NewMemoryStore() IStore {
+	return &memoryStore{
+		users:      newTable[model.UserM](),
+		posts:      newTable[model.PostM](),
+		revisions:  newTable[model.PostRevisionM](),
+		comments:   newTable[model.CommentM](),
+		tags:       newTable[model.TagM](),
+		categories: newTable[model.CategoryM](),
+		postTags:   newTable[model.PostTagM](),
+		likes:      newTable[model.PostLikeM](),
+		bookmarks:  newTable[model.BookmarkM](),
+		outboxs:    newTable[model.OutboxM](),
+	}
+}
+
+// DB 内存存储没有数据库实例，总是返回 nil.
+func (s *memoryStore) DB(ctx context.Context, wheres ...where.Where) *gorm.DB {
+	return nil
+}
+
+// TX 独占执行 fn，fn 返回错误时将所有表恢复到事务开始前的状态.
+func (s *memoryStore) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inMemoryTx(ctx) {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users, posts, outboxs := s.users.snapshot(), s.posts.snapshot(), s.outboxs.snapshot()
+	revisions, comments := s.revisions.snapshot(), s.comments.snapshot()
+	tags, categories, postTags := s.tags.snapshot(), s.categories.snapshot(), s.postTags.snapshot()
+	likes, bookmarks := s.likes.snapshot(), s.bookmarks.snapshot()
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, true)); err != nil {
+		s.users.restore(users)
+		s.posts.restore(posts)
+		s.revisions.restore(revisions)
+		s.comments.restore(comments)
+		s.tags.restore(tags)
+		s.categories.restore(categories)
+		s.postTags.restore(postTags)
+		s.likes.restore(likes)
+		s.bookmarks.restore(bookmarks)
+		s.outboxs.restore(outboxs)
+		return err
+	}
+	return nil
+}
+
+// User 返回一个实现了 UserStore 接口的实例.
+func (s *memoryStore) User() UserStore {
+	return &memoryUserStore{store: s}
+}
+
+// Post 返回一个实现了 PostStore 接口的实例.
+func (s *memoryStore) Post() PostStore {
+	return &memoryPostStore{store: s}
+}
+
+// PostRevision 返回一个实现了 PostRevisionStore 接口的实例.
+func (s *memoryStore) PostRevision() PostRevisionStore {
+	return &memoryPostRevisionStore{store: s}
+}
+
+// Comment 返回一个实现了 CommentStore 接口的实例.
+func (s *memoryStore) Comment() CommentStore {
+	return &memoryCommentStore{store: s}
+}
+
+// Tag 返回一个实现了 TagStore 接口的实例.
+func (s *memoryStore) Tag() TagStore {
+	return &memoryTagStore{store: s}
+}
+
+// Category 返回一个实现了 CategoryStore 接口的实例.
+func (s *memoryStore) Category() CategoryStore {
+	return &memoryCategoryStore{store: s}
+}
+
+// PostTag 返回一个实现了 PostTagStore 接口的实例.
+func (s *memoryStore) PostTag() PostTagStore {
+	return &memoryPostTagStore{store: s}
+}
+
+// PostLike 返回一个实现了 PostLikeStore 接口的实例.
+func (s *memoryStore) PostLike() PostLikeStore {
+	return &memoryPostLikeStore{store: s}
+}
+
+// Bookmark 返回一个实现了 BookmarkStore 接口的实例.
+func (s *memoryStore) Bookmark() BookmarkStore {
+	return &memoryBookmarkStore{store: s}
+}
+
+// Outbox 返回一个实现了 OutboxStore 接口的实例.
+func (s *memoryStore) Outbox() OutboxStore {
+	return &memoryOutboxStore{store: s}
+}
+
+// lock 为读写操作加锁并返回解锁函数. 事务中已持有写锁，无需再次加锁.
+func (s *memoryStore) lock(ctx context.Context, write bool) func() {
+	switch {
+	case inMemoryTx(ctx):
+		return func() {}
+	case write:
+		s.mu.Lock()
+		return s.mu.Unlock
+	default:
+		s.mu.RLock()
+		return s.mu.RUnlock
+	}
+}
+
+// inMemoryTx 判断当前是否处于内存存储的事务中.
+func inMemoryTx(ctx context.Context) bool {
+	ok, _ := ctx.Value(memoryTxKey{}).(bool)
+	return ok
+}
+
+// resolvePostTagged 将 opts 中按标签查询博客的子查询条件替换为等价的 postID IN 条件.
+func (s *memoryStore) resolvePostTagged(ctx context.Context, opts *where.Options) (*where.Options, error) {
+	if opts == nil {
+		return nil, nil
+	}
+
+	resolved := *opts
+	resolved.Clauses = make([]clause.Expression, 0, len(opts.Clauses))
+	for _, expr := range opts.Clauses {
+		e, ok := expr.(postTagged)
+		if !ok {
+			resolved.Clauses = append(resolved.Clauses, expr)
+			continue
+		}
+		_, postTags, err := s.postTags.list(ctx, where.F("tagID", e.tagID), scopeActive)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]any, 0, len(postTags))
+		for _, postTag := range postTags {
+			ids = append(ids, postTag.PostID)
+		}
+		resolved.Clauses = append(resolved.Clauses, clause.IN{Column: clause.Column{Name: "postID"}, Values: ids})
+	}
+	return &resolved, nil
+}
+
+// memoryUserStore 是 UserStore 的内存实现.
+type memoryUserStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryUserStore 实现了 UserStore 接口.
+var _ UserStore = (*memoryUserStore)(nil)
+
+// Create 插入一条用户记录.
+func (s *memoryUserStore) Create(ctx context.Context, obj *model.UserM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.users.create(ctx, obj)
+}
+
+// CreateInBatches 插入多条用户记录，任意一条失败时不会插入任何记录.
+func (s *memoryUserStore) CreateInBatches(ctx context.Context, objs []*model.UserM, batchSize int) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.users.createInBatches(ctx, objs)
+}
+
+// Update 更新用户记录.
+func (s *memoryUserStore) Update(ctx context.Context, obj *model.UserM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.users.update(ctx, obj)
+}
+
+// UpdateColumns 只更新用户记录的 columns 列.
+func (s *memoryUserStore) UpdateColumns(ctx context.Context, obj *model.UserM, columns ...string) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.users.updateColumns(ctx, obj, columns)
+}
+
+// Delete 根据条件软删除用户记录.
+func (s *memoryUserStore) Delete(ctx context.Context, opts *where.Options) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.users.delete(ctx, opts)
+}
+
+// Purge 永久删除满足条件的已删除用户记录.
+func (s *memoryUserStore) Purge(ctx context.Context, opts *where.Options) (int64, error) {
+	defer s.store.lock(ctx, true)()
+	return s.store.users.purge(ctx, opts)
+}
+
+// Get 根据条件查询用户记录.
+func (s *memoryUserStore) Get(ctx context.Context, opts *where.Options) (*model.UserM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.users.get(ctx, opts)
+}
+
+// List 返回用户列表和总数.
+func (s *memoryUserStore) List(ctx context.Context, opts *where.Options) (int64, []*model.UserM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.users.list(ctx, opts, scopeActive)
+}
+
+// Count 返回符合条件的用户总数.
+func (s *memoryUserStore) Count(ctx context.Context, opts *where.Options) (int64, error) {
+	defer s.store.lock(ctx, false)()
+	count, _, err := s.store.users.list(ctx, opts, scopeActive)
+	return count, err
+}
+
+// Exists 判断是否存在符合条件的用户，包括已被软删除的用户.
+func (s *memoryUserStore) Exists(ctx context.Context, opts *where.Options) (bool, error) {
+	defer s.store.lock(ctx, false)()
+	count, _, err := s.store.users.list(ctx, opts, scopeAll)
+	return count > 0, err
+}
+
+// memoryPostStore 是 PostStore 的内存实现.
+type memoryPostStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryPostStore 实现了 PostStore 接口.
+var _ PostStore = (*memoryPostStore)(nil)
+
+// Create 插入一条帖子记录.
+func (s *memoryPostStore) Create(ctx context.Context, obj *model.PostM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.posts.create(ctx, obj)
+}
+
+// CreateInBatches 插入多条帖子记录，任意一条失败时不会插入任何记录.
+func (s *memoryPostStore) CreateInBatches(ctx context.Context, objs []*model.PostM, batchSize int) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.posts.createInBatches(ctx, objs)
+}
+
+// Update 更新帖子记录.
+func (s *memoryPostStore) Update(ctx context.Context, obj *model.PostM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.posts.update(ctx, obj)
+}
+
+// UpdateColumns 只更新帖子记录的 columns 列.
+func (s *memoryPostStore) UpdateColumns(ctx context.Context, obj *model.PostM, columns ...string) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.posts.updateColumns(ctx, obj, columns)
+}
+
+// Delete 根据条件将帖子记录移入回收站.
+func (s *memoryPostStore) Delete(ctx context.Context, opts *where.Options) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.posts.delete(ctx, opts)
+}
+
+// Restore 恢复回收站中满足条件的帖子记录.
+func (s *memoryPostStore) Restore(ctx context.Context, opts *where.Options) (int64, error) {
+	defer s.store.lock(ctx, true)()
+	return s.store.posts.undelete(ctx, opts)
+}
+
+// Purge 永久删除回收站中满足条件的帖子记录及其修订历史、评论、标签关联、点赞和收藏.
+func (s *memoryPostStore) Purge(ctx context.Context, opts *where.Options) (int64, error) {
+	defer s.store.lock(ctx, true)()
+
+	_, posts, err := s.store.posts.list(ctx, opts, scopeTrashed)
+	if err != nil || len(posts) == 0 {
+		return 0, err
+	}
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.PostID)
+	}
+	if err := s.store.revisions.delete(ctx, where.F("postID", ids)); err != nil {
+		return 0, err
+	}
+	if err := s.store.comments.delete(ctx, where.F("postID", ids)); err != nil {
+		return 0, err
+	}
+	if err := s.store.postTags.delete(ctx, where.F("postID", ids)); err != nil {
+		return 0, err
+	}
+	if err := s.store.likes.delete(ctx, where.F("postID", ids)); err != nil {
+		return 0, err
+	}
+	if err := s.store.bookmarks.delete(ctx, where.F("postID", ids)); err != nil {
+		return 0, err
+	}
+	return s.store.posts.purge(ctx, where.F("postID", ids))
+}
+
+// Increment 为帖子记录的计数列 column 增加 delta.
+func (s *memoryPostStore) Increment(ctx context.Context, postID string, column string, delta int64) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.posts.increment(ctx, where.F("postID", postID), column, delta)
+}
+
+// Get 根据条件查询帖子记录.
+func (s *memoryPostStore) Get(ctx context.Context, opts *where.Options) (*model.PostM, error) {
+	defer s.store.lock(ctx, false)()
+	opts, err := s.store.resolvePostTagged(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.posts.get(ctx, opts)
+}
+
+// List 返回帖子列表和总数.
+func (s *memoryPostStore) List(ctx context.Context, opts *where.Options) (int64, []*model.PostM, error) {
+	defer s.store.lock(ctx, false)()
+	opts, err := s.store.resolvePostTagged(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return s.store.posts.list(ctx, opts, scopeActive)
+}
+
+// Count 返回符合条件的帖子总数.
+func (s *memoryPostStore) Count(ctx context.Context, opts *where.Options) (int64, error) {
+	defer s.store.lock(ctx, false)()
+	opts, err := s.store.resolvePostTagged(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	count, _, err := s.store.posts.list(ctx, opts, scopeActive)
+	return count, err
+}
+
+// ListDeleted 返回回收站中的帖子列表和总数.
+func (s *memoryPostStore) ListDeleted(ctx context.Context, opts *where.Options) (int64, []*model.PostM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.posts.list(ctx, opts, scopeTrashed)
+}
+
+// memoryPostRevisionStore 是 PostRevisionStore 的内存实现.
+type memoryPostRevisionStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryPostRevisionStore 实现了 PostRevisionStore 接口.
+var _ PostRevisionStore = (*memoryPostRevisionStore)(nil)
+
+// Create 插入一条修订记录.
+func (s *memoryPostRevisionStore) Create(ctx context.Context, obj *model.PostRevisionM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.revisions.create(ctx, obj)
+}
+
+// Get 根据条件查询修订记录.
+func (s *memoryPostRevisionStore) Get(ctx context.Context, opts *where.Options) (*model.PostRevisionM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.revisions.get(ctx, opts)
+}
+
+// List 返回修订列表和总数.
+func (s *memoryPostRevisionStore) List(ctx context.Context, opts *where.Options) (int64, []*model.PostRevisionM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.revisions.list(ctx, opts, scopeActive)
+}
+
+// memoryCommentStore 是 CommentStore 的内存实现.
+type memoryCommentStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryCommentStore 实现了 CommentStore 接口.
+var _ CommentStore = (*memoryCommentStore)(nil)
+
+// Create 插入一条评论记录.
+func (s *memoryCommentStore) Create(ctx context.Context, obj *model.CommentM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.comments.create(ctx, obj)
+}
+
+// Update 更新评论记录.
+func (s *memoryCommentStore) Update(ctx context.Context, obj *model.CommentM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.comments.update(ctx, obj)
+}
+
+// Delete 根据条件删除评论记录.
+func (s *memoryCommentStore) Delete(ctx context.Context, opts *where.Options) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.comments.delete(ctx, opts)
+}
+
+// Get 根据条件查询评论记录.
+func (s *memoryCommentStore) Get(ctx context.Context, opts *where.Options) (*model.CommentM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.comments.get(ctx, opts)
+}
+
+// List 返回评论列表和总数.
+func (s *memoryCommentStore) List(ctx context.Context, opts *where.Options) (int64, []*model.CommentM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.comments.list(ctx, opts, scopeActive)
+}
+
+// memoryTagStore 是 TagStore 的内存实现.
+type memoryTagStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryTagStore 实现了 TagStore 接口.
+var _ TagStore = (*memoryTagStore)(nil)
+
+// CreateIfNotExist 插入 objs 中不存在的标签.
+func (s *memoryTagStore) CreateIfNotExist(ctx context.Context, objs []*model.TagM) error {
+	defer s.store.lock(ctx, true)()
+	for _, obj := range objs {
+		if err := s.store.tags.create(ctx, obj); err != nil && !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get 根据条件查询标签记录.
+func (s *memoryTagStore) Get(ctx context.Context, opts *where.Options) (*model.TagM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.tags.get(ctx, opts)
+}
+
+// List 返回标签列表和总数.
+func (s *memoryTagStore) List(ctx context.Context, opts *where.Options) (int64, []*model.TagM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.tags.list(ctx, opts, scopeActive)
+}
+
+// ListPostCounts 返回有已发布博客的标签及其已发布博客数.
+func (s *memoryTagStore) ListPostCounts(ctx context.Context, opts *where.Options) (int64, []*TagPostCount, error) {
+	defer s.store.lock(ctx, false)()
+
+	_, posts, err := s.store.posts.list(ctx, where.F("status", model.PostStatusPublished), scopeActive)
+	if err != nil {
+		return 0, nil, err
+	}
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.PostID)
+	}
+	_, postTags, err := s.store.postTags.list(ctx, where.F("postID", postIDs), scopeActive)
+	if err != nil {
+		return 0, nil, err
+	}
+	counts := make(map[int64]int64)
+	for _, postTag := range postTags {
+		counts[postTag.TagID]++
+	}
+	_, tags, err := s.store.tags.list(ctx, where.F("id", slices.Collect(maps.Keys(counts))), scopeActive)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	ret := make([]*TagPostCount, 0, len(tags))
+	for _, tag := range tags {
+		ret = append(ret, &TagPostCount{Name: tag.Name, PostCount: counts[tag.ID]})
+	}
+	slices.SortFunc(ret, func(a, b *TagPostCount) int {
+		return cmp.Or(cmp.Compare(b.PostCount, a.PostCount), strings.Compare(a.Name, b.Name))
+	})
+
+	count := int64(len(ret))
+	if opts != nil {
+		ret = ret[min(max(opts.Offset, 0), len(ret)):]
+		if opts.Limit >= 0 {
+			ret = ret[:min(opts.Limit, len(ret))]
+		}
+	}
+	return count, ret, nil
+}
+
+// memoryCategoryStore 是 CategoryStore 的内存实现.
+type memoryCategoryStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryCategoryStore 实现了 CategoryStore 接口.
+var _ CategoryStore = (*memoryCategoryStore)(nil)
+
+// CreateIfNotExist 在同名分类不存在时插入 obj.
+func (s *memoryCategoryStore) CreateIfNotExist(ctx context.Context, obj *model.CategoryM) error {
+	defer s.store.lock(ctx, true)()
+	if err := s.store.categories.create(ctx, obj); err != nil && !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return err
+	}
+	return nil
+}
+
+// Get 根据条件查询分类记录.
+func (s *memoryCategoryStore) Get(ctx context.Context, opts *where.Options) (*model.CategoryM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.categories.get(ctx, opts)
+}
+
+// List 返回分类列表和总数.
+func (s *memoryCategoryStore) List(ctx context.Context, opts *where.Options) (int64, []*model.CategoryM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.categories.list(ctx, opts, scopeActive)
+}
+
+// memoryPostTagStore 是 PostTagStore 的内存实现.
+type memoryPostTagStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryPostTagStore 实现了 PostTagStore 接口.
+var _ PostTagStore = (*memoryPostTagStore)(nil)
+
+// CreateInBatches 插入多条关联记录，任意一条失败时不会插入任何记录.
+func (s *memoryPostTagStore) CreateInBatches(ctx context.Context, objs []*model.PostTagM, batchSize int) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.postTags.createInBatches(ctx, objs)
+}
+
+// Delete 根据条件删除关联记录.
+func (s *memoryPostTagStore) Delete(ctx context.Context, opts *where.Options) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.postTags.delete(ctx, opts)
+}
+
+// List 返回关联列表和总数.
+func (s *memoryPostTagStore) List(ctx context.Context, opts *where.Options) (int64, []*model.PostTagM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.postTags.list(ctx, opts, scopeActive)
+}
+
+// memoryPostLikeStore 是 PostLikeStore 的内存实现.
+type memoryPostLikeStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryPostLikeStore 实现了 PostLikeStore 接口.
+var _ PostLikeStore = (*memoryPostLikeStore)(nil)
+
+// CreateIfNotExist 在用户尚未点赞时插入点赞记录.
+func (s *memoryPostLikeStore) CreateIfNotExist(ctx context.Context, obj *model.PostLikeM) (bool, error) {
+	defer s.store.lock(ctx, true)()
+	if err := s.store.likes.create(ctx, obj); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete 根据条件删除点赞记录.
+func (s *memoryPostLikeStore) Delete(ctx context.Context, opts *where.Options) (int64, error) {
+	defer s.store.lock(ctx, true)()
+	n, _, err := s.store.likes.list(ctx, opts, scopeActive)
+	if err != nil || n == 0 {
+		return 0, err
+	}
+	return n, s.store.likes.delete(ctx, opts)
+}
+
+// memoryBookmarkStore 是 BookmarkStore 的内存实现.
+type memoryBookmarkStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryBookmarkStore 实现了 BookmarkStore 接口.
+var _ BookmarkStore = (*memoryBookmarkStore)(nil)
+
+// CreateIfNotExist 在用户尚未收藏时插入收藏记录.
+func (s *memoryBookmarkStore) CreateIfNotExist(ctx context.Context, obj *model.BookmarkM) error {
+	defer s.store.lock(ctx, true)()
+	if err := s.store.bookmarks.create(ctx, obj); err != nil && !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return err
+	}
+	return nil
+}
+
+// Delete 根据条件删除收藏记录.
+func (s *memoryBookmarkStore) Delete(ctx context.Context, opts *where.Options) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.bookmarks.delete(ctx, opts)
+}
+
+// List 返回收藏列表和总数.
+func (s *memoryBookmarkStore) List(ctx context.Context, opts *where.Options) (int64, []*model.BookmarkM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.bookmarks.list(ctx, opts, scopeActive)
+}
+
+// memoryOutboxStore 是 OutboxStore 的内存实现.
+type memoryOutboxStore struct {
+	store *memoryStore
+}
+
+// 确保 memoryOutboxStore 实现了 OutboxStore 接口.
+var _ OutboxStore = (*memoryOutboxStore)(nil)
+
+// Create 插入一条事件记录.
+func (s *memoryOutboxStore) Create(ctx context.Context, obj *model.OutboxM) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.outboxs.create(ctx, obj)
+}
+
+// CreateInBatches 插入多条事件记录，任意一条失败时不会插入任何记录.
+func (s *memoryOutboxStore) CreateInBatches(ctx context.Context, objs []*model.OutboxM, batchSize int) error {
+	defer s.store.lock(ctx, true)()
+	return s.store.outboxs.createInBatches(ctx, objs)
+}
+
+// List 返回事件列表和总数.
+func (s *memoryOutboxStore) List(ctx context.Context, opts *where.Options) (int64, []*model.OutboxM, error) {
+	defer s.store.lock(ctx, false)()
+	return s.store.outboxs.list(ctx, opts, scopeActive)
+}
+
+// ListUnpublished 按写入顺序返回最多 limit 条未发布的事件.
+func (s *memoryOutboxStore) ListUnpublished(ctx context.Context, limit int) ([]*model.OutboxM, error) {
+	defer s.store.lock(ctx, false)()
+
+	var ret []*model.OutboxM
+	for _, row := range s.store.outboxs.rows {
+		if len(ret) == limit {
+			break
+		}
+		if !row.Published {
+			obj := *row
+			ret = append(ret, &obj)
+		}
+	}
+	return ret, nil
+}
+
+// MarkPublished 将事件标记为已发布.
+func (s *memoryOutboxStore) MarkPublished(ctx context.Context, ids []int64) error {
+	defer s.store.lock(ctx, true)()
+
+	now := time.Now()
+	rows := slices.Clone(s.store.outboxs.rows)
+	for i, row := range rows {
+		if slices.Contains(ids, row.ID) {
+			obj := *row
+			obj.Published, obj.PublishedAt = true, &now
+			rows[i] = &obj
+		}
+	}
+	s.store.outboxs.rows = rows
+	return nil
+}
+
+// table 是一张内存表，按主键升序保存记录.
+// 记录写入后不会被原地修改，更新时整体替换，因此快照只需复制切片.
+type table[T any] struct {
+	schema *schema.Schema
+	rows   []*T
+	nextID int64
+}
+
+// tableState 是 table 的快照，用于事务回滚.
+type tableState[T any] struct {
+	rows   []*T
+	nextID int64
+}
+
+// newTable 根据模型的 GORM 标签解析列名和唯一索引.
+func newTable[T any]() *table[T] {
+	sch, err := schema.Parse(new(T), &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		panic(err)
+	}
+	return &table[T]{schema: sch}
+}
+
+// snapshot 返回当前表的快照.
+func (t *table[T]) snapshot() tableState[T] {
+	return tableState[T]{rows: slices.Clone(t.rows), nextID: t.nextID}
+}
+
+// restore 将表恢复到快照时的状态.
+func (t *table[T]) restore(state tableState[T]) {
+	t.rows, t.nextID = state.rows, state.nextID
+}
+
+// create 执行 BeforeCreate 钩子，分配自增主键并插入记录.
+func (t *table[T]) create(ctx context.Context, obj *T) error {
+	if hook, ok := any(obj).(interface{ BeforeCreate(*gorm.DB) error }); ok {
+		if err := hook.BeforeCreate(nil); err != nil {
+			return err
+		}
+	}
+
+	rv := reflect.ValueOf(obj).Elem()
+	now := time.Now()
+	t.setIfZero(ctx, rv, "createdAt", now)
+	t.setIfZero(ctx, rv, "updatedAt", now)
+
+	pk := t.schema.PrioritizedPrimaryField
+	id, zero := pk.ValueOf(ctx, rv)
+	if zero {
+		if err := pk.Set(ctx, rv, t.nextID+1); err != nil {
+			return err
+		}
+		id, _ = pk.ValueOf(ctx, rv)
+	}
+	if _, idx := t.find(ctx, id); idx >= 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	if err := t.checkUnique(ctx, rv, -1); err != nil {
+		return err
+	}
+
+	row := *obj
+	t.rows = append(t.rows, &row)
+	if n := reflect.ValueOf(id).Int(); n > t.nextID {
+		t.nextID = n
+	}
+	return nil
+}
+
+// createInBatches 插入多条记录，任意一条失败时恢复到插入前的状态.
+func (t *table[T]) createInBatches(ctx context.Context, objs []*T) error {
+	state := t.snapshot()
+	for _, obj := range objs {
+		if err := t.create(ctx, obj); err != nil {
+			t.restore(state)
+			return err
+		}
+	}
+	return nil
+}
+
+// update 按主键整体替换记录，记录不存在时插入，与 GORM 的 Save 语义一致.
+func (t *table[T]) update(ctx context.Context, obj *T) error {
+	rv := reflect.ValueOf(obj).Elem()
+	id, zero := t.schema.PrioritizedPrimaryField.ValueOf(ctx, rv)
+	if zero {
+		return t.create(ctx, obj)
+	}
+	_, idx := t.find(ctx, id)
+	if idx < 0 {
+		return t.create(ctx, obj)
+	}
+
+	if hook, ok := any(obj).(interface{ BeforeUpdate(*gorm.DB) error }); ok {
+		if err := hook.BeforeUpdate(nil); err != nil {
+			return err
+		}
+	}
+	if field := t.schema.LookUpField("updatedAt"); field != nil {
+		if err := field.Set(ctx, rv, time.Now()); err != nil {
+			return err
+		}
+	}
+	if err := t.checkUnique(ctx, rv, idx); err != nil {
+		return err
+	}
+
+	row := *obj
+	t.rows[idx] = &row
+	return nil
+}
+
+// updateColumns 只更新记录的 columns 列. 表中有 version 列时使用乐观锁，
+// 版本号与 obj 不一致或记录不存在时返回 ErrVersionConflict，与 GORM 存储一致.
+func (t *table[T]) updateColumns(ctx context.Context, obj *T, columns []string) error {
+	rv := reflect.ValueOf(obj).Elem()
+	id, _ := t.schema.PrioritizedPrimaryField.ValueOf(ctx, rv)
+	current, idx := t.find(ctx, id)
+	version := t.schema.LookUpField("version")
+	// 与 GORM 一样，不会更新已被软删除的记录
+	if idx >= 0 && t.deleted(ctx, reflect.ValueOf(current).Elem()) {
+		idx = -1
+	}
+	if idx < 0 {
+		if version != nil {
+			return ErrVersionConflict
+		}
+		return nil
+	}
+
+	row := *current
+	rowv := reflect.ValueOf(&row).Elem()
+	if version != nil {
+		want, _ := version.ValueOf(ctx, rv)
+		got, _ := version.ValueOf(ctx, rowv)
+		if !equal(want, got) {
+			return ErrVersionConflict
+		}
+		if err := version.Set(ctx, rowv, got.(int64)+1); err != nil {
+			return err
+		}
+	}
+	for _, column := range columns {
+		field := t.schema.LookUpField(column)
+		if field == nil {
+			return fmt.Errorf("memory store: unknown column %s", column)
+		}
+		value, _ := field.ValueOf(ctx, rv)
+		if err := field.Set(ctx, rowv, value); err != nil {
+			return err
+		}
+	}
+	if err := t.update(ctx, &row); err != nil {
+		return err
+	}
+	*obj = row
+	return nil
+}
+
+// delete 删除所有满足条件的记录. 有 deletedAt 列的表只设置删除时间（软删除）.
+func (t *table[T]) delete(ctx context.Context, opts *where.Options) error {
+	match, err := t.compile(opts, scopeActive)
+	if err != nil {
+		return err
+	}
+
+	field := t.schema.LookUpField("deletedAt")
+	if field == nil {
+		t.rows = slices.DeleteFunc(slices.Clone(t.rows), func(row *T) bool {
+			return match(ctx, reflect.ValueOf(row).Elem())
+		})
+		return nil
+	}
+
+	_, err = t.replace(ctx, match, func(rv reflect.Value) error {
+		return field.Set(ctx, rv, gorm.DeletedAt{Time: time.Now(), Valid: true})
+	})
+	return err
+}
+
+// undelete 恢复所有满足条件的已被软删除的记录，返回恢复的记录数.
+func (t *table[T]) undelete(ctx context.Context, opts *where.Options) (int64, error) {
+	match, err := t.compile(opts, scopeTrashed)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	return t.replace(ctx, match, func(rv reflect.Value) error {
+		if err := t.schema.LookUpField("deletedAt").Set(ctx, rv, gorm.DeletedAt{}); err != nil {
+			return err
+		}
+		if field := t.schema.LookUpField("updatedAt"); field != nil {
+			return field.Set(ctx, rv, now)
+		}
+		return nil
+	})
+}
+
+// purge 永久删除所有满足条件的已被软删除的记录，返回删除的记录数.
+func (t *table[T]) purge(ctx context.Context, opts *where.Options) (int64, error) {
+	match, err := t.compile(opts, scopeTrashed)
+	if err != nil {
+		return 0, err
+	}
+
+	n := len(t.rows)
+	t.rows = slices.DeleteFunc(slices.Clone(t.rows), func(row *T) bool {
+		return match(ctx, reflect.ValueOf(row).Elem())
+	})
+	return int64(n - len(t.rows)), nil
+}
+
+// increment 为所有满足条件的未被删除记录的整数列 column 增加 delta，不会更新 updatedAt 和版本号.
+func (t *table[T]) increment(ctx context.Context, opts *where.Options, column string, delta int64) error {
+	field := t.schema.LookUpField(column)
+	if field == nil {
+		return fmt.Errorf("memory store: unknown column %s", column)
+	}
+	match, err := t.compile(opts, scopeActive)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.replace(ctx, match, func(rv reflect.Value) error {
+		value, _ := field.ValueOf(ctx, rv)
+		return field.Set(ctx, rv, value.(int64)+delta)
+	})
+	return err
+}
+
+// replace 使用 fn 修改所有满足条件的记录的副本并替换原记录，返回修改的记录数.
+func (t *table[T]) replace(ctx context.Context, match predicate, fn func(rv reflect.Value) error) (int64, error) {
+	var n int64
+	rows := slices.Clone(t.rows)
+	for i, row := range rows {
+		if !match(ctx, reflect.ValueOf(row).Elem()) {
+			continue
+		}
+		obj := *row
+		if err := fn(reflect.ValueOf(&obj).Elem()); err != nil {
+			return 0, err
+		}
+		rows[i] = &obj
+		n++
+	}
+	t.rows = rows
+	return n, nil
+}
+
+// get 返回主键最小的满足条件的记录，与 GORM 的 First 语义一致.
+func (t *table[T]) get(ctx context.Context, opts *where.Options) (*T, error) {
+	match, err := t.compile(opts, scopeActive)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range t.rows {
+		if match(ctx, reflect.ValueOf(row).Elem()) {
+			obj := *row
+			return &obj, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// list 按 opts 中的排序子句和默认排序返回 scope 范围内满足条件的记录，并返回分页前的总数.
+// 默认排序为 (createdAt, id) 倒序，回收站中的记录按 (deletedAt, id) 倒序.
+func (t *table[T]) list(ctx context.Context, opts *where.Options, scope rowScope) (int64, []*T, error) {
+	match, err := t.compile(opts, scope)
+	if err != nil {
+		return 0, nil, err
+	}
+	order := defaultOrder
+	if scope == scopeTrashed {
+		order = trashOrder
+	}
+
+	var matched []*T
+	for _, row := range t.rows {
+		if match(ctx, reflect.ValueOf(row).Elem()) {
+			matched = append(matched, row)
+		}
+	}
+	if err := t.sort(ctx, matched, opts, order); err != nil {
+		return 0, nil, err
+	}
+
+	count := int64(len(matched))
+	if opts != nil {
+		matched = matched[min(max(opts.Offset, 0), len(matched)):]
+		if opts.Limit >= 0 {
+			matched = matched[:min(opts.Limit, len(matched))]
+		}
+	}
+
+	ret := make([]*T, 0, len(matched))
+	for _, row := range matched {
+		obj := *row
+		ret = append(ret, &obj)
+	}
+	return count, ret, nil
+}
+
+// sort 先按 opts 中的排序子句排列记录，再按 order 排列，与 GORM 存储的排序一致.
+func (t *table[T]) sort(ctx context.Context, rows []*T, opts *where.Options, order clause.OrderBy) error {
+	var columns []clause.OrderByColumn
+	if opts != nil {
+		for _, expr := range opts.Clauses {
+			if orderBy, ok := expr.(clause.OrderBy); ok {
+				columns = append(columns, orderBy.Columns...)
+			}
+		}
+	}
+	columns = append(columns, order.Columns...)
+
+	type sortField struct {
+		field *schema.Field
+		desc  bool
+	}
+	var fields []sortField
+	for _, column := range columns {
+		field := t.schema.LookUpField(column.Column.Name)
+		if field == nil {
+			// 没有 createdAt 列的表只按主键排序
+			if slices.Contains(order.Columns, column) {
+				continue
+			}
+			return fmt.Errorf("memory store: unknown column %s", column.Column.Name)
+		}
+		fields = append(fields, sortField{field: field, desc: column.Desc})
+	}
+
+	slices.SortStableFunc(rows, func(a, b *T) int {
+		for _, f := range fields {
+			va, _ := f.field.ValueOf(ctx, reflect.ValueOf(a).Elem())
+			vb, _ := f.field.ValueOf(ctx, reflect.ValueOf(b).Elem())
+			c, _ := compare(va, vb)
+			if f.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return nil
+}
+
+// find 按主键查找记录，未找到时返回的下标为 -1.
+func (t *table[T]) find(ctx context.Context, id any) (*T, int) {
+	pk := t.schema.PrioritizedPrimaryField
+	for i, row := range t.rows {
+		if v, _ := pk.ValueOf(ctx, reflect.ValueOf(row).Elem()); equal(v, id) {
+			return row, i
+		}
+	}
+	return nil, -1
+}
+
+// checkUnique 检查 rv 是否违反唯一索引，skip 为需要跳过的记录下标（即被更新的记录自身）.
+// 与数据库迁移保持一致，索引列全部为空值时不做检查（例如未填写的手机号）.
+func (t *table[T]) checkUnique(ctx context.Context, rv reflect.Value, skip int) error {
+	for _, idx := range t.schema.ParseIndexes() {
+		if idx.Class != "UNIQUE" {
+			continue
+		}
+
+		allZero := true
+		for _, opt := range idx.Fields {
+			if _, zero := opt.Field.ValueOf(ctx, rv); !zero {
+				allZero = false
+			}
+		}
+		if allZero {
+			continue
+		}
+
+		for i, row := range t.rows {
+			if i == skip {
+				continue
+			}
+			dup := true
+			for _, opt := range idx.Fields {
+				a, _ := opt.Field.ValueOf(ctx, rv)
+				b, _ := opt.Field.ValueOf(ctx, reflect.ValueOf(row).Elem())
+				if !equal(a, b) {
+					dup = false
+					break
+				}
+			}
+			if dup {
+				return gorm.ErrDuplicatedKey
+			}
+		}
+	}
+	return nil
+}
+
+// deleted 判断记录是否已被软删除，没有 deletedAt 列的表总是返回 false.
+func (t *table[T]) deleted(ctx context.Context, rv reflect.Value) bool {
+	field := t.schema.LookUpField("deletedAt")
+	if field == nil {
+		return false
+	}
+	_, zero := field.ValueOf(ctx, rv)
+	return !zero
+}
+
+// setIfZero 在列值为零值时将其设置为 value.
+func (t *table[T]) setIfZero(ctx context.Context, rv reflect.Value, column string, value any) {
+	if field := t.schema.LookUpField(column); field != nil {
+		if _, zero := field.ValueOf(ctx, rv); zero {
+			_ = field.Set(ctx, rv, value)
+		}
+	}
+}
+
+// predicate 判断一条记录是否满足查询条件.
+type predicate func(ctx context.Context, rv reflect.Value) bool
+
+// rowScope 为查询的记录范围，对应 GORM 软删除的查询语义.
+type rowScope int
+
+const (
+	// scopeActive 只包含未被软删除的记录，即 GORM 的默认查询.
+	scopeActive rowScope = iota
+	// scopeTrashed 只包含已被软删除的记录，即 Unscoped 加上 deletedAt IS NOT NULL.
+	scopeTrashed
+	// scopeAll 包含所有记录，即 Unscoped.
+	scopeAll
+)
+
+// compile 将 where.Options 转换为 scope 范围内的 predicate. 不支持的查询条件返回错误，避免静默地返回错误的结果.
+func (t *table[T]) compile(opts *where.Options, scope rowScope) (predicate, error) {
+	preds := []predicate{func(ctx context.Context, rv reflect.Value) bool {
+		return scope == scopeAll || t.deleted(ctx, rv) == (scope == scopeTrashed)
+	}}
+	if opts != nil {
+		for k, v := range opts.Filters {
+			field := t.schema.LookUpField(fmt.Sprint(k))
+			if field == nil {
+				return nil, fmt.Errorf("memory store: unknown column %v", k)
+			}
+			preds = append(preds, filterPredicate(field, v))
+		}
+
+		for _, q := range opts.Queries {
+			pred, err := t.queryPredicate(q)
+			if err != nil {
+				return nil, err
+			}
+			preds = append(preds, pred)
+		}
+
+		for _, expr := range opts.Clauses {
+			// 排序子句由 list 处理，内存存储总是返回全部列
+			switch expr.(type) {
+			case clause.OrderBy, clause.Select:
+				continue
+			}
+			pred, err := t.clausePredicate(expr)
+			if err != nil {
+				return nil, err
+			}
+			preds = append(preds, pred)
+		}
+	}
+
+	return func(ctx context.Context, rv reflect.Value) bool {
+		for _, pred := range preds {
+			if !pred(ctx, rv) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// clausePredicate 将 GORM 条件子句转换为 predicate，支持比较、IN、LIKE 以及 AND/OR/NOT 组合.
+func (t *table[T]) clausePredicate(expr clause.Expression) (predicate, error) {
+	switch e := expr.(type) {
+	case clause.Eq:
+		return t.columnPredicate(e.Column, func(field *schema.Field) predicate { return filterPredicate(field, e.Value) })
+	case clause.Neq:
+		return t.columnPredicate(e.Column, func(field *schema.Field) predicate { return not(filterPredicate(field, e.Value)) })
+	case clause.IN:
+		return t.columnPredicate(e.Column, func(field *schema.Field) predicate { return filterPredicate(field, e.Values) })
+	case clause.Gt:
+		return t.columnPredicate(e.Column, comparePredicate(e.Value, func(c int) bool { return c > 0 }))
+	case clause.Gte:
+		return t.columnPredicate(e.Column, comparePredicate(e.Value, func(c int) bool { return c >= 0 }))
+	case clause.Lt:
+		return t.columnPredicate(e.Column, comparePredicate(e.Value, func(c int) bool { return c < 0 }))
+	case clause.Lte:
+		return t.columnPredicate(e.Column, comparePredicate(e.Value, func(c int) bool { return c <= 0 }))
+	case clause.Like:
+		return t.likePredicate(e.Column, fmt.Sprint(e.Value), 0)
+	case filtering.Like:
+		return t.likePredicate(e.Column, e.Value, filtering.LikeEscape)
+	case clause.Where:
+		return t.combine(e.Exprs, true)
+	case clause.AndConditions:
+		return t.combine(e.Exprs, true)
+	case clause.OrConditions:
+		return t.combine(e.Exprs, false)
+	case clause.NotConditions:
+		pred, err := t.combine(e.Exprs, true)
+		if err != nil {
+			return nil, err
+		}
+		return not(pred), nil
+	case interface{ Unwrap() clause.Expression }:
+		// 根据数据库类型生成 SQL 的条件，使用其与数据库无关的形式
+		return t.clausePredicate(e.Unwrap())
+	default:
+		return nil, fmt.Errorf("memory store: unsupported clause %T", expr)
+	}
+}
+
+// likePredicate 返回列值匹配 LIKE 模式 pattern 的 predicate，escape 为 0 时不支持转义.
+func (t *table[T]) likePredicate(column any, pattern string, escape rune) (predicate, error) {
+	re, err := likePattern(pattern, escape)
+	if err != nil {
+		return nil, err
+	}
+	return t.columnPredicate(column, func(field *schema.Field) predicate {
+		return func(ctx context.Context, rv reflect.Value) bool {
+			v, _ := field.ValueOf(ctx, rv)
+			return re.MatchString(fmt.Sprint(v))
+		}
+	})
+}
+
+// columnPredicate 查找 column 对应的字段，并使用 build 构造 predicate.
+func (t *table[T]) columnPredicate(column any, build func(field *schema.Field) predicate) (predicate, error) {
+	name := fmt.Sprint(column)
+	if col, ok := column.(clause.Column); ok {
+		name = col.Name
+	}
+	field := t.schema.LookUpField(name)
+	if field == nil {
+		return nil, fmt.Errorf("memory store: unknown column %s", name)
+	}
+	return build(field), nil
+}
+
+// combine 将多个子句组合为一个 predicate，and 为 false 时任意一个子句满足即可.
+func (t *table[T]) combine(exprs []clause.Expression, and bool) (predicate, error) {
+	preds := make([]predicate, 0, len(exprs))
+	for _, expr := range exprs {
+		pred, err := t.clausePredicate(expr)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+
+	return func(ctx context.Context, rv reflect.Value) bool {
+		for _, pred := range preds {
+			if pred(ctx, rv) != and {
+				return !and
+			}
+		}
+		return and || len(preds) == 0
+	}, nil
+}
+
+// queryPredicate 将 "<column> =|!=|<>|like ?" 形式的查询转换为 predicate.
+func (t *table[T]) queryPredicate(q where.Query) (predicate, error) {
+	query, ok := q.Query.(string)
+	if !ok {
+		return nil, fmt.Errorf("memory store: unsupported query %v", q.Query)
+	}
+	m := queryPattern.FindStringSubmatch(query)
+	if m == nil || len(q.Args) != 1 {
+		return nil, fmt.Errorf("memory store: unsupported query %q", query)
+	}
+	field := t.schema.LookUpField(m[1])
+	if field == nil {
+		return nil, fmt.Errorf("memory store: unknown column %s", m[1])
+	}
+
+	arg := q.Args[0]
+	switch strings.ToLower(m[2]) {
+	case "=":
+		return filterPredicate(field, arg), nil
+	case "!=", "<>":
+		return not(filterPredicate(field, arg)), nil
+	default:
+		re, err := likePattern(fmt.Sprint(arg), 0)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, rv reflect.Value) bool {
+			v, _ := field.ValueOf(ctx, rv)
+			return re.MatchString(fmt.Sprint(v))
+		}, nil
+	}
+}
+
+// filterPredicate 返回列值等于 value 的 predicate，value 为切片时匹配其中任意一个元素.
+func filterPredicate(field *schema.Field, value any) predicate {
+	values := []any{value}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		values = make([]any, rv.Len())
+		for i := range values {
+			values[i] = rv.Index(i).Interface()
+		}
+	}
+
+	return func(ctx context.Context, rv reflect.Value) bool {
+		v, _ := field.ValueOf(ctx, rv)
+		return slices.ContainsFunc(values, func(want any) bool { return equal(v, want) })
+	}
+}
+
+// comparePredicate 返回将列值与 value 比较，并由 ok 判断比较结果的 predicate 构造函数.
+// 无法比较的值（例如类型不同）视为不满足条件.
+func comparePredicate(value any, ok func(c int) bool) func(field *schema.Field) predicate {
+	return func(field *schema.Field) predicate {
+		return func(ctx context.Context, rv reflect.Value) bool {
+			v, _ := field.ValueOf(ctx, rv)
+			c, comparable := compare(v, value)
+			return comparable && ok(c)
+		}
+	}
+}
+
+// not 返回 pred 取反后的 predicate.
+func not(pred predicate) predicate {
+	return func(ctx context.Context, rv reflect.Value) bool { return !pred(ctx, rv) }
+}
+
+// compare 比较两个列值，支持时间、数值和字符串，第二个返回值表示两个值是否可比较.
+// 实现了 driver.Valuer 的列值（例如 gorm.DeletedAt）按其在数据库中的值比较.
+func compare(a, b any) (int, bool) {
+	a, b = columnValue(a), columnValue(b)
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb), true
+		}
+		return 0, false
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if fa, ok := number(va); ok {
+		if fb, ok := number(vb); ok {
+			return cmp.Compare(fa, fb), true
+		}
+		return 0, false
+	}
+	if va.Kind() == reflect.String && vb.Kind() == reflect.String {
+		return strings.Compare(va.String(), vb.String()), true
+	}
+	return 0, false
+}
+
+// columnValue 返回列值在数据库中的值，NULL 为 nil.
+func columnValue(v any) any {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if value, err := valuer.Value(); err == nil {
+			return value
+		}
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		return rv.Elem().Interface()
+	}
+	return v
+}
+
+// number 将整数和浮点数统一转换为 float64.
+func number(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// likePattern 将 SQL LIKE 模式转换为忽略大小写的正则表达式，escape 不为 0 时其后的字符按普通字符匹配.
+func likePattern(pattern string, escape rune) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		case escape != 0 && r == escape:
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// equal 比较两个列值，忽略整数等类型的差异.
+func equal(a, b any) bool {
+	a, b = columnValue(a), columnValue(b)
+	return a == b || fmt.Sprint(a) == fmt.Sprint(b)
+}
@@ -0,0 +1,313 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/filtering"
+	"github.com/wshadm/miniblog/internal/pkg/pagetoken"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// testPostFields 与博客列表接口允许过滤和排序的字段一致.
+var testPostFields = filtering.Fields{
+	"userID":    {Column: "userID", Type: filtering.String},
+	"title":     {Column: "title", Type: filtering.String},
+	"status":    {Column: "status", Type: filtering.String},
+	"likeCount": {Column: "likeCount", Type: filtering.Int},
+	"createdAt": {Column: "createdAt", Type: filtering.Time},
+}
+
+// testEpoch 为测试博客的起始创建时间，第 i 篇博客创建于 testEpoch 之后 i 小时.
+var testEpoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// seedPosts 向 s 中插入相同的一组博客，两种存储中博客的自增 ID 也相同.
+func seedPosts(t *testing.T, s IStore) {
+	t.Helper()
+
+	posts := []*model.PostM{
+		{UserID: "user-a", Title: "go basics", Status: model.PostStatusPublished, LikeCount: 5},
+		{UserID: "user-a", Title: "go advanced", Status: model.PostStatusDraft},
+		{UserID: "user-b", Title: "rust intro", Status: model.PostStatusPublished, LikeCount: 2},
+		{UserID: "user-b", Title: "Go_tips 100%", Status: model.PostStatusPublished, LikeCount: 3},
+		{UserID: "user-c", Title: "python", Status: model.PostStatusScheduled, LikeCount: 1},
+		{UserID: "user-c", Title: "another go", Status: model.PostStatusPublished, LikeCount: 3},
+	}
+	for i, post := range posts {
+		post.Content = "content"
+		post.CreatedAt = testEpoch.Add(time.Duration(i) * time.Hour)
+		post.UpdatedAt = post.CreatedAt
+	}
+	if err := s.Post().CreateInBatches(context.Background(), posts, 100); err != nil {
+		t.Fatalf("failed to seed posts: %v", err)
+	}
+}
+
+// titles 返回博客的标题.
+func titles(posts []*model.PostM) []string {
+	ret := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ret = append(ret, post.Title)
+	}
+	return ret
+}
+
+// mustFilter 解析过滤和排序表达式.
+func mustFilter(t *testing.T, filter string, orderBy string) []clause.Expression {
+	t.Helper()
+
+	exprs, err := testPostFields.Filter(filter)
+	if err != nil {
+		t.Fatalf("Filter(%q): %v", filter, err)
+	}
+	order, err := testPostFields.OrderBy(orderBy)
+	if err != nil {
+		t.Fatalf("OrderBy(%q): %v", orderBy, err)
+	}
+	return append(exprs, order...)
+}
+
+// newStorePair 返回插入了相同数据的 SQLite 存储和内存存储.
+func newStorePair(t *testing.T) (IStore, IStore) {
+	t.Helper()
+
+	db, mem := newSQLiteStore(t), NewMemoryStore()
+	seedPosts(t, db)
+	seedPosts(t, mem)
+	return db, mem
+}
+
+// TestMemoryStorePostListMatchesSQLite 对两种存储执行相同的查询条件，结果必须一致.
+func TestMemoryStorePostListMatchesSQLite(t *testing.T) {
+	ctx := context.Background()
+	db, mem := newStorePair(t)
+	ts := func(i int) string { return testEpoch.Add(time.Duration(i) * time.Hour).Format(time.RFC3339) }
+
+	tests := []struct {
+		name string
+		opts *where.Options
+		// want 为期望的记录数，避免两种存储都返回空结果时测试通过
+		want int64
+	}{
+		{"all", where.NewWhere(), 6},
+		{"filter", where.F("userID", "user-a"), 2},
+		{"filter in", where.F("userID", []string{"user-a", "user-b"}), 4},
+		{"multiple filters", where.F("userID", "user-c", "status", model.PostStatusPublished), 1},
+		{"no match", where.F("userID", "user-x"), 0},
+		{"query like", where.NewWhere().Q("title like ?", "%go%"), 4},
+		{"query like underscore", where.NewWhere().Q("title like ?", "go_b%"), 1},
+		{"query equal", where.NewWhere().Q("title = ?", "python"), 1},
+		{"query not equal", where.NewWhere().Q("userID != ?", "user-a"), 4},
+		{"filter contains and compare", where.C(mustFilter(t, `title:"go" AND likeCount >= 3`, "")...), 3},
+		// : 的值中的 % 和 _ 不是通配符
+		{"filter contains underscore", where.C(mustFilter(t, `title:"_"`, "")...), 1},
+		{"filter contains percent", where.C(mustFilter(t, `title:"100%"`, "")...), 1},
+		{"filter contains escape character", where.C(mustFilter(t, `title:"!"`, "")...), 0},
+		{"contains clause", where.C(filtering.Contains("title", "O_T")), 1},
+		{"filter or", where.C(mustFilter(t, `status = "draft" OR likeCount > 4`, "")...), 2},
+		{"filter not", where.C(mustFilter(t, `NOT userID = "user-a"`, "")...), 4},
+		{"filter nested", where.C(mustFilter(t, `(title = "python" OR title = "rust intro") NOT status = "scheduled"`, "")...), 1},
+		{"filter time after", where.C(mustFilter(t, `createdAt > "`+ts(2)+`"`, "")...), 3},
+		{"filter time before", where.C(mustFilter(t, `createdAt <= "`+ts(1)+`"`, "")...), 2},
+		{"order by", where.C(mustFilter(t, "", "likeCount desc, title")...), 6},
+		{"order by with offset and limit", where.C(mustFilter(t, "", "likeCount, title desc")...).O(1).L(3), 6},
+		{"default order with limit", where.L(2), 6},
+		{"offset past end", where.O(10).L(2), 6},
+		{"or clause", where.C(clause.Or(
+			clause.Eq{Column: clause.Column{Name: "status"}, Value: model.PostStatusPublished},
+			clause.Eq{Column: clause.Column{Name: "userID"}, Value: "user-a"},
+		)), 5},
+		{"in clause", where.C(clause.IN{Column: clause.Column{Name: "title"}, Values: []any{"python", "go basics"}}), 2},
+		{"keyset", where.C(pagetoken.After(pagetoken.Cursor{CreatedAt: testEpoch.Add(3 * time.Hour), ID: 4})), 3},
+		{"filter with query and clause", where.F("status", model.PostStatusPublished).Q("title like ?", "%o%").
+			C(mustFilter(t, `likeCount < 5`, "title")...), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// SQL 存储生成 SQL 时会把 Queries 追加到 opts.Clauses 中，因此先查询内存存储
+			gotCount, gotPosts, err := mem.Post().List(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("memory List: %v", err)
+			}
+			// Count 忽略分页参数，与 List 返回的总数一致
+			memCount, err := mem.Post().Count(ctx, tt.opts)
+			if err != nil || memCount != gotCount {
+				t.Errorf("memory Count() = %d, %v, want %d", memCount, err, gotCount)
+			}
+
+			wantCount, wantPosts, err := db.Post().List(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("sqlite List: %v", err)
+			}
+			if wantCount != tt.want {
+				t.Fatalf("sqlite count = %d, want %d", wantCount, tt.want)
+			}
+			if gotCount != wantCount || !slices.Equal(titles(gotPosts), titles(wantPosts)) {
+				t.Errorf("memory List() = %d %q, sqlite = %d %q", gotCount, titles(gotPosts), wantCount, titles(wantPosts))
+			}
+			if count, err := db.Post().Count(ctx, tt.opts); err != nil || count != wantCount {
+				t.Errorf("sqlite Count() = %d, %v, want %d", count, err, wantCount)
+			}
+		})
+	}
+}
+
+func TestMemoryStorePostGetMatchesSQLite(t *testing.T) {
+	ctx := context.Background()
+	db, mem := newStorePair(t)
+
+	tests := []struct {
+		name    string
+		opts    *where.Options
+		wantErr error
+	}{
+		{"found", where.F("title", "python"), nil},
+		{"not found", where.F("title", "nope"), gorm.ErrRecordNotFound},
+		{"query", where.NewWhere().Q("title like ?", "rust%"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mem.Post().Get(ctx, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("memory Get() error = %v, want %v", err, tt.wantErr)
+			}
+			want, err := db.Post().Get(ctx, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("sqlite Get() error = %v, want %v", err, tt.wantErr)
+			}
+			if want != nil && (got.Title != want.Title || got.UserID != want.UserID || got.LikeCount != want.LikeCount) {
+				t.Errorf("memory Get() = %+v, sqlite = %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSoftDeleteMatchesSQLite(t *testing.T) {
+	ctx := context.Background()
+	db, mem := newStorePair(t)
+
+	for name, s := range map[string]IStore{"sqlite": db, "memory": mem} {
+		t.Run(name, func(t *testing.T) {
+			if err := s.Post().Delete(ctx, where.F("userID", "user-c")); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if count, _, err := s.Post().List(ctx, where.NewWhere()); err != nil || count != 4 {
+				t.Errorf("List() after Delete = %d, %v, want 4", count, err)
+			}
+			count, posts, err := s.Post().ListDeleted(ctx, where.F("userID", "user-c"))
+			if err != nil || count != 2 || !slices.Equal(titles(posts), []string{"another go", "python"}) {
+				t.Errorf("ListDeleted() = %d %q, %v, want [another go python]", count, titles(posts), err)
+			}
+			if _, err := s.Post().Get(ctx, where.F("title", "python")); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("Get() deleted post error = %v, want %v", err, gorm.ErrRecordNotFound)
+			}
+
+			if n, err := s.Post().Restore(ctx, where.F("title", "python")); err != nil || n != 1 {
+				t.Errorf("Restore() = %d, %v, want 1", n, err)
+			}
+			if count, _, err := s.Post().List(ctx, where.NewWhere()); err != nil || count != 5 {
+				t.Errorf("List() after Restore = %d, %v, want 5", count, err)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreTXMatchesSQLite(t *testing.T) {
+	ctx := context.Background()
+	db, mem := newStorePair(t)
+	rollback := errors.New("rollback")
+
+	for name, s := range map[string]IStore{"sqlite": db, "memory": mem} {
+		t.Run(name, func(t *testing.T) {
+			likeCount := func() int64 {
+				t.Helper()
+				post, err := s.Post().Get(ctx, where.F("title", "go basics"))
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				return post.LikeCount
+			}
+			postID := func() string {
+				t.Helper()
+				post, err := s.Post().Get(ctx, where.F("title", "go basics"))
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				return post.PostID
+			}()
+
+			write := func(ctx context.Context) error {
+				if err := s.Post().Create(ctx, &model.PostM{UserID: "user-d", Title: "new", Content: "content"}); err != nil {
+					return err
+				}
+				if err := s.Post().Increment(ctx, postID, "likeCount", 1); err != nil {
+					return err
+				}
+				// 事务内能读到本事务中的修改
+				if count, _, err := s.Post().List(ctx, where.NewWhere()); err != nil || count != 7 {
+					t.Errorf("List() in transaction = %d, %v, want 7", count, err)
+				}
+				return nil
+			}
+
+			err := s.TX(ctx, func(ctx context.Context) error {
+				if err := write(ctx); err != nil {
+					return err
+				}
+				return rollback
+			})
+			if !errors.Is(err, rollback) {
+				t.Fatalf("TX() error = %v, want %v", err, rollback)
+			}
+			if count, _, err := s.Post().List(ctx, where.NewWhere()); err != nil || count != 6 {
+				t.Errorf("List() after rollback = %d, %v, want 6", count, err)
+			}
+			if n := likeCount(); n != 5 {
+				t.Errorf("likeCount after rollback = %d, want 5", n)
+			}
+
+			if err := s.TX(ctx, write); err != nil {
+				t.Fatalf("TX: %v", err)
+			}
+			if count, _, err := s.Post().List(ctx, where.NewWhere()); err != nil || count != 7 {
+				t.Errorf("List() after commit = %d, %v, want 7", count, err)
+			}
+			if n := likeCount(); n != 6 {
+				t.Errorf("likeCount after commit = %d, want 6", n)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreUserExistsMatchesSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range map[string]IStore{"sqlite": newSQLiteStore(t), "memory": NewMemoryStore()} {
+		t.Run(name, func(t *testing.T) {
+			user := &model.UserM{Username: "carol", Password: "miniblog1234", Nickname: "carol", Email: "carol@qq.com", Phone: "18110000003"}
+			if err := s.User().Create(ctx, user); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if err := s.User().Delete(ctx, where.F("userID", user.UserID)); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+
+			// 被软删除的用户仍然占用用户名
+			if exists, err := s.User().Exists(ctx, where.F("username", "carol")); err != nil || !exists {
+				t.Errorf("Exists() deleted user = %v, %v, want true", exists, err)
+			}
+			if exists, err := s.User().Exists(ctx, where.F("username", "dave")); err != nil || exists {
+				t.Errorf("Exists() unknown user = %v, %v, want false", exists, err)
+			}
+			if _, err := s.User().Get(ctx, where.F("username", "carol")); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("Get() deleted user error = %v, want %v", err, gorm.ErrRecordNotFound)
+			}
+		})
+	}
+}
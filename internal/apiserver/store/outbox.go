@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+)
+
+// OutboxStore 定义了事务发件箱在 store 层所实现的方法.
+type OutboxStore interface {
+	Create(ctx context.Context, obj *model.OutboxM) error
+	List(ctx context.Context, opts *where.Options) (int64, []*model.OutboxM, error)
+	OutboxExpansion
+}
+
+// OutboxExpansion 定义了事务发件箱的附加方法.
+type OutboxExpansion interface {
+	CreateInBatches(ctx context.Context, objs []*model.OutboxM, batchSize int) error
+	ListUnpublished(ctx context.Context, limit int) ([]*model.OutboxM, error)
+	MarkPublished(ctx context.Context, ids []int64) error
+}
+
+// outboxStore 是 OutboxStore 接口的实现.
+type outboxStore struct {
+	store *datastore
+}
+
+// 确保 outboxStore 实现了 OutboxStore 接口.
+var _ OutboxStore = (*outboxStore)(nil)
+
+// newOutboxStore 创建 outboxStore 的实例.
+func newOutboxStore(store *datastore) *outboxStore {
+	return &outboxStore{store: store}
+}
+
+// Create 插入一条事件记录，需要和业务数据在同一个事务中调用.
+func (s *outboxStore) Create(ctx context.Context, obj *model.OutboxM) error {
+	if err := s.store.DB(ctx).Create(obj).Error; err != nil {
+		log.Errorw("Failed to insert outbox event into database", "err", err, "event", obj)
+		return err
+	}
+	return nil
+}
+
+// CreateInBatches 分批插入多条事件记录.
+func (s *outboxStore) CreateInBatches(ctx context.Context, objs []*model.OutboxM, batchSize int) error {
+	if err := s.store.DB(ctx).CreateInBatches(objs, batchSize).Error; err != nil {
+		log.Errorw("Failed to batch insert outbox events into database", "err", err, "count", len(objs))
+		return err
+	}
+	return nil
+}
+
+// List 返回事件列表和总数.
+func (s *outboxStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.OutboxM, err error) {
+	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list outbox events from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
+
+// ListUnpublished 按写入顺序返回最多 limit 条未发布的事件.
+func (s *outboxStore) ListUnpublished(ctx context.Context, limit int) (ret []*model.OutboxM, err error) {
+	err = s.store.DB(ctx).Where("published = ?", false).Order("id asc").Limit(limit).Find(&ret).Error
+	if err != nil {
+		log.Errorw("Failed to list unpublished outbox events from database", "err", err)
+		return nil, err
+	}
+	return
+}
+
+// MarkPublished 将事件标记为已发布.
+func (s *outboxStore) MarkPublished(ctx context.Context, ids []int64) error {
+	err := s.store.DB(ctx).Model(&model.OutboxM{}).Where("id IN ?", ids).
+		Updates(map[string]any{"published": true, "publishedAt": time.Now()}).Error
+	if err != nil {
+		log.Errorw("Failed to mark outbox events as published", "err", err, "ids", ids)
+		return err
+	}
+	return nil
+}
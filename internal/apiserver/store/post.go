@@ -2,10 +2,14 @@ package store
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/onexstack/onexstack/pkg/store/where"
 	"github.com/wshadm/miniblog/internal/apiserver/model"
 	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostStore interface {
@@ -18,7 +22,18 @@ type PostStore interface {
 }
 
 // PostExpansion 定义了帖子操作的附加方法.
-type PostExpansion interface{}
+// Delete 只是将帖子移入回收站（软删除），回收站中的帖子可以通过 Restore 恢复或通过 Purge 永久删除.
+type PostExpansion interface {
+	CreateInBatches(ctx context.Context, objs []*model.PostM, batchSize int) error
+	UpdateColumns(ctx context.Context, obj *model.PostM, columns ...string) error
+	// Count 返回符合条件的帖子总数，忽略 opts 中的分页参数.
+	Count(ctx context.Context, opts *where.Options) (int64, error)
+	ListDeleted(ctx context.Context, opts *where.Options) (int64, []*model.PostM, error)
+	Restore(ctx context.Context, opts *where.Options) (int64, error)
+	Purge(ctx context.Context, opts *where.Options) (int64, error)
+	// Increment 为博客的计数列 column 增加 delta，不会更新 updatedAt 和版本号.
+	Increment(ctx context.Context, postID string, column string, delta int64) error
+}
 
 // postStore 是 PostStore 接口的实现.
 type postStore struct {
@@ -42,16 +57,56 @@ func (s *postStore) Create(ctx context.Context, obj *model.PostM) error {
 	return nil
 }
 
+// CreateInBatches 分批插入多条帖子记录，每批只需要一次数据库写入.
+func (s *postStore) CreateInBatches(ctx context.Context, objs []*model.PostM, batchSize int) error {
+	if err := s.store.DB(ctx).CreateInBatches(objs, batchSize).Error; err != nil {
+		log.Errorw("Failed to batch insert posts into database", "err", err, "count", len(objs))
+		return err
+	}
+	return nil
+}
+
 // Update 更新帖子数据库记录.
 func (s *postStore) Update(ctx context.Context, obj *model.PostM) error {
 	if err := s.store.DB(ctx).Save(obj).Error; err != nil {
-		log.Errorw("Failed to insert post into database", "err", err, "post", obj)
+		log.Errorw("Failed to update post in database", "err", err, "post", obj)
 		return err
 	}
 	return nil
 }
 
-// Delete 根据条件删除帖子记录.
+// UpdateColumns 只更新帖子记录的 columns 列，updatedAt 总是会被更新.
+// 使用乐观锁：只有数据库中的版本号与 obj.Version 一致时才会更新，更新后版本号加 1，否则返回 ErrVersionConflict.
+func (s *postStore) UpdateColumns(ctx context.Context, obj *model.PostM, columns ...string) error {
+	version := obj.Version
+	obj.Version++
+	result := s.store.DB(ctx).Model(obj).Where(clause.Eq{Column: clause.Column{Name: "version"}, Value: version}).
+		Select(append(slices.Clone(columns), "updatedAt", "version")).Updates(obj)
+	if result.Error != nil {
+		obj.Version = version
+		log.Errorw("Failed to update post columns in database", "err", result.Error, "post", obj, "columns", columns)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		obj.Version = version
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// Increment 使用 column = column + delta 原子地更新计数，并发更新不会丢失计数.
+// 使用 UpdateColumn 跳过 updatedAt 和版本号，计数变化不会使客户端持有的 etag 失效.
+func (s *postStore) Increment(ctx context.Context, postID string, column string, delta int64) error {
+	err := s.store.DB(ctx).Model(&model.PostM{}).Where(clause.Eq{Column: clause.Column{Name: "postID"}, Value: postID}).
+		UpdateColumn(column, gorm.Expr("? + ?", clause.Column{Name: column}, delta)).Error
+	if err != nil {
+		log.Errorw("Failed to increment post counter in database", "err", err, "postID", postID, "column", column, "delta", delta)
+		return err
+	}
+	return nil
+}
+
+// Delete 根据条件将帖子记录移入回收站.
 func (s *postStore) Delete(ctx context.Context, opts *where.Options) error {
 	err := s.store.DB(ctx, opts).Delete(&model.PostM{}).Error
 	if err != nil {
@@ -61,11 +116,62 @@ func (s *postStore) Delete(ctx context.Context, opts *where.Options) error {
 	return nil
 }
 
+// Restore 恢复回收站中满足条件的帖子记录，返回恢复的记录数.
+func (s *postStore) Restore(ctx context.Context, opts *where.Options) (int64, error) {
+	result := s.store.DB(ctx, opts).Unscoped().Model(&model.PostM{}).Where(trashed).Update("deletedAt", nil)
+	if result.Error != nil {
+		log.Errorw("Failed to restore post in database", "err", result.Error, "conditions", opts)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// Purge 永久删除回收站中满足条件的帖子记录及其修订历史、评论、标签关联、点赞和收藏，返回删除的记录数.
+func (s *postStore) Purge(ctx context.Context, opts *where.Options) (int64, error) {
+	var n int64
+	err := s.store.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		if err := opts.Where(tx).Unscoped().Model(&model.PostM{}).Where(trashed).Pluck("postID", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		byPostID := map[string]any{"postID": ids}
+		if err := tx.Where(byPostID).Delete(&model.PostRevisionM{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(byPostID).Delete(&model.CommentM{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(byPostID).Delete(&model.PostTagM{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(byPostID).Delete(&model.PostLikeM{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(byPostID).Delete(&model.BookmarkM{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where(byPostID).Delete(&model.PostM{})
+		n = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		log.Errorw("Failed to purge post from database", "err", err, "conditions", opts)
+		return 0, err
+	}
+	return n, nil
+}
+
 // Get 根据条件查询帖子记录.
 func (s *postStore) Get(ctx context.Context, opts *where.Options) (*model.PostM, error) {
 	var obj model.PostM //目的是作为返回实例使用
 	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
-		log.Errorw("Failed to retrieve post from database", "err", err, "conditions", opts)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorw("Failed to retrieve post from database", "err", err, "conditions", opts)
+		}
 		return nil, err
 	}
 	return &obj, nil
@@ -75,10 +181,30 @@ func (s *postStore) Get(ctx context.Context, opts *where.Options) (*model.PostM,
 func (s *postStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.PostM, err error) {
 	//直接使用返回值中的变量ret，也相当于直接修改它的值。由于在返回值中已经预先声明了变量。所以err要用“=”赋值
 	//只使用return，是因为返回参数中已经命名了，会自动返回这些变量。
-	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
 	if err != nil {
 		log.Errorw("Failed to list posts from database", "err", err, "conditions", opts)
 		return 0, nil, err
 	}
 	return
 }
+
+// Count 返回符合条件的帖子总数.
+func (s *postStore) Count(ctx context.Context, opts *where.Options) (count int64, err error) {
+	err = s.store.DB(ctx, opts).Model(&model.PostM{}).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to count posts from database", "err", err, "conditions", opts)
+		return 0, err
+	}
+	return
+}
+
+// ListDeleted 返回回收站中的帖子列表和总数，按删除时间倒序排列.
+func (s *postStore) ListDeleted(ctx context.Context, opts *where.Options) (count int64, ret []*model.PostM, err error) {
+	err = s.store.DB(ctx, opts).Unscoped().Where(trashed).Order(trashOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list deleted posts from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm/clause"
+)
+
+// PostLikeStore 定义了博客点赞在 store 层所实现的方法.
+// 博客被永久删除时，其点赞随之删除.
+type PostLikeStore interface {
+	// CreateIfNotExist 在用户尚未点赞时插入 obj，返回是否插入了新的点赞.
+	CreateIfNotExist(ctx context.Context, obj *model.PostLikeM) (bool, error)
+	// Delete 根据条件删除点赞，返回删除的记录数.
+	Delete(ctx context.Context, opts *where.Options) (int64, error)
+}
+
+// postLikeStore 是 PostLikeStore 接口的实现.
+type postLikeStore struct {
+	store *datastore
+}
+
+// 确保 postLikeStore 实现了 PostLikeStore 接口.
+var _ PostLikeStore = (*postLikeStore)(nil)
+
+// newPostLikeStore 创建 postLikeStore 的实例.
+func newPostLikeStore(store *datastore) *postLikeStore {
+	return &postLikeStore{store: store}
+}
+
+// CreateIfNotExist 插入一条点赞记录，同一用户重复点赞时不会返回错误.
+func (s *postLikeStore) CreateIfNotExist(ctx context.Context, obj *model.PostLikeM) (bool, error) {
+	result := s.store.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(obj)
+	if result.Error != nil {
+		log.Errorw("Failed to insert post like into database", "err", result.Error, "like", obj)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Delete 根据条件删除点赞记录.
+func (s *postLikeStore) Delete(ctx context.Context, opts *where.Options) (int64, error) {
+	result := s.store.DB(ctx, opts).Delete(&model.PostLikeM{})
+	if result.Error != nil {
+		log.Errorw("Failed to delete post likes from database", "err", result.Error, "conditions", opts)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm"
+)
+
+// PostRevisionStore 定义了博客修订历史在 store 层所实现的方法.
+// 修订只会追加，不会被修改；博客被永久删除时，其修订随之删除.
+type PostRevisionStore interface {
+	Create(ctx context.Context, obj *model.PostRevisionM) error
+	Get(ctx context.Context, opts *where.Options) (*model.PostRevisionM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.PostRevisionM, error)
+}
+
+// postRevisionStore 是 PostRevisionStore 接口的实现.
+type postRevisionStore struct {
+	store *datastore
+}
+
+// 确保 postRevisionStore 实现了 PostRevisionStore 接口.
+var _ PostRevisionStore = (*postRevisionStore)(nil)
+
+// newPostRevisionStore 创建 postRevisionStore 的实例.
+func newPostRevisionStore(store *datastore) *postRevisionStore {
+	return &postRevisionStore{store: store}
+}
+
+// Create 插入一条修订记录，需要和博客的修改在同一个事务中调用.
+func (s *postRevisionStore) Create(ctx context.Context, obj *model.PostRevisionM) error {
+	if err := s.store.DB(ctx).Create(obj).Error; err != nil {
+		log.Errorw("Failed to insert post revision into database", "err", err, "postID", obj.PostID, "revision", obj.Revision)
+		return err
+	}
+	return nil
+}
+
+// Get 根据条件查询修订记录.
+func (s *postRevisionStore) Get(ctx context.Context, opts *where.Options) (*model.PostRevisionM, error) {
+	var obj model.PostRevisionM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorw("Failed to retrieve post revision from database", "err", err, "conditions", opts)
+		}
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// List 返回修订列表和总数，按创建时间倒序排列，即最新的修订在前.
+func (s *postRevisionStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.PostRevisionM, err error) {
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list post revisions from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm/clause"
+)
+
+// PostTagStore 定义了博客与标签的关联在 store 层所实现的方法.
+// 博客被永久删除时，其标签关联随之删除.
+type PostTagStore interface {
+	CreateInBatches(ctx context.Context, objs []*model.PostTagM, batchSize int) error
+	Delete(ctx context.Context, opts *where.Options) error
+	List(ctx context.Context, opts *where.Options) (int64, []*model.PostTagM, error)
+}
+
+// postTagStore 是 PostTagStore 接口的实现.
+type postTagStore struct {
+	store *datastore
+}
+
+// 确保 postTagStore 实现了 PostTagStore 接口.
+var _ PostTagStore = (*postTagStore)(nil)
+
+// newPostTagStore 创建 postTagStore 的实例.
+func newPostTagStore(store *datastore) *postTagStore {
+	return &postTagStore{store: store}
+}
+
+// CreateInBatches 分批插入多条关联记录.
+func (s *postTagStore) CreateInBatches(ctx context.Context, objs []*model.PostTagM, batchSize int) error {
+	if len(objs) == 0 {
+		return nil
+	}
+	if err := s.store.DB(ctx).CreateInBatches(objs, batchSize).Error; err != nil {
+		log.Errorw("Failed to batch insert post tags into database", "err", err, "count", len(objs))
+		return err
+	}
+	return nil
+}
+
+// Delete 根据条件删除关联记录.
+func (s *postTagStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(&model.PostTagM{}).Error
+	if err != nil {
+		log.Errorw("Failed to delete post tags from database", "err", err, "conditions", opts)
+		return err
+	}
+	return nil
+}
+
+// List 返回关联列表和总数.
+func (s *postTagStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.PostTagM, err error) {
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list post tags from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
+
+// PostTagged 返回带有标签 tagID 的博客的查询条件，用于 PostStore 的查询.
+func PostTagged(tagID int64) clause.Expression {
+	return postTagged{tagID: tagID}
+}
+
+// postTagged 是按标签查询博客的条件，生成 postID IN (SELECT postID FROM post_tag WHERE tagID = ?) 子查询.
+type postTagged struct {
+	tagID int64
+}
+
+// Build 实现 clause.Expression 接口.
+func (e postTagged) Build(builder clause.Builder) {
+	builder.WriteQuoted(clause.Column{Name: "postID"})
+	builder.WriteString(" IN (SELECT ")
+	builder.WriteQuoted(clause.Column{Name: "postID"})
+	builder.WriteString(" FROM ")
+	builder.WriteQuoted(clause.Table{Name: model.TableNamePostTagM})
+	builder.WriteString(" WHERE ")
+	builder.WriteQuoted(clause.Column{Name: "tagID"})
+	builder.WriteString(" = ")
+	builder.AddVar(builder, e.tagID)
+	builder.WriteByte(')')
+}
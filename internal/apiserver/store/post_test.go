@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/rid"
+	"gorm.io/gorm"
+)
+
+// newPosts 返回 n 篇尚未插入数据库的博客.
+func newPosts(n int) []*model.PostM {
+	posts := make([]*model.PostM, 0, n)
+	for i := range n {
+		posts = append(posts, &model.PostM{UserID: "user-bench", Title: fmt.Sprintf("title %d", i), Content: "content"})
+	}
+	return posts
+}
+
+// BenchmarkPostCreate 比较插入博客的几种方式，ns/op 均为插入一篇博客的耗时.
+//
+//	go test ./internal/apiserver/store -run '^$' -bench BenchmarkPostCreate
+func BenchmarkPostCreate(b *testing.B) {
+	ctx := context.Background()
+
+	// AfterCreateSave 模拟之前的实现：插入后根据自增 ID 生成 postID，再 Save 一次，每篇博客需要两次写入
+	b.Run("AfterCreateSave", func(b *testing.B) {
+		s := newSQLiteStore(b)
+		posts := newPosts(b.N)
+		b.ResetTimer()
+		for _, post := range posts {
+			err := s.DB(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(post).Error; err != nil {
+					return err
+				}
+				post.PostID = rid.PostID.New(uint64(post.ID))
+				return tx.Save(post).Error
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// Create 在插入前生成 postID，每篇博客只需要一次写入
+	b.Run("Create", func(b *testing.B) {
+		s := newSQLiteStore(b)
+		posts := newPosts(b.N)
+		b.ResetTimer()
+		for _, post := range posts {
+			if err := s.Post().Create(ctx, post); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// CreateInBatches 每 100 篇博客只需要一次写入
+	b.Run("CreateInBatches", func(b *testing.B) {
+		s := newSQLiteStore(b)
+		posts := newPosts(b.N)
+		b.ResetTimer()
+		if err := s.Post().CreateInBatches(ctx, posts, 100); err != nil {
+			b.Fatal(err)
+		}
+	})
+}
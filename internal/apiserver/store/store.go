@@ -2,10 +2,12 @@ package store
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"github.com/onexstack/onexstack/pkg/store/where"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -20,8 +22,34 @@ type IStore interface {
 	TX(ctx context.Context, fn func(ctx context.Context) error) error
 	User() UserStore
 	Post() PostStore
+	PostRevision() PostRevisionStore
+	Comment() CommentStore
+	Tag() TagStore
+	Category() CategoryStore
+	PostTag() PostTagStore
+	PostLike() PostLikeStore
+	Bookmark() BookmarkStore
+	Outbox() OutboxStore
 }
 
+// trashed 为回收站中记录的查询条件，即已被软删除的记录，需要与 Unscoped 一起使用.
+var trashed = clause.Neq{Column: clause.Column{Name: "deletedAt"}, Value: nil}
+
+// trashOrder 为回收站列表的排序，按 (deletedAt, id) 倒序.
+var trashOrder = clause.OrderBy{Columns: []clause.OrderByColumn{
+	{Column: clause.Column{Name: "deletedAt"}, Desc: true},
+	{Column: clause.Column{Name: "id"}, Desc: true},
+}}
+
+// ErrVersionConflict 表示记录已被其他请求修改，更新时的版本号与数据库中的版本号不一致.
+var ErrVersionConflict = errors.New("record version conflict")
+
+// defaultOrder 为列表查询的默认排序，按 (createdAt, id) 倒序，与 pagetoken 的游标分页保持一致.
+var defaultOrder = clause.OrderBy{Columns: []clause.OrderByColumn{
+	{Column: clause.Column{Name: "createdAt"}, Desc: true},
+	{Column: clause.Column{Name: "id"}, Desc: true},
+}}
+
 // transactionKey 用于在 context.Context 中存储事务上下文的键.
 type transactionKey struct{}
 
@@ -80,4 +108,44 @@ func (store *datastore) User() UserStore {
 // Posts 返回一个实现了 PostStore 接口的实例.
 func (store *datastore) Post() PostStore {
 	return newPostStore(store)
-}
\ No newline at end of file
+}
+
+// PostRevision 返回一个实现了 PostRevisionStore 接口的实例.
+func (store *datastore) PostRevision() PostRevisionStore {
+	return newPostRevisionStore(store)
+}
+
+// Comment 返回一个实现了 CommentStore 接口的实例.
+func (store *datastore) Comment() CommentStore {
+	return newCommentStore(store)
+}
+
+// Tag 返回一个实现了 TagStore 接口的实例.
+func (store *datastore) Tag() TagStore {
+	return newTagStore(store)
+}
+
+// Category 返回一个实现了 CategoryStore 接口的实例.
+func (store *datastore) Category() CategoryStore {
+	return newCategoryStore(store)
+}
+
+// PostTag 返回一个实现了 PostTagStore 接口的实例.
+func (store *datastore) PostTag() PostTagStore {
+	return newPostTagStore(store)
+}
+
+// PostLike 返回一个实现了 PostLikeStore 接口的实例.
+func (store *datastore) PostLike() PostLikeStore {
+	return newPostLikeStore(store)
+}
+
+// Bookmark 返回一个实现了 BookmarkStore 接口的实例.
+func (store *datastore) Bookmark() BookmarkStore {
+	return newBookmarkStore(store)
+}
+
+// Outbox 返回一个实现了 OutboxStore 接口的实例.
+func (store *datastore) Outbox() OutboxStore {
+	return newOutboxStore(store)
+}
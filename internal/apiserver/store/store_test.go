@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wshadm/miniblog/internal/apiserver/migration"
+	"github.com/wshadm/miniblog/pkg/options"
+)
+
+// newSQLiteStore 创建使用 SQLite 内存数据库的 datastore，并执行全部数据库迁移.
+func newSQLiteStore(tb testing.TB) *datastore {
+	tb.Helper()
+
+	opts := options.NewSQLiteOptions()
+	opts.Path = ":memory:"
+	db, err := opts.NewDB()
+	if err != nil {
+		tb.Fatalf("failed to open sqlite: %v", err)
+	}
+	tb.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	m, err := migration.New(db, options.DriverSQLite)
+	if err != nil {
+		tb.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := m.Up(context.Background()); err != nil {
+		tb.Fatalf("failed to migrate sqlite: %v", err)
+	}
+	// NewStore 返回全局单例，测试中每次都创建新的 datastore
+	return &datastore{core: db}
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagStore 定义了标签在 store 层所实现的方法.
+type TagStore interface {
+	Get(ctx context.Context, opts *where.Options) (*model.TagM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.TagM, error)
+	TagExpansion
+}
+
+// TagExpansion 定义了标签操作的附加方法.
+type TagExpansion interface {
+	// CreateIfNotExist 插入 objs 中不存在的标签，已存在的同名标签保持不变.
+	CreateIfNotExist(ctx context.Context, objs []*model.TagM) error
+	// ListPostCounts 返回有已发布博客的标签及其已发布博客数，按博客数倒序、标签名升序排列.
+	ListPostCounts(ctx context.Context, opts *where.Options) (int64, []*TagPostCount, error)
+}
+
+// TagPostCount 为标签及其已发布的博客数.
+type TagPostCount struct {
+	Name      string `gorm:"column:name"`
+	PostCount int64  `gorm:"column:postCount"`
+}
+
+// tagStore 是 TagStore 接口的实现.
+type tagStore struct {
+	store *datastore
+}
+
+// 确保 tagStore 实现了 TagStore 接口.
+var _ TagStore = (*tagStore)(nil)
+
+// newTagStore 创建 tagStore 的实例.
+func newTagStore(store *datastore) *tagStore {
+	return &tagStore{store: store}
+}
+
+// CreateIfNotExist 插入 objs 中不存在的标签，并发创建同名标签时不会返回错误.
+func (s *tagStore) CreateIfNotExist(ctx context.Context, objs []*model.TagM) error {
+	if len(objs) == 0 {
+		return nil
+	}
+	if err := s.store.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(objs).Error; err != nil {
+		log.Errorw("Failed to insert tags into database", "err", err, "tags", objs)
+		return err
+	}
+	return nil
+}
+
+// Get 根据条件查询标签记录.
+func (s *tagStore) Get(ctx context.Context, opts *where.Options) (*model.TagM, error) {
+	var obj model.TagM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorw("Failed to retrieve tag from database", "err", err, "conditions", opts)
+		}
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// List 返回标签列表和总数.
+func (s *tagStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.TagM, err error) {
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list tags from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
+
+// ListPostCounts 通过 post_tag 关联已发布且未被删除的博客，按标签分组统计博客数.
+// opts 中只有 Offset 和 Limit 生效.
+func (s *tagStore) ListPostCounts(ctx context.Context, opts *where.Options) (count int64, ret []*TagPostCount, err error) {
+	tag, postTag, post := model.TableNameTagM, model.TableNamePostTagM, model.TableNamePostM
+	db := s.store.DB(ctx).Model(&model.TagM{}).
+		Joins("JOIN ? ON ? = ?", clause.Table{Name: postTag},
+			clause.Column{Table: postTag, Name: "tagID"}, clause.Column{Table: tag, Name: "id"}).
+		Joins("JOIN ? ON ? = ?", clause.Table{Name: post},
+			clause.Column{Table: post, Name: "postID"}, clause.Column{Table: postTag, Name: "postID"}).
+		Where(clause.Eq{Column: clause.Column{Table: post, Name: "status"}, Value: model.PostStatusPublished}).
+		Where(clause.Eq{Column: clause.Column{Table: post, Name: "deletedAt"}, Value: nil}).
+		Session(&gorm.Session{})
+
+	if err = db.Distinct(tag + ".id").Count(&count).Error; err != nil {
+		log.Errorw("Failed to count tags from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	err = db.Select("?, COUNT(*) AS ?", clause.Column{Table: tag, Name: "name"}, clause.Column{Name: "postCount"}).
+		Group(tag + ".name").
+		Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: "postCount"}, Desc: true},
+			{Column: clause.Column{Table: tag, Name: "name"}},
+		}}).
+		Offset(opts.Offset).Limit(opts.Limit).Scan(&ret).Error
+	if err != nil {
+		log.Errorw("Failed to list tags from database", "err", err, "conditions", opts)
+		return 0, nil, err
+	}
+	return
+}
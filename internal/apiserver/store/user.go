@@ -3,11 +3,13 @@ package store
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/onexstack/onexstack/pkg/store/where"
 	"github.com/wshadm/miniblog/internal/apiserver/model"
 	"github.com/wshadm/miniblog/internal/pkg/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserStore 定义了 user 模块在 store 层所实现的方法.
@@ -21,7 +23,16 @@ type UserStore interface {
 }
 
 // UserExpansion 定义了用户操作的附加方法.
-type UserExpansion interface{}
+// Delete 只是软删除用户，被删除的用户在 Purge 之前仍然占用用户名和手机号.
+type UserExpansion interface {
+	CreateInBatches(ctx context.Context, objs []*model.UserM, batchSize int) error
+	UpdateColumns(ctx context.Context, obj *model.UserM, columns ...string) error
+	// Count 返回符合条件的用户总数，忽略 opts 中的分页参数.
+	Count(ctx context.Context, opts *where.Options) (int64, error)
+	// Exists 判断是否存在符合条件的用户，包括已被软删除的用户.
+	Exists(ctx context.Context, opts *where.Options) (bool, error)
+	Purge(ctx context.Context, opts *where.Options) (int64, error)
+}
 
 // userStore 是 UserStore 接口的实现.
 type userStore struct {
@@ -45,6 +56,15 @@ func (s *userStore) Create(ctx context.Context, obj *model.UserM) error {
 	return nil
 }
 
+// CreateInBatches 分批插入多条用户记录，每批只需要一次数据库写入.
+func (s *userStore) CreateInBatches(ctx context.Context, objs []*model.UserM, batchSize int) error {
+	if err := s.store.DB(ctx).CreateInBatches(objs, batchSize).Error; err != nil {
+		log.Errorw("Failed to batch insert users into database", "err", err, "count", len(objs))
+		return err
+	}
+	return nil
+}
+
 // Update 更新用户数据库记录.
 func (s *userStore) Update(ctx context.Context, obj *model.UserM) error {
 	//save：如果记录有主键，那就执行update。如果记录没有主键就执行inster
@@ -55,7 +75,26 @@ func (s *userStore) Update(ctx context.Context, obj *model.UserM) error {
 	return nil
 }
 
-// Delete 根据条件删除用户记录.
+// UpdateColumns 只更新用户记录的 columns 列，updatedAt 总是会被更新.
+// 使用乐观锁：只有数据库中的版本号与 obj.Version 一致时才会更新，更新后版本号加 1，否则返回 ErrVersionConflict.
+func (s *userStore) UpdateColumns(ctx context.Context, obj *model.UserM, columns ...string) error {
+	version := obj.Version
+	obj.Version++
+	result := s.store.DB(ctx).Model(obj).Where(clause.Eq{Column: clause.Column{Name: "version"}, Value: version}).
+		Select(append(slices.Clone(columns), "updatedAt", "version")).Updates(obj)
+	if result.Error != nil {
+		obj.Version = version
+		log.Errorw("Failed to update user columns in database", "err", result.Error, "user", obj, "columns", columns)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		obj.Version = version
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// Delete 根据条件软删除用户记录.
 func (s *userStore) Delete(ctx context.Context, opts *where.Options) error {
 	if err := s.store.DB(ctx, opts).Delete(new(model.UserM)).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		log.Errorw("Failed to delete user from database", "err", err, "conditions", opts)
@@ -64,19 +103,54 @@ func (s *userStore) Delete(ctx context.Context, opts *where.Options) error {
 	return nil
 }
 
+// Purge 永久删除满足条件的已删除用户记录，返回删除的记录数.
+func (s *userStore) Purge(ctx context.Context, opts *where.Options) (int64, error) {
+	result := s.store.DB(ctx, opts).Unscoped().Where(trashed).Delete(new(model.UserM))
+	if result.Error != nil {
+		log.Errorw("Failed to purge user from database", "err", result.Error, "conditions", opts)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
 // Get 根据条件查询用户记录.
 func (s *userStore) Get(ctx context.Context, opts *where.Options) (*model.UserM, error) {
 	var obj model.UserM
-	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil && errors.As(err, gorm.ErrRecordNotFound) {
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorw("Failed to retrieve user from database", "err", err, "conditions", opts)
+		}
 		return nil, err
 	}
 	return &obj, nil
 }
+
+// List 返回用户列表和总数.
 func (s *userStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.UserM, err error) {
-	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	err = s.store.DB(ctx, opts).Order(defaultOrder).Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
 	if err != nil {
 		log.Errorw("Failed to list users from database", "err", err, "conditions", opts)
 		return
 	}
 	return
 }
+
+// Count 返回符合条件的用户总数.
+func (s *userStore) Count(ctx context.Context, opts *where.Options) (count int64, err error) {
+	err = s.store.DB(ctx, opts).Model(&model.UserM{}).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to count users from database", "err", err, "conditions", opts)
+		return 0, err
+	}
+	return
+}
+
+// Exists 判断是否存在符合条件的用户，包括已被软删除的用户.
+func (s *userStore) Exists(ctx context.Context, opts *where.Options) (bool, error) {
+	var count int64
+	if err := s.store.DB(ctx, opts).Unscoped().Model(&model.UserM{}).Offset(-1).Limit(-1).Count(&count).Error; err != nil {
+		log.Errorw("Failed to check user existence in database", "err", err, "conditions", opts)
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,89 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"github.com/wshadm/miniblog/internal/pkg/server"
+	"gorm.io/gorm/clause"
+)
+
+// Purger 定期永久删除回收站中超过保留期限的博客，以及超过保留期限的已删除用户.
+// 多个实例同时运行时只是重复执行相同的删除，不需要额外的协调.
+type Purger struct {
+	store     store.IStore
+	retention time.Duration
+	interval  time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// 确保 Purger 实现了 server.Server 接口.
+var _ server.Server = (*Purger)(nil)
+
+// NewPurger 创建一个 Purger 实例，删除时间早于 retention 之前的记录会被永久删除.
+func NewPurger(store store.IStore, retention time.Duration, interval time.Duration) *Purger {
+	return &Purger{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// RunOrDie 每隔 interval 清理一次回收站，直到 GracefulStop 被调用.
+// 清理失败时只记录日志，等待下一次清理.
+func (p *Purger) RunOrDie() {
+	logger.L().Info().Dur("retention", p.retention).Dur("interval", p.interval).Msg("Start trash purger")
+	defer close(p.done)
+
+	for {
+		p.purge(context.Background())
+
+		select {
+		case <-p.stop:
+			return
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// GracefulStop 等待正在进行的清理完成后停止 purger.
+func (p *Purger) GracefulStop(ctx context.Context) {
+	logger.L().Info().Msg("Gracefully stop trash purger")
+	close(p.stop)
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		logger.L().Warn().Err(ctx.Err()).Msg("Trash purger did not stop in time")
+	}
+}
+
+// purge 永久删除超过保留期限的博客和用户.
+func (p *Purger) purge(ctx context.Context) {
+	before := time.Now().Add(-p.retention)
+
+	posts, err := p.store.Post().Purge(ctx, expired(before))
+	if err != nil {
+		logger.L().Warn().Err(err).Msg("Failed to purge deleted posts")
+	} else if posts > 0 {
+		logger.L().Info().Int64("count", posts).Time("before", before).Msg("Purged deleted posts")
+	}
+
+	users, err := p.store.User().Purge(ctx, expired(before))
+	if err != nil {
+		logger.L().Warn().Err(err).Msg("Failed to purge deleted users")
+	} else if users > 0 {
+		logger.L().Info().Int64("count", users).Time("before", before).Msg("Purged deleted users")
+	}
+}
+
+// expired 返回删除时间早于 before 的查询条件.
+// deletedAt 由 GORM 写入，在 SQLite 中与查询参数使用相同的时间格式，可以直接比较.
+func expired(before time.Time) *where.Options {
+	return where.NewWhere().C(clause.Lt{Column: clause.Column{Name: "deletedAt"}, Value: before})
+}
@@ -0,0 +1,138 @@
+// Package viewcount 缓冲博客的浏览次数，并由 Flusher 定期批量写入数据库，
+// 避免热门博客的每次浏览都更新一次 post 表.
+package viewcount
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKey 为 Redis 中保存未写入数据库的浏览次数的哈希表，字段为 postID.
+const redisKey = "miniblog:post:views"
+
+// drainScript 原子地读取并删除哈希表，多个实例同时取出时每次浏览只会被其中一个实例写入数据库.
+var drainScript = redis.NewScript(`
+local counts = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return counts
+`)
+
+// Buffer 缓冲尚未写入数据库的博客浏览次数.
+type Buffer interface {
+	// Incr 为博客增加一次浏览.
+	Incr(ctx context.Context, postID string) error
+	// Get 返回博客尚未写入数据库的浏览次数.
+	Get(ctx context.Context, postID string) (int64, error)
+	// Drain 取出并清空所有尚未写入数据库的浏览次数.
+	Drain(ctx context.Context) (map[string]int64, error)
+	// Add 将 counts 放回缓冲，用于写入数据库失败后重试.
+	Add(ctx context.Context, counts map[string]int64) error
+}
+
+// memoryBuffer 是保存在进程内存中的 Buffer，进程退出前由 Flusher 写入数据库.
+type memoryBuffer struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// 确保 memoryBuffer 实现了 Buffer 接口.
+var _ Buffer = (*memoryBuffer)(nil)
+
+// NewMemoryBuffer 创建一个内存 Buffer.
+func NewMemoryBuffer() Buffer {
+	return &memoryBuffer{counts: make(map[string]int64)}
+}
+
+// Incr 为博客增加一次浏览.
+func (b *memoryBuffer) Incr(ctx context.Context, postID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.counts[postID]++
+	return nil
+}
+
+// Get 返回博客尚未写入数据库的浏览次数.
+func (b *memoryBuffer) Get(ctx context.Context, postID string) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.counts[postID], nil
+}
+
+// Drain 取出并清空所有尚未写入数据库的浏览次数.
+func (b *memoryBuffer) Drain(ctx context.Context) (map[string]int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	counts := b.counts
+	b.counts = make(map[string]int64)
+	return counts, nil
+}
+
+// Add 将 counts 放回缓冲.
+func (b *memoryBuffer) Add(ctx context.Context, counts map[string]int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for postID, n := range counts {
+		b.counts[postID] += n
+	}
+	return nil
+}
+
+// redisBuffer 是保存在 Redis 中的 Buffer，多个实例共享同一份缓冲，实例重启不会丢失浏览次数.
+type redisBuffer struct {
+	rdb redis.Cmdable
+}
+
+// 确保 redisBuffer 实现了 Buffer 接口.
+var _ Buffer = (*redisBuffer)(nil)
+
+// NewRedisBuffer 创建一个使用 Redis 哈希表保存浏览次数的 Buffer.
+func NewRedisBuffer(rdb redis.Cmdable) Buffer {
+	return &redisBuffer{rdb: rdb}
+}
+
+// Incr 为博客增加一次浏览.
+func (b *redisBuffer) Incr(ctx context.Context, postID string) error {
+	return b.rdb.HIncrBy(ctx, redisKey, postID, 1).Err()
+}
+
+// Get 返回博客尚未写入数据库的浏览次数.
+func (b *redisBuffer) Get(ctx context.Context, postID string) (int64, error) {
+	n, err := b.rdb.HGet(ctx, redisKey, postID).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return n, err
+}
+
+// Drain 取出并清空所有尚未写入数据库的浏览次数.
+func (b *redisBuffer) Drain(ctx context.Context) (map[string]int64, error) {
+	values, err := drainScript.Run(ctx, b.rdb, []string{redisKey}).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		n, err := strconv.ParseInt(values[i+1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		counts[values[i]] = n
+	}
+	return counts, nil
+}
+
+// Add 将 counts 放回缓冲.
+func (b *redisBuffer) Add(ctx context.Context, counts map[string]int64) error {
+	_, err := b.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for postID, n := range counts {
+			pipe.HIncrBy(ctx, redisKey, postID, n)
+		}
+		return nil
+	})
+	return err
+}
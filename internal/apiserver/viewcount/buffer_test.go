@@ -0,0 +1,152 @@
+package viewcount
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestBuffers 返回内存 Buffer 和使用 miniredis 的 Redis Buffer.
+func newTestBuffers(t *testing.T) map[string]Buffer {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return map[string]Buffer{
+		"memory": NewMemoryBuffer(),
+		"redis":  NewRedisBuffer(rdb),
+	}
+}
+
+// incr 为 postID 增加 n 次浏览.
+func incr(t *testing.T, b Buffer, postID string, n int) {
+	t.Helper()
+
+	for range n {
+		if err := b.Incr(context.Background(), postID); err != nil {
+			t.Fatalf("Incr(%s): %v", postID, err)
+		}
+	}
+}
+
+// drain 取出 b 中的浏览次数.
+func drain(t *testing.T, b Buffer) map[string]int64 {
+	t.Helper()
+
+	counts, err := b.Drain(context.Background())
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	return counts
+}
+
+func TestBuffer(t *testing.T) {
+	for name, b := range newTestBuffers(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if counts := drain(t, b); len(counts) != 0 {
+				t.Errorf("Drain() of an empty buffer = %v, want empty", counts)
+			}
+			if n, err := b.Get(ctx, "post-1"); err != nil || n != 0 {
+				t.Errorf("Get() of an unknown post = %d, %v, want 0", n, err)
+			}
+
+			incr(t, b, "post-1", 3)
+			incr(t, b, "post-2", 1)
+			if n, err := b.Get(ctx, "post-1"); err != nil || n != 3 {
+				t.Errorf("Get(post-1) = %d, %v, want 3", n, err)
+			}
+
+			want := map[string]int64{"post-1": 3, "post-2": 1}
+			if counts := drain(t, b); !maps.Equal(counts, want) {
+				t.Errorf("Drain() = %v, want %v", counts, want)
+			}
+			// Drain 之后缓冲为空
+			if counts := drain(t, b); len(counts) != 0 {
+				t.Errorf("second Drain() = %v, want empty", counts)
+			}
+			if n, err := b.Get(ctx, "post-1"); err != nil || n != 0 {
+				t.Errorf("Get(post-1) after Drain() = %d, %v, want 0", n, err)
+			}
+
+			// 放回的浏览次数与新的浏览累加
+			incr(t, b, "post-1", 1)
+			if err := b.Add(ctx, want); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if err := b.Add(ctx, nil); err != nil {
+				t.Fatalf("Add(nil): %v", err)
+			}
+			want = map[string]int64{"post-1": 4, "post-2": 1}
+			if counts := drain(t, b); !maps.Equal(counts, want) {
+				t.Errorf("Drain() after Add() = %v, want %v", counts, want)
+			}
+		})
+	}
+}
+
+// TestBufferConcurrentDrain 检查与 Incr 并发执行的 Drain 不会丢失或重复计算浏览次数.
+func TestBufferConcurrentDrain(t *testing.T) {
+	for name, b := range newTestBuffers(t) {
+		t.Run(name, func(t *testing.T) {
+			const workers, views = 4, 200
+
+			var wg sync.WaitGroup
+			for range workers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range views {
+						if err := b.Incr(context.Background(), "post-1"); err != nil {
+							t.Errorf("Incr: %v", err)
+							return
+						}
+					}
+				}()
+			}
+
+			var (
+				total int64
+				done  = make(chan struct{})
+			)
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			for finished := false; !finished; {
+				select {
+				case <-done:
+					finished = true
+				default:
+				}
+				total += drain(t, b)["post-1"]
+			}
+			if total != workers*views {
+				t.Errorf("drained %d views, want %d", total, workers*views)
+			}
+		})
+	}
+}
+
+func TestRedisBufferDrainRemovesKey(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	b := NewRedisBuffer(rdb)
+
+	incr(t, b, "post-1", 2)
+	if got := mr.HGet(redisKey, "post-1"); got != "2" {
+		t.Fatalf("hash field post-1 = %q, want 2", got)
+	}
+	drain(t, b)
+	if mr.Exists(redisKey) {
+		t.Errorf("key %s should be removed by Drain()", redisKey)
+	}
+}
@@ -0,0 +1,105 @@
+package viewcount
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"github.com/wshadm/miniblog/internal/pkg/server"
+)
+
+// batchSize 为每个事务中写入的博客数量.
+const batchSize = 100
+
+// Flusher 定期将 Buffer 中的浏览次数批量累加到 post 表的 viewCount 列.
+// 累加使用 viewCount = viewCount + n，多个实例同时写入时不会丢失浏览次数.
+type Flusher struct {
+	buffer   Buffer
+	store    store.IStore
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// 确保 Flusher 实现了 server.Server 接口.
+var _ server.Server = (*Flusher)(nil)
+
+// NewFlusher 创建一个 Flusher 实例.
+func NewFlusher(buffer Buffer, store store.IStore, interval time.Duration) *Flusher {
+	return &Flusher{
+		buffer:   buffer,
+		store:    store,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// RunOrDie 每隔 interval 写入一次浏览次数，GracefulStop 被调用后再写入一次并退出.
+func (f *Flusher) RunOrDie() {
+	logger.L().Info().Dur("interval", f.interval).Msg("Start view count flusher")
+	defer close(f.done)
+
+	for {
+		select {
+		case <-f.stop:
+			f.flush(context.Background())
+			return
+		case <-time.After(f.interval):
+			f.flush(context.Background())
+		}
+	}
+}
+
+// GracefulStop 将缓冲中剩余的浏览次数写入数据库后停止 Flusher.
+func (f *Flusher) GracefulStop(ctx context.Context) {
+	logger.L().Info().Msg("Gracefully stop view count flusher")
+	close(f.stop)
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		logger.L().Warn().Err(ctx.Err()).Msg("View count flusher did not stop in time")
+	}
+}
+
+// flush 取出缓冲中的浏览次数，每 batchSize 篇博客在一个事务中写入.
+// 写入失败的浏览次数会被放回缓冲，等待下一次写入.
+func (f *Flusher) flush(ctx context.Context) {
+	counts, err := f.buffer.Drain(ctx)
+	if err != nil {
+		logger.L().Warn().Err(err).Msg("Failed to drain view counts")
+		return
+	}
+
+	// 按 postID 排序，多个实例同时写入时以相同的顺序加锁，避免死锁
+	ids := slices.Sorted(maps.Keys(counts))
+	var flushed int
+	for batch := range slices.Chunk(ids, batchSize) {
+		err := f.store.TX(ctx, func(ctx context.Context) error {
+			for _, postID := range batch {
+				if err := f.store.Post().Increment(ctx, postID, "viewCount", counts[postID]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.L().Warn().Err(err).Int("count", len(batch)).Msg("Failed to flush view counts")
+			failed := make(map[string]int64, len(batch))
+			for _, postID := range batch {
+				failed[postID] = counts[postID]
+			}
+			if err := f.buffer.Add(ctx, failed); err != nil {
+				logger.L().Error().Err(err).Int("count", len(batch)).Msg("Failed to return view counts to buffer, views are lost")
+			}
+			continue
+		}
+		flushed += len(batch)
+	}
+	if flushed > 0 {
+		logger.L().Debug().Int("count", flushed).Msg("Flushed view counts")
+	}
+}
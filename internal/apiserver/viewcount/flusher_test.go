@@ -0,0 +1,214 @@
+package viewcount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/wshadm/miniblog/internal/apiserver/model"
+	"github.com/wshadm/miniblog/internal/apiserver/store"
+)
+
+var errIncrement = errors.New("increment failed")
+
+// failingStore 统计事务的次数，为 fail 中的博客增加计数时返回错误.
+type failingStore struct {
+	store.IStore
+	fail map[string]bool
+	txs  atomic.Int64
+}
+
+func (s *failingStore) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.txs.Add(1)
+	return s.IStore.TX(ctx, fn)
+}
+
+func (s *failingStore) Post() store.PostStore {
+	return &failingPostStore{PostStore: s.IStore.Post(), store: s}
+}
+
+type failingPostStore struct {
+	store.PostStore
+	store *failingStore
+}
+
+func (s *failingPostStore) Increment(ctx context.Context, postID string, column string, delta int64) error {
+	if s.store.fail[postID] {
+		return errIncrement
+	}
+	return s.PostStore.Increment(ctx, postID, column, delta)
+}
+
+// newTestStore 创建内存存储，并写入 n 篇博客，返回博客 ID.
+func newTestStore(t *testing.T, n int) (*failingStore, []string) {
+	t.Helper()
+
+	s := &failingStore{IStore: store.NewMemoryStore()}
+	posts := make([]*model.PostM, 0, n)
+	for i := range n {
+		posts = append(posts, &model.PostM{UserID: "user-a", Title: fmt.Sprintf("post %d", i), Status: model.PostStatusPublished})
+	}
+	if err := s.Post().CreateInBatches(context.Background(), posts, n); err != nil {
+		t.Fatalf("CreateInBatches: %v", err)
+	}
+	ids := make([]string, 0, n)
+	for _, post := range posts {
+		ids = append(ids, post.PostID)
+	}
+	return s, ids
+}
+
+// viewCounts 返回数据库中 ids 的浏览数，浏览数为 0 的博客不在结果中.
+func viewCounts(t *testing.T, s store.IStore, ids []string) map[string]int64 {
+	t.Helper()
+
+	counts := make(map[string]int64)
+	for _, id := range ids {
+		post, err := s.Post().Get(context.Background(), where.F("postID", id))
+		if err != nil {
+			t.Fatalf("Get(%s): %v", id, err)
+		}
+		if post.ViewCount != 0 {
+			counts[id] = post.ViewCount
+		}
+	}
+	return counts
+}
+
+func TestFlush(t *testing.T) {
+	for name, b := range newTestBuffers(t) {
+		t.Run(name, func(t *testing.T) {
+			s, ids := newTestStore(t, 3)
+			incr(t, b, ids[0], 2)
+			incr(t, b, ids[2], 1)
+
+			f := NewFlusher(b, s, time.Hour)
+			f.flush(context.Background())
+			want := map[string]int64{ids[0]: 2, ids[2]: 1}
+			if got := viewCounts(t, s, ids); !maps.Equal(got, want) {
+				t.Errorf("viewCount after flush = %v, want %v", got, want)
+			}
+			if counts := drain(t, b); len(counts) != 0 {
+				t.Errorf("buffer after flush = %v, want empty", counts)
+			}
+
+			// 再次写入时累加到已有的浏览数上，缓冲为空时不开启事务
+			incr(t, b, ids[0], 1)
+			f.flush(context.Background())
+			want[ids[0]] = 3
+			if got := viewCounts(t, s, ids); !maps.Equal(got, want) {
+				t.Errorf("viewCount after second flush = %v, want %v", got, want)
+			}
+			txs := s.txs.Load()
+			f.flush(context.Background())
+			if s.txs.Load() != txs {
+				t.Errorf("flush of an empty buffer opened %d transactions, want 0", s.txs.Load()-txs)
+			}
+		})
+	}
+}
+
+// TestFlushReturnsFailedBatch 检查每 batchSize 篇博客在一个事务中写入，写入失败的批次整体回滚并放回缓冲.
+func TestFlushReturnsFailedBatch(t *testing.T) {
+	for name, b := range newTestBuffers(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			n := 2*batchSize + 1
+			s, ids := newTestStore(t, n)
+
+			counts := make(map[string]int64, n)
+			for i, id := range ids {
+				counts[id] = int64(i%3 + 1)
+			}
+			if err := b.Add(ctx, counts); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+
+			// 按 postID 排序后分批，让第二批中的最后一篇博客写入失败
+			sorted := slices.Sorted(slices.Values(ids))
+			failed := sorted[batchSize : 2*batchSize]
+			s.fail = map[string]bool{failed[len(failed)-1]: true}
+
+			f := NewFlusher(b, s, time.Hour)
+			f.flush(ctx)
+			if txs := s.txs.Load(); txs != 3 {
+				t.Errorf("flush opened %d transactions, want 3", txs)
+			}
+
+			// 失败批次中已执行的累加被回滚，浏览次数全部放回缓冲，其他批次正常写入
+			wantStored := maps.Clone(counts)
+			wantBuffered := make(map[string]int64, len(failed))
+			for _, id := range failed {
+				delete(wantStored, id)
+				wantBuffered[id] = counts[id]
+			}
+			if got := viewCounts(t, s, ids); !maps.Equal(got, wantStored) {
+				t.Errorf("viewCount after a failed batch has %d posts, want %d", len(got), len(wantStored))
+			}
+			buffered := drain(t, b)
+			if !maps.Equal(buffered, wantBuffered) {
+				t.Errorf("buffer after a failed batch has %d posts, want %d", len(buffered), len(wantBuffered))
+			}
+
+			// 恢复后重新写入，每次浏览只计算一次
+			if err := b.Add(ctx, buffered); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			s.fail = nil
+			f.flush(ctx)
+			if got := viewCounts(t, s, ids); !maps.Equal(got, counts) {
+				t.Errorf("viewCount after retry does not match the buffered views")
+			}
+		})
+	}
+}
+
+// TestFlusherFlushesOnStop 检查 GracefulStop 写入缓冲中剩余的浏览次数后再退出.
+func TestFlusherFlushesOnStop(t *testing.T) {
+	s, ids := newTestStore(t, 1)
+	b := NewMemoryBuffer()
+	f := NewFlusher(b, s, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f.RunOrDie()
+	}()
+	incr(t, b, ids[0], 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	f.GracefulStop(ctx)
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("RunOrDie did not return after GracefulStop")
+	}
+	if got := viewCounts(t, s, ids); got[ids[0]] != 2 {
+		t.Errorf("viewCount after GracefulStop = %d, want 2", got[ids[0]])
+	}
+}
+
+// TestFlusherFlushesPeriodically 检查 Flusher 每隔 interval 写入一次浏览次数.
+func TestFlusherFlushesPeriodically(t *testing.T) {
+	s, ids := newTestStore(t, 1)
+	b := NewMemoryBuffer()
+	f := NewFlusher(b, s, 10*time.Millisecond)
+	go f.RunOrDie()
+	defer f.GracefulStop(context.Background())
+
+	incr(t, b, ids[0], 1)
+	deadline := time.Now().Add(5 * time.Second)
+	for viewCounts(t, s, ids)[ids[0]] != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("views were not flushed before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,33 @@
+package authn
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cost 为 bcrypt 加密使用的计算代价，值越大越安全，但加密越慢.
+var cost = bcrypt.DefaultCost
+
+// SetCost 设置 bcrypt 的计算代价，超出 [bcrypt.MinCost, bcrypt.MaxCost] 范围的值会被忽略.
+// 应在服务启动时调用一次.
+func SetCost(c int) {
+	if c >= bcrypt.MinCost && c <= bcrypt.MaxCost {
+		cost = c
+	}
+}
+
+// Encrypt 使用 bcrypt 加密明文密码.
+func Encrypt(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	return string(hashed), err
+}
+
+// Compare 比较加密后的密码与明文密码是否匹配.
+func Compare(hashedPassword, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+}
+
+// IsEncrypted 判断 password 是否已经是 bcrypt 加密后的密码.
+func IsEncrypted(password string) bool {
+	_, err := bcrypt.Cost([]byte(password))
+	return err == nil
+}
@@ -0,0 +1,111 @@
+package authz
+
+import (
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/authz"
+	"gorm.io/gorm"
+)
+
+const (
+	// RoleAdmin 表示管理员角色，可以访问所有资源.
+	RoleAdmin = "role::admin"
+	// RoleUser 表示普通用户角色，新创建的用户默认属于该角色.
+	RoleUser = "role::user"
+	// Anonymous 表示未登录的匿名用户，只能访问允许匿名访问的接口.
+	Anonymous = "anonymous"
+)
+
+// aclModel 定义 Casbin 访问控制模型.
+// 请求必须命中至少一条 allow 策略，且没有命中任何 deny 策略才会被放行.
+// obj 使用 keyMatch 匹配，支持 * 通配；act 使用正则匹配.
+const aclModel = `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act, eft
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && regexMatch(r.act, p.act)`
+
+// defaultPolicies 是服务启动时写入 casbin_rule 表的默认策略.
+// gRPC 请求的 obj 为 FullMethod、act 为 CALL；Gin 请求的 obj 为 URL 路径、act 为 HTTP 方法.
+// 普通用户只能访问用户和博客相关接口，博客和用户数据的归属由业务层进一步限定.
+// 匿名用户只能读取和检索博客及其评论和标签，业务层只返回已发布的博客.
+// 需要将某个用户设置为管理员时，在 casbin_rule 中添加 `g, <userID>, role::admin` 并移除其 role::user 即可，
+// 策略会被定时重新加载，无需重启服务.
+var defaultPolicies = [][]string{
+	{RoleAdmin, "*", ".*", "allow"},
+
+	{RoleUser, "/v1.MiniBlog/*", "CALL", "allow"},
+	{RoleUser, "/v1.MiniBlog/ListUsers", "CALL", "deny"},
+
+	{RoleUser, "/refresh-token", "PUT", "allow"},
+	{RoleUser, "/v1/users/*", "GET|PUT|DELETE", "allow"},
+	{RoleUser, "/v1/posts*", "GET|POST|PUT|DELETE", "allow"},
+	{RoleUser, "/v1/trash/posts*", "GET|PUT|DELETE", "allow"},
+	{RoleUser, "/v1/tags", "GET", "allow"},
+	{RoleUser, "/v1/search/posts", "GET", "allow"},
+	{RoleUser, "/v1/bookmarks", "GET", "allow"},
+
+	{Anonymous, "/v1.MiniBlog/GetPost", "CALL", "allow"},
+	{Anonymous, "/v1.MiniBlog/ListPost", "CALL", "allow"},
+	{Anonymous, "/v1.MiniBlog/ListComments", "CALL", "allow"},
+	{Anonymous, "/v1.MiniBlog/ListTags", "CALL", "allow"},
+	{Anonymous, "/v1.MiniBlog/SearchPosts", "CALL", "allow"},
+	{Anonymous, "/v1/posts", "GET", "allow"},
+	{Anonymous, "/v1/posts/*", "GET", "allow"},
+	{Anonymous, "/v1/tags", "GET", "allow"},
+	{Anonymous, "/v1/search/posts", "GET", "allow"},
+}
+
+// Authz 是基于 Casbin 的授权器，策略保存在 casbin_rule 表中.
+type Authz struct {
+	*authz.Authz
+}
+
+// NewAuthz 创建授权器，写入默认策略，并每隔 reloadInterval 从数据库重新加载策略.
+func NewAuthz(db *gorm.DB, reloadInterval time.Duration) (*Authz, error) {
+	a, err := authz.NewAuthz(db, authz.WithAclModel(aclModel), authz.WithAutoLoadPolicyTime(reloadInterval))
+	if err != nil {
+		return nil, err
+	}
+
+	// 已存在的策略会被跳过
+	if _, err := a.AddPoliciesEx(defaultPolicies); err != nil {
+		return nil, err
+	}
+	return &Authz{a}, nil
+}
+
+// Authorize 判断 subject 是否有权对 object 执行 action，subject 为空时按匿名用户判断.
+func (a *Authz) Authorize(subject, object, action string) (bool, error) {
+	if subject == "" {
+		subject = Anonymous
+	}
+	return a.Authz.Authorize(subject, object, action)
+}
+
+// IsAdmin 判断用户是否拥有管理员角色.
+func (a *Authz) IsAdmin(userID string) bool {
+	ok, _ := a.HasRoleForUser(userID, RoleAdmin)
+	return ok
+}
+
+// AddUser 为新用户授予普通用户角色.
+func (a *Authz) AddUser(userID string) error {
+	_, err := a.AddGroupingPolicy(userID, RoleUser)
+	return err
+}
+
+// RemoveUser 移除用户的所有角色.
+func (a *Authz) RemoveUser(userID string) error {
+	_, err := a.RemoveFilteredGroupingPolicy(0, userID)
+	return err
+}
@@ -17,17 +17,17 @@ func WithUserID(ctx context.Context, userID string) context.Context {
 
 // UserID从上下文中提取用户ID
 func UserID(ctx context.Context) string {
-	userID := ctx.Value(userIDKey{}).(string)
+	userID, _ := ctx.Value(userIDKey{}).(string)
 	return userID
 }
 
-//WithRquestID将请求ID存放到上下文中
+// WithRquestID将请求ID存放到上下文中
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, requestIDKey{}, requestID)
 }
 
-//RequestID从上下文中提取请求
+// RequestID从上下文中提取请求
 func RequestID(ctx context.Context) string {
-	requestID := ctx.Value(requestIDKey{}).(string)
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
 	return requestID
 }
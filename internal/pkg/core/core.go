@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/etag"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// 与 grpc-gateway 保持一致的 JSON 编解码选项，保证不同服务器模式返回相同的数据格式.
+var (
+	marshalOptions   = protojson.MarshalOptions{UseEnumNumbers: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Handler 是业务处理函数的类型，通常为 biz 层的方法.
+type Handler[T any, R proto.Message] func(ctx context.Context, rq *T) (R, error)
+
+// HandleRequest 是 Gin 处理 API 请求的通用函数.
+// 请求参数的绑定规则与 grpc-gateway 一致：
+// 请求体按 protojson 解析，路径参数覆盖同名字段，没有请求体时从查询参数中读取字段.
+// 成功时返回 protojson 格式的响应，失败时返回 errorsx.ErrorX 格式的错误.
+func HandleRequest[T any, PT interface {
+	*T
+	proto.Message
+}, R proto.Message](c *gin.Context, handler Handler[T, R]) {
+	rq := PT(new(T))
+	if err := ReadRequest(c, rq); err != nil {
+		WriteResponse(c, nil, err)
+		return
+	}
+
+	rp, err := handler(c.Request.Context(), (*T)(rq))
+	if err != nil {
+		WriteResponse(c, nil, err)
+		return
+	}
+	WriteResponse(c, rp, nil)
+}
+
+// ReadRequest 将 HTTP 请求中的请求体、路径参数、查询参数和 If-Match 头绑定到 rq.
+func ReadRequest(c *gin.Context, rq proto.Message) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return errorsx.ErrBind.WithMessage("%s", err.Error())
+	}
+
+	if len(body) > 0 {
+		if err := unmarshalOptions.Unmarshal(body, rq); err != nil {
+			return errorsx.ErrBind.WithMessage("%s", err.Error())
+		}
+	} else if err := runtime.PopulateQueryParameters(rq, c.Request.URL.Query(), &utilities.DoubleArray{}); err != nil {
+		return errorsx.ErrBind.WithMessage("%s", err.Error())
+	}
+
+	for _, param := range c.Params {
+		if err := runtime.PopulateFieldFromPath(rq, param.Key, param.Value); err != nil {
+			return errorsx.ErrBind.WithMessage("%s", err.Error())
+		}
+	}
+
+	// If-Match 头等价于请求中的 etag 字段
+	etag.SetIfMatch(rq, c.GetHeader(etag.HeaderIfMatch))
+	return nil
+}
+
+// WriteResponse 将处理结果写入 HTTP 响应.
+// 发生错误时，返回体为 errorsx.ErrorX，HTTP 状态码为 ErrorX.Code.
+func WriteResponse(c *gin.Context, rp proto.Message, err error) {
+	if err != nil {
+		errx := errorsx.FromError(err)
+		if requestID := contextx.RequestID(c.Request.Context()); requestID != "" {
+			errx = errx.WithRequestID(requestID)
+		}
+		WriteError(c.Writer, errx)
+		return
+	}
+
+	data, err := marshalOptions.Marshal(rp)
+	if err != nil {
+		WriteError(c.Writer, errorsx.ErrInternal.WithMessage("%s", err.Error()))
+		return
+	}
+	c.Data(http.StatusOK, "application/json", data)
+}
+
+// WriteError 以 JSON 格式将 errorsx.ErrorX 写入 w.
+// Gin 和 grpc-gateway 均使用该函数返回错误，保证错误格式一致.
+func WriteError(w http.ResponseWriter, errx *errorsx.ErrorX) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errx.Code)
+	_ = json.NewEncoder(w).Encode(errx)
+}
@@ -0,0 +1,27 @@
+package errno
+
+import (
+	"net/http"
+
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrDBRead 表示数据库读取失败.
+	ErrDBRead = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBRead", Message: "Database read failure."}
+
+	// ErrDBWrite 表示数据库写入失败.
+	ErrDBWrite = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBWrite", Message: "Database write failure."}
+
+	// ErrSignToken 表示签发 JWT Token 时出错.
+	ErrSignToken = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.SignToken", Message: "Error occurred while signing the JSON web token."}
+
+	// ErrEtagMismatch 表示请求中的 etag 与资源当前的 etag 不一致，资源已被其他请求修改.
+	ErrEtagMismatch = &errorsx.ErrorX{Code: http.StatusPreconditionFailed, Reason: "Aborted.EtagMismatch", Message: "The resource has been modified, please reload and try again."}
+
+	// ErrPageTokenInvalid 表示分页令牌无效，或与当前的查询条件不一致.
+	ErrPageTokenInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.PageTokenInvalid", Message: "Page token is invalid."}
+
+	// ErrTokenInvalid 表示 JWT Token 格式无效或已过期.
+	ErrTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenInvalid", Message: "Token was invalid."}
+)
@@ -0,0 +1,10 @@
+package errno
+
+import (
+	"net/http"
+
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+// ErrCommentNotFound 表示未找到指定评论.
+var ErrCommentNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.CommentNotFound", Message: "Comment not found."}
@@ -0,0 +1,13 @@
+package errno
+
+import (
+	"net/http"
+
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+// ErrPostNotFound 表示未找到指定博客.
+var ErrPostNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PostNotFound", Message: "Post not found."}
+
+// ErrPostRevisionNotFound 表示未找到博客的指定修订.
+var ErrPostRevisionNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PostRevisionNotFound", Message: "Post revision not found."}
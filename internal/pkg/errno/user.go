@@ -0,0 +1,18 @@
+package errno
+
+import (
+	"net/http"
+
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrUserAlreadyExists 表示用户已存在.
+	ErrUserAlreadyExists = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "AlreadyExist.UserAlreadyExists", Message: "User already exists."}
+
+	// ErrUserNotFound 表示未找到指定用户.
+	ErrUserNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.UserNotFound", Message: "User not found."}
+
+	// ErrPasswordInvalid 表示用户名或密码不正确.
+	ErrPasswordInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.PasswordInvalid", Message: "Password is incorrect."}
+)
@@ -0,0 +1,57 @@
+// Package etag 实现基于版本号的 ETag，用于乐观并发控制.
+// 资源每次更新版本号加 1，客户端通过请求中的 etag 字段或 HTTP If-Match 头携带读取时的 etag，
+// 与当前版本不一致时说明资源已被其他请求修改.
+package etag
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// HeaderIfMatch 为 HTTP If-Match 请求头.
+	HeaderIfMatch = "If-Match"
+
+	// fieldName 为请求消息中 etag 字段的名称.
+	fieldName = "etag"
+)
+
+// Format 返回版本号对应的 etag.
+func Format(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// Match 判断 etag 与版本号是否一致. etag 也可以是 If-Match 头的格式，
+// 例如 "3"、W/"3"、*，或以逗号分隔的多个 etag，其中任意一个一致即可.
+func Match(etag string, version int64) bool {
+	want := Format(version)
+	for tag := range strings.SplitSeq(etag, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		tag = strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+		if tag == want {
+			return true
+		}
+	}
+	return false
+}
+
+// SetIfMatch 将 If-Match 头的值设置到 msg 的 etag 字段中.
+// msg 没有 etag 字段或者已经设置了 etag 时不做处理，即请求体中的 etag 优先.
+func SetIfMatch(msg proto.Message, ifMatch string) {
+	if ifMatch == "" {
+		return
+	}
+
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(fieldName)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() || m.Get(fd).String() != "" {
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfString(ifMatch))
+}
@@ -0,0 +1,82 @@
+package etag
+
+import (
+	"testing"
+
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		version int64
+		want    string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{1234567890123, "1234567890123"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.version); got != tt.want {
+			t.Errorf("Format(%d) = %q, want %q", tt.version, got, tt.want)
+		}
+		// Format 的结果总是与同一个版本号匹配
+		if !Match(Format(tt.version), tt.version) {
+			t.Errorf("Match(Format(%d), %d) = false, want true", tt.version, tt.version)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		etag string
+		want bool
+	}{
+		{"unquoted", "3", true},
+		{"quoted", `"3"`, true},
+		{"weak", `W/"3"`, true},
+		{"weak unquoted", "W/3", true},
+		{"spaces", `  "3" `, true},
+		{"wildcard", "*", true},
+		{"list", `"1", W/"2", "3"`, true},
+		{"list without match", `"1", W/"2"`, false},
+		{"other version", "4", false},
+		{"quoted other version", `"4"`, false},
+		{"weak other version", `W/"4"`, false},
+		{"prefix of version", "33", false},
+		{"lowercase weak prefix", `w/"3"`, false},
+		{"empty", "", false},
+		{"empty quotes", `""`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.etag, 3); got != tt.want {
+				t.Errorf("Match(%q, 3) = %v, want %v", tt.etag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     proto.Message
+		ifMatch string
+		want    proto.Message
+	}{
+		{"set", &apiv1.UpdatePostRequest{}, `"3"`, &apiv1.UpdatePostRequest{Etag: `"3"`}},
+		// 请求体中的 etag 优先
+		{"keep request etag", &apiv1.UpdatePostRequest{Etag: "2"}, `"3"`, &apiv1.UpdatePostRequest{Etag: "2"}},
+		{"empty header", &apiv1.UpdatePostRequest{}, "", &apiv1.UpdatePostRequest{}},
+		{"no etag field", &apiv1.GetPostRequest{PostID: "post-1"}, `"3"`, &apiv1.GetPostRequest{PostID: "post-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetIfMatch(tt.msg, tt.ifMatch)
+			if !proto.Equal(tt.msg, tt.want) {
+				t.Errorf("SetIfMatch(%q) = %v, want %v", tt.ifMatch, tt.msg, tt.want)
+			}
+		})
+	}
+}
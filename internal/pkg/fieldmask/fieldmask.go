@@ -0,0 +1,91 @@
+// Package fieldmask 根据字段白名单解析 google.protobuf.FieldMask，
+// 用于部分更新（update mask）和稀疏读取（read mask）.
+package fieldmask
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gorm.io/gorm/clause"
+)
+
+// Fields 为允许出现在字段掩码中的字段，键为 API 中的字段名，值为数据库列名.
+// 列名为空表示该字段没有对应的列.
+type Fields map[string]string
+
+// Paths 校验 mask 并返回其中的字段名，mask 为空时返回 nil.
+// protojson 会将 JSON 中的 createdAt 转换为 created_at，因此匹配字段名时忽略大小写和下划线.
+func (fs Fields) Paths(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, nil
+	}
+
+	names := make(map[string]string, len(fs))
+	for name := range fs {
+		names[normalize(name)] = name
+	}
+
+	paths := make([]string, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		name, ok := names[normalize(path)]
+		if !ok {
+			return nil, fmt.Errorf("field mask: unknown field %q", strings.TrimSpace(path))
+		}
+		if !slices.Contains(paths, name) {
+			paths = append(paths, name)
+		}
+	}
+	return paths, nil
+}
+
+// Select 返回只查询 paths 对应列的子句，required 为总是需要查询的列. paths 为空时返回空切片.
+func (fs Fields) Select(paths []string, required ...string) []clause.Expression {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	columns := slices.Clone(required)
+	for _, path := range paths {
+		if column := fs[path]; column != "" && !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	sel := clause.Select{Columns: make([]clause.Column, 0, len(columns))}
+	for _, column := range columns {
+		sel.Columns = append(sel.Columns, clause.Column{Name: column})
+	}
+	return []clause.Expression{sel}
+}
+
+// Has 判断 name 是否需要处理. paths 为空时返回 fallback，例如更新时以字段是否显式设置为准.
+func Has(paths []string, name string, fallback bool) bool {
+	if len(paths) == 0 {
+		return fallback
+	}
+	return slices.Contains(paths, name)
+}
+
+// Prune 清除 msg 中不在 paths 中的字段，paths 为空时不做处理.
+func Prune(msg proto.Message, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	m := msg.ProtoReflect()
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !slices.Contains(paths, string(fd.Name())) {
+			m.Clear(fd)
+		}
+		return true
+	})
+}
+
+// normalize 返回忽略大小写和下划线后的字段名.
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", ""))
+}
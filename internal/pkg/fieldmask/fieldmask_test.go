@@ -0,0 +1,142 @@
+package fieldmask
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/wshadm/miniblog/pkg/api/apiserver/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm/clause"
+)
+
+var testFields = Fields{
+	"postID":    "postID",
+	"title":     "title",
+	"createdAt": "createdAt",
+	"etag":      "version",
+	"tags":      "",
+}
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		want    []string
+		wantErr string
+	}{
+		{"empty mask", nil, nil, ""},
+		{"fields", []string{"title", "tags"}, []string{"title", "tags"}, ""},
+		// protojson 将 JSON 中的 createdAt 转换为 created_at
+		{"snake case", []string{"created_at"}, []string{"createdAt"}, ""},
+		{"case and spaces", []string{" CreatedAt ", "ETAG"}, []string{"createdAt", "etag"}, ""},
+		{"duplicate", []string{"title", "title", "TITLE"}, []string{"title"}, ""},
+		{"unknown field", []string{"title", "author"}, nil, `unknown field "author"`},
+		{"nested path", []string{"tags.name"}, nil, `unknown field "tags.name"`},
+		{"nested path of known field", []string{"title.length"}, nil, `unknown field "title.length"`},
+		{"empty path", []string{""}, nil, `unknown field ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mask *fieldmaskpb.FieldMask
+			if tt.paths != nil {
+				mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			got, err := testFields.Paths(mask)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Paths(%q) error = %v, want %q", tt.paths, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("Paths(%q) = %q, %v, want %q", tt.paths, got, err, tt.want)
+			}
+		})
+	}
+
+	// 空的 mask 与未设置 mask 相同
+	if got, err := testFields.Paths(&fieldmaskpb.FieldMask{}); got != nil || err != nil {
+		t.Errorf("Paths(empty mask) = %q, %v, want nil", got, err)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	if got := testFields.Select(nil, "id"); got != nil {
+		t.Errorf("Select(nil) = %v, want nil", got)
+	}
+
+	got := testFields.Select([]string{"title", "tags", "etag", "postID"}, "id", "postID")
+	want := []clause.Column{{Name: "id"}, {Name: "postID"}, {Name: "title"}, {Name: "version"}}
+	if len(got) != 1 {
+		t.Fatalf("Select() = %v, want one clause", got)
+	}
+	if sel, ok := got[0].(clause.Select); !ok || !slices.Equal(sel.Columns, want) {
+		t.Errorf("Select() = %v, want columns %v", got[0], want)
+	}
+}
+
+func TestHas(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    []string
+		field    string
+		fallback bool
+		want     bool
+	}{
+		// 没有设置 mask 时以 fallback 为准，例如字段是否被显式设置
+		{"empty mask with set field", nil, "title", true, true},
+		{"empty mask with unset field", nil, "title", false, false},
+		{"in mask", []string{"title", "tags"}, "tags", false, true},
+		// 设置了 mask 时忽略 fallback
+		{"not in mask", []string{"title"}, "tags", true, false},
+		{"unknown field", []string{"title"}, "author", true, false},
+		{"nested path is not the field", []string{"tags.name"}, "tags", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Has(tt.paths, tt.field, tt.fallback); got != tt.want {
+				t.Errorf("Has(%q, %q, %v) = %v, want %v", tt.paths, tt.field, tt.fallback, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	newPost := func() *apiv1.Post {
+		return &apiv1.Post{
+			PostID:    "post-1",
+			Title:     "title",
+			Content:   "content",
+			CreatedAt: timestamppb.Now(),
+			Tags:      []string{"go"},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  *apiv1.Post
+	}{
+		{"empty mask keeps all fields", nil, newPost()},
+		{"scalar fields", []string{"postID", "title"}, &apiv1.Post{PostID: "post-1", Title: "title"}},
+		{"message and repeated fields", []string{"createdAt", "tags"}, &apiv1.Post{CreatedAt: &timestamppb.Timestamp{}, Tags: []string{"go"}}},
+		{"unset field in mask", []string{"title", "category"}, &apiv1.Post{Title: "title"}},
+		{"unknown field clears all fields", []string{"author"}, &apiv1.Post{}},
+		{"nested path does not keep the field", []string{"tags.name"}, &apiv1.Post{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newPost()
+			if tt.want.CreatedAt != nil {
+				tt.want.CreatedAt = got.CreatedAt
+			}
+			Prune(got, tt.paths)
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Prune(%q) = %v, want %v", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+// Package filtering 解析 AIP-160 风格的过滤表达式和 order_by 排序表达式，
+// 并根据字段白名单将其转换为 GORM 查询条件.
+//
+// 支持的过滤语法（关键字区分大小写）:
+//
+//	title:"go" AND createdAt > "2026-01-01"
+//	(title = "a" OR title = "b") NOT userID = "user-xxx"
+//
+// 比较运算符为 =、!=、<、<=、>、>= 和 :，其中 : 对字符串字段表示包含，值中的 % 和 _ 按普通字符匹配.
+// 多个条件之间以空格分隔时等价于 AND，OR 的优先级高于 AND.
+// 包含空格或运算符的值（例如 RFC3339 时间）需要使用双引号.
+package filtering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// maxLength 为过滤表达式的最大长度.
+	maxLength = 1024
+	// maxDepth 为过滤表达式的最大嵌套层数.
+	maxDepth = 32
+)
+
+// Type 为字段的值类型，决定了过滤值的解析方式和支持的运算符.
+type Type int
+
+const (
+	// String 为字符串字段，支持全部运算符.
+	String Type = iota
+	// Int 为整数字段，: 等价于 =.
+	Int
+	// Time 为时间字段，不支持 :.
+	Time
+)
+
+// Field 描述一个允许过滤和排序的字段.
+type Field struct {
+	// Column 为数据库列名.
+	Column string
+	// Type 为字段的值类型.
+	Type Type
+}
+
+// Fields 为字段白名单，键为 API 中的字段名.
+type Fields map[string]Field
+
+// Filter 解析过滤表达式并返回对应的查询条件，表达式为空时返回空切片.
+// 引用了白名单之外的字段或语法错误时返回错误.
+func (fs Fields) Filter(filter string) ([]clause.Expression, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	if len(filter) > maxLength {
+		return nil, fmt.Errorf("filter must be at most %d characters", maxLength)
+	}
+
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{fields: fs, tokens: tokens}
+	expr, err := p.expression(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("filter: unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return []clause.Expression{expr}, nil
+}
+
+// OrderBy 解析排序表达式，例如 "title, createdAt desc"，表达式为空时返回空切片.
+func (fs Fields) OrderBy(orderBy string) ([]clause.Expression, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+
+	var columns []clause.OrderByColumn
+	seen := make(map[string]bool)
+	for part := range strings.SplitSeq(orderBy, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("order_by: invalid expression %q", strings.TrimSpace(part))
+		}
+
+		field, ok := fs[words[0]]
+		if !ok {
+			return nil, fmt.Errorf("order_by: unknown field %q", words[0])
+		}
+		if seen[words[0]] {
+			return nil, fmt.Errorf("order_by: duplicate field %q", words[0])
+		}
+		seen[words[0]] = true
+
+		desc := false
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				desc = true
+			default:
+				return nil, fmt.Errorf("order_by: invalid direction %q", words[1])
+			}
+		}
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: desc})
+	}
+	return []clause.Expression{clause.OrderBy{Columns: columns}}, nil
+}
+
+// parser 是过滤表达式的递归下降解析器，文法为:
+//
+//	expression  = sequence { "AND" sequence }
+//	sequence    = factor { factor }
+//	factor      = term { "OR" term }
+//	term        = [ "NOT" ] simple
+//	simple      = restriction | "(" expression ")"
+//	restriction = field comparator value
+type parser struct {
+	fields Fields
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword 判断下一个 token 是否为关键字 kw.
+func (p *parser) keyword(kw string) bool {
+	tok := p.peek()
+	return tok.kind == tokenText && tok.text == kw
+}
+
+func (p *parser) expression(depth int) (clause.Expression, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("filter: expression is nested too deeply")
+	}
+
+	exprs, err := p.list(depth, "AND", p.sequence)
+	if err != nil {
+		return nil, err
+	}
+	return clause.And(exprs...), nil
+}
+
+func (p *parser) sequence(depth int) (clause.Expression, error) {
+	var exprs []clause.Expression
+	for {
+		expr, err := p.factor(depth)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+
+		// 以空格分隔的条件等价于 AND
+		tok := p.peek()
+		if tok.kind == tokenEOF || tok.kind == tokenRParen || p.keyword("AND") {
+			return clause.And(exprs...), nil
+		}
+	}
+}
+
+func (p *parser) factor(depth int) (clause.Expression, error) {
+	exprs, err := p.list(depth, "OR", p.term)
+	if err != nil {
+		return nil, err
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return clause.Or(exprs...), nil
+}
+
+// list 解析以关键字 sep 分隔的一组子表达式.
+func (p *parser) list(depth int, sep string, parse func(depth int) (clause.Expression, error)) ([]clause.Expression, error) {
+	var exprs []clause.Expression
+	for {
+		expr, err := parse(depth)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+
+		if !p.keyword(sep) {
+			return exprs, nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) term(depth int) (clause.Expression, error) {
+	if !p.keyword("NOT") {
+		return p.simple(depth)
+	}
+	p.next()
+	expr, err := p.simple(depth)
+	if err != nil {
+		return nil, err
+	}
+	return negation{expr: expr}, nil
+}
+
+func (p *parser) simple(depth int) (clause.Expression, error) {
+	if p.peek().kind != tokenLParen {
+		return p.restriction()
+	}
+	p.next()
+	expr, err := p.expression(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok.kind != tokenRParen {
+		return nil, fmt.Errorf("filter: expected ')' at position %d", tok.pos)
+	}
+	return expr, nil
+}
+
+func (p *parser) restriction() (clause.Expression, error) {
+	name := p.next()
+	if name.kind != tokenText || name.text == "AND" || name.text == "OR" || name.text == "NOT" {
+		return nil, fmt.Errorf("filter: expected field name at position %d", name.pos)
+	}
+	field, ok := p.fields[name.text]
+	if !ok {
+		return nil, fmt.Errorf("filter: unknown field %q", name.text)
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator {
+		return nil, fmt.Errorf("filter: expected comparator after %q", name.text)
+	}
+	value := p.next()
+	if value.kind != tokenText && value.kind != tokenString {
+		return nil, fmt.Errorf("filter: expected value after %q %s", name.text, op.text)
+	}
+
+	return condition(name.text, field, op.text, value.text)
+}
+
+// condition 根据字段类型将比较条件转换为查询条件.
+func condition(name string, field Field, op string, value string) (clause.Expression, error) {
+	column := clause.Column{Name: field.Column}
+
+	switch field.Type {
+	case String:
+		if op == ":" {
+			return Contains(field.Column, value), nil
+		}
+		return compare(column, op, value), nil
+	case Int:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("filter: field %q requires an integer value", name)
+		}
+		if op == ":" {
+			op = "="
+		}
+		return compare(column, op, v), nil
+	case Time:
+		t, err := parseTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("filter: field %q requires a time value such as \"2026-01-02\" or RFC 3339", name)
+		}
+		if op == ":" {
+			return nil, fmt.Errorf("filter: operator ':' is not supported on field %q", name)
+		}
+		return timeCondition{column: column, op: op, value: t}, nil
+	default:
+		return nil, fmt.Errorf("filter: unsupported type of field %q", name)
+	}
+}
+
+// compare 返回 column op value 的查询条件.
+func compare(column clause.Column, op string, value any) clause.Expression {
+	switch op {
+	case "!=":
+		return clause.Neq{Column: column, Value: value}
+	case "<":
+		return clause.Lt{Column: column, Value: value}
+	case "<=":
+		return clause.Lte{Column: column, Value: value}
+	case ">":
+		return clause.Gt{Column: column, Value: value}
+	case ">=":
+		return clause.Gte{Column: column, Value: value}
+	default:
+		return clause.Eq{Column: column, Value: value}
+	}
+}
+
+// parseTime 解析 RFC 3339、"2006-01-02 15:04:05" 和 "2006-01-02" 格式的时间，没有时区时按 UTC 处理.
+func parseTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// negation 是 NOT 条件. GORM 的 clause.Not 会对 AND 条件中的每个子条件分别取反，
+// 这与 NOT (a AND b) 的语义不一致，因此总是生成 NOT (...).
+type negation struct {
+	expr clause.Expression
+}
+
+// Build 实现 clause.Expression 接口.
+func (n negation) Build(builder clause.Builder) {
+	builder.WriteString("NOT (")
+	n.expr.Build(builder)
+	builder.WriteByte(')')
+}
+
+// Unwrap 返回与数据库无关的查询条件，供不生成 SQL 的存储实现使用.
+func (n negation) Unwrap() clause.Expression {
+	return clause.Not(n.expr)
+}
+
+// timeCondition 是时间字段的比较条件，在生成 SQL 时根据数据库类型决定时间参数的格式.
+type timeCondition struct {
+	column clause.Column
+	op     string
+	value  time.Time
+}
+
+// Build 实现 clause.Expression 接口.
+func (c timeCondition) Build(builder clause.Builder) {
+	// SQLite 以文本保存时间并按字符串比较，默认值 CURRENT_TIMESTAMP 的格式为 "2006-01-02 15:04:05"，
+	// 而驱动写入的时间带有纳秒和时区，因此比较前将字段和参数都转换为相同的 UTC 格式
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.Dialector.Name() == "sqlite" {
+		compare(SQLiteTime(stmt, c.column.Name), c.op, c.value.UTC().Format(SQLiteTimeLayout)).Build(builder)
+		return
+	}
+	compare(c.column, c.op, c.value).Build(builder)
+}
+
+// Unwrap 返回与数据库无关的查询条件，供不生成 SQL 的存储实现使用.
+func (c timeCondition) Unwrap() clause.Expression {
+	return compare(c.column, c.op, c.value)
+}
+
+// LikeEscape 是 Like 条件的转义字符. 不使用 \，因为在 MySQL 的字符串字面量中 \ 本身也需要转义.
+const LikeEscape = '!'
+
+// likeReplacer 转义 LIKE 模式中的通配符和转义字符.
+var likeReplacer = strings.NewReplacer(string(LikeEscape), string(LikeEscape)+string(LikeEscape),
+	"%", string(LikeEscape)+"%", "_", string(LikeEscape)+"_")
+
+// Like 是使用 LikeEscape 作为转义字符的 LIKE 条件，Value 中的 !%、!_ 和 !! 分别匹配 %、_ 和 ! 本身.
+type Like struct {
+	Column clause.Column
+	Value  string
+}
+
+// Build 实现 clause.Expression 接口.
+func (l Like) Build(builder clause.Builder) {
+	builder.WriteQuoted(l.Column)
+	builder.WriteString(" LIKE ")
+	builder.AddVar(builder, l.Value)
+	builder.WriteString(" ESCAPE '" + string(LikeEscape) + "'")
+}
+
+// EscapeLike 转义 s 中的 %、_ 和 LikeEscape，使其在 Like 条件中按普通字符匹配.
+func EscapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+// Contains 返回 column 的值包含 value 的查询条件.
+func Contains(column string, value string) Like {
+	return Like{Column: clause.Column{Name: column}, Value: "%" + EscapeLike(value) + "%"}
+}
+
+// SQLiteTimeLayout 是 SQLiteTime 返回的时间格式.
+const SQLiteTimeLayout = "2006-01-02 15:04:05.000"
+
+// SQLiteTime 返回将 SQLite 中的时间字段转换为 SQLiteTimeLayout 格式的 UTC 时间的表达式.
+func SQLiteTime(stmt *gorm.Statement, column string) clause.Column {
+	return clause.Column{Name: "strftime('%Y-%m-%d %H:%M:%f', " + stmt.Quote(column) + ")", Raw: true}
+}
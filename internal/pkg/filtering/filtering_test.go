@@ -0,0 +1,164 @@
+package filtering
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var testFields = Fields{
+	"title":     {Column: "title", Type: String},
+	"likeCount": {Column: "likeCount", Type: Int},
+	"createdAt": {Column: "createdAt", Type: Time},
+}
+
+// toSQL 返回 exprs 在 SQLite 上生成的 WHERE、ORDER BY 子句和参数.
+func toSQL(t *testing.T, exprs []clause.Expression) string {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	stmt := db.Table("posts").Clauses(exprs...).Find(&[]map[string]any{}).Statement
+	return strings.TrimSpace(strings.TrimPrefix(stmt.SQL.String(), "SELECT * FROM `posts`")) + fmt.Sprint(stmt.Vars)
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{"equal", `title = "go"`, "WHERE `title` = ?[go]"},
+		{"unquoted value", "title=go", "WHERE `title` = ?[go]"},
+		{"comparators", "title != a title < b title <= c title > d title >= e",
+			"WHERE `title` <> ? AND `title` < ? AND `title` <= ? AND `title` > ? AND `title` >= ?[a b c d e]"},
+		{"contains", "title:go", "WHERE `title` LIKE ? ESCAPE '!'[%go%]"},
+		{"contains wildcards", `title:"50%_off!"`, "WHERE `title` LIKE ? ESCAPE '!'[%50!%!_off!!%]"},
+		{"int", "likeCount >= 3", "WHERE `likeCount` >= ?[3]"},
+		{"int has", "likeCount:3", "WHERE `likeCount` = ?[3]"},
+		{"negative int", "likeCount > -1", "WHERE `likeCount` > ?[-1]"},
+		{"date", `createdAt >= "2026-01-02"`, "WHERE strftime('%Y-%m-%d %H:%M:%f', `createdAt`) >= ?[2026-01-02 00:00:00.000]"},
+		{"rfc3339 in another zone", `createdAt < "2026-01-02T08:00:00+08:00"`, "WHERE strftime('%Y-%m-%d %H:%M:%f', `createdAt`) < ?[2026-01-02 00:00:00.000]"},
+		{"ideographic space", "title　=　go", "WHERE `title` = ?[go]"},
+		{"no-break space", "title = go", "WHERE `title` = ?[go]"},
+		{"vertical tab and form feed", "title\v=\fgo", "WHERE `title` = ?[go]"},
+		// OR 的优先级高于以空格分隔的 AND
+		{"or binds tighter than sequence", "title = a OR title = b likeCount = 1",
+			"WHERE (`title` = ? OR `title` = ?) AND `likeCount` = ?[a b 1]"},
+		{"or binds tighter than and", "title = a AND title = b OR likeCount = 1",
+			"WHERE `title` = ? AND (`title` = ? OR `likeCount` = ?)[a b 1]"},
+		{"not binds to one restriction", "NOT title = a AND likeCount > 1",
+			"WHERE NOT (`title` = ?) AND `likeCount` > ?[a 1]"},
+		{"not with parentheses", "NOT (title = a likeCount = 1)",
+			"WHERE NOT ((`title` = ? AND `likeCount` = ?))[a 1]"},
+		{"nesting", "(title = a OR (likeCount >= 2 AND likeCount <= 5))",
+			"WHERE (`title` = ? OR (`likeCount` >= ? AND `likeCount` <= ?))[a 2 5]"},
+		{"max depth", strings.Repeat("(", maxDepth) + "title = a" + strings.Repeat(")", maxDepth), "WHERE `title` = ?[a]"},
+		{"keyword as value", "title = AND", "WHERE `title` = ?[AND]"},
+		{"lowercase or is not a keyword", "title = a or", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprs, err := testFields.Filter(tt.filter)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("Filter(%q) = %s, want error", tt.filter, toSQL(t, exprs))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Filter(%q): %v", tt.filter, err)
+			}
+			if got := toSQL(t, exprs); got != tt.want {
+				t.Errorf("Filter(%q) = %s, want %s", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterEmpty(t *testing.T) {
+	for _, filter := range []string{"", " ", "　"} {
+		if exprs, err := testFields.Filter(filter); err != nil || exprs != nil {
+			t.Errorf("Filter(%q) = %v, %v, want nil", filter, exprs, err)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		wantErr string
+	}{
+		{"too long", "title = " + strings.Repeat("a", maxLength), "at most 1024 characters"},
+		{"too deep", strings.Repeat("(", maxDepth+2) + "title = a" + strings.Repeat(")", maxDepth+2), "nested too deeply"},
+		{"unterminated string", `title = "go`, "unterminated string at position 8"},
+		{"unknown field", "name = a", `unknown field "name"`},
+		{"keyword as field", "AND = a", "expected field name at position 0"},
+		{"missing field", "= a", "expected field name at position 0"},
+		{"missing comparator", "title a", `expected comparator after "title"`},
+		{"missing value", "title =", `expected value after "title" =`},
+		{"operator as value", "title = =", `expected value after "title" =`},
+		{"unclosed parenthesis", "(title = a", "expected ')' at position 10"},
+		{"extra parenthesis", "title = a)", `unexpected ")" at position 9`},
+		{"trailing or", "title = a OR", "expected field name at position 12"},
+		{"trailing not", "NOT", "expected field name at position 3"},
+		{"empty parentheses", "()", "expected field name at position 1"},
+		{"lone bang", "title ! a", "unexpected '!' at position 6"},
+		{"int not a number", "likeCount = abc", `field "likeCount" requires an integer value`},
+		{"int fraction", "likeCount > 1.5", `field "likeCount" requires an integer value`},
+		{"int overflow", "likeCount > 99999999999999999999", `field "likeCount" requires an integer value`},
+		{"time not a time", "createdAt > yesterday", `field "createdAt" requires a time value`},
+		{"time has", `createdAt:"2026-01-02"`, `operator ':' is not supported on field "createdAt"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprs, err := testFields.Filter(tt.filter)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Filter(%q) = %v, %v, want error %q", tt.filter, exprs, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderBy string
+		want    string
+		wantErr string
+	}{
+		{"empty", " ", "[]", ""},
+		{"ascending by default", "title", "ORDER BY `title`[]", ""},
+		{"multiple fields", "title desc, likeCount ASC", "ORDER BY `title` DESC,`likeCount`[]", ""},
+		{"extra spaces", "  createdAt   DESC  ", "ORDER BY `createdAt` DESC[]", ""},
+		{"unknown field", "name", "", `unknown field "name"`},
+		{"duplicate field", "title, title desc", "", `duplicate field "title"`},
+		{"invalid direction", "title down", "", `invalid direction "down"`},
+		{"too many words", "title asc desc", "", `invalid expression "title asc desc"`},
+		{"empty part", "title,", "", `invalid expression ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprs, err := testFields.OrderBy(tt.orderBy)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("OrderBy(%q) error = %v, want %q", tt.orderBy, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OrderBy(%q): %v", tt.orderBy, err)
+			}
+			if got := toSQL(t, exprs); got != tt.want {
+				t.Errorf("OrderBy(%q) = %s, want %s", tt.orderBy, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package filtering
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind 为 token 的类型.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenText 为不带引号的文本，包括字段名、关键字和值.
+	tokenText
+	// tokenString 为双引号括起来的字符串.
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+// token 为过滤表达式中的一个词法单元，pos 为其在表达式中的字节偏移.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators 为支持的比较运算符，较长的运算符需要排在前面.
+var operators = []string{"!=", "<=", ">=", "=", "<", ">", ":"}
+
+// lex 将过滤表达式切分为 token，最后一个 token 总是 tokenEOF.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		// 空白字符与文本的分隔规则保持一致，包括 U+3000 等非 ASCII 空白，
+		// 否则文本长度为 0 会导致死循环
+		if r, size := utf8.DecodeRuneInString(input[i:]); unicode.IsSpace(r) {
+			i += size
+			continue
+		}
+		switch {
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == '"':
+			text, n, err := lexString(input[i:])
+			if err != nil {
+				return nil, fmt.Errorf("filter: %w at position %d", err, i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i += n
+		case strings.ContainsRune("!<>=:", rune(c)):
+			op := lexOperator(input[i:])
+			if op == "" {
+				return nil, fmt.Errorf("filter: unexpected %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+			i += len(op)
+		default:
+			end := strings.IndexFunc(input[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune(`()"!<>=:`, r)
+			})
+			if end < 0 {
+				end = len(input) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("filter: unexpected %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenText, text: input[i : i+end], pos: i})
+			i += end
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+// lexOperator 返回 input 开头的比较运算符，不是运算符时返回空字符串.
+func lexOperator(input string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(input, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// lexString 解析 input 开头的双引号字符串，支持 \" 和 \\ 转义，返回字符串内容和消耗的字节数.
+func lexString(input string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(input); i++ {
+		switch input[i] {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 == len(input) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+			i++
+			b.WriteByte(input[i])
+		default:
+			b.WriteByte(input[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
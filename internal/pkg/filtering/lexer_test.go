@@ -0,0 +1,66 @@
+package filtering
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// texts 返回 tokens 的类型和文本，不包括最后的 tokenEOF.
+func texts(tokens []token) []string {
+	ret := make([]string, 0, len(tokens))
+	for _, tok := range tokens[:len(tokens)-1] {
+		switch tok.kind {
+		case tokenString:
+			ret = append(ret, `"`+tok.text+`"`)
+		default:
+			ret = append(ret, tok.text)
+		}
+	}
+	return ret
+}
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+	}{
+		{"empty", "", []string{}, ""},
+		{"restriction", `title="go"`, []string{"title", "=", `"go"`}, ""},
+		{"operators", "a!=1 b<=2 c>=3 d<4 e>5 f:6", []string{"a", "!=", "1", "b", "<=", "2", "c", ">=", "3", "d", "<", "4", "e", ">", "5", "f", ":", "6"}, ""},
+		{"parentheses", "(a = 1)", []string{"(", "a", "=", "1", ")"}, ""},
+		{"ascii whitespace", "a\t=\r\n1", []string{"a", "=", "1"}, ""},
+		{"ideographic space", "a　=　1", []string{"a", "=", "1"}, ""},
+		{"no-break space", "a = 1", []string{"a", "=", "1"}, ""},
+		{"vertical tab and form feed", "a\v=\f1", []string{"a", "=", "1"}, ""},
+		{"only whitespace", "　 \v", []string{}, ""},
+		{"non-ascii text", "title=博客", []string{"title", "=", "博客"}, ""},
+		{"string with spaces", `a = "x y"`, []string{"a", "=", `"x y"`}, ""},
+		{"string escapes", `a = "x\"y\\z"`, []string{"a", "=", `"x"y\z"`}, ""},
+		{"unterminated string", `a = "xy`, nil, "unterminated string at position 4"},
+		{"unterminated escape", `a = "xy\`, nil, "unterminated string at position 4"},
+		{"lone bang", "a ! 1", nil, `unexpected '!' at position 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lex(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("lex(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lex(%q): %v", tt.input, err)
+			}
+			if got := texts(tokens); !slices.Equal(got, tt.want) {
+				t.Errorf("lex(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if last := tokens[len(tokens)-1]; last.kind != tokenEOF || last.pos != len(tt.input) {
+				t.Errorf("lex(%q) last token = %+v, want EOF at %d", tt.input, last, len(tt.input))
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/token"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/core"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+)
+
+// AuthnMiddleware 是一个 Gin 中间件，用于校验请求中的 Bearer Token，
+// 并将解析出的用户 ID 保存到请求上下文中.
+func AuthnMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从 Authorization 请求头中解析 JWT Token
+		userID, err := token.ParseRequest(c)
+		if err != nil {
+			log.W(c.Request.Context()).Errorw("Failed to parse request", "err", err)
+			core.WriteResponse(c, nil, errno.ErrTokenInvalid.WithMessage("%s", err.Error()))
+			c.Abort()
+			return
+		}
+
+		// 将用户 ID 保存到上下文中，log.W(ctx) 会自动带上 x-user-id
+		ctx := contextx.WithUserID(c.Request.Context(), userID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// OptionalAuthnMiddleware 是允许匿名访问的认证中间件.
+// 请求没有 Authorization 头时直接放行，上下文中没有用户 ID；携带了令牌时与 AuthnMiddleware 相同，令牌必须有效.
+func OptionalAuthnMiddleware() gin.HandlerFunc {
+	authn := AuthnMiddleware()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		authn(c)
+	}
+}
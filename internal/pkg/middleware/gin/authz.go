@@ -0,0 +1,32 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/core"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+)
+
+// Authorizer 用于判断 subject 是否有权对 object 执行 action.
+type Authorizer interface {
+	Authorize(subject, object, action string) (bool, error)
+}
+
+// AuthzMiddleware 是一个 Gin 中间件，用于对请求进行授权.
+// 必须在认证中间件之后执行，以便从上下文中获取用户 ID.
+func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject := contextx.UserID(c.Request.Context())
+		object := c.Request.URL.Path
+		action := c.Request.Method
+
+		if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+			log.W(c.Request.Context()).Errorw("Permission denied", "subject", subject, "object", object, "action", action, "err", err)
+			core.WriteResponse(c, nil, errorsx.ErrPermissionDenied)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
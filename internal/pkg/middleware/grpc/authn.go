@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/token"
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/errno"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthnInterceptor 是一个 gRPC 拦截器，用于校验请求中的 Bearer Token，
+// 并将解析出的用户 ID 保存到上下文中.
+func AuthnInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		// 从 metadata 的 authorization 中解析 JWT Token
+		userID, err := token.ParseRequest(ctx)
+		if err != nil {
+			log.W(ctx).Errorw("Failed to parse request", "err", err)
+			return nil, errno.ErrTokenInvalid.WithMessage("%s", err.Error())
+		}
+
+		// 将用户 ID 保存到上下文中，log.W(ctx) 会自动带上 x-user-id
+		ctx = contextx.WithUserID(ctx, userID)
+		return handler(ctx, req)
+	}
+}
+
+// OptionalAuthnInterceptor 是允许匿名访问的认证拦截器.
+// 请求没有 authorization 元数据时直接放行，上下文中没有用户 ID；携带了令牌时与 AuthnInterceptor 相同，令牌必须有效.
+func OptionalAuthnInterceptor() grpc.UnaryServerInterceptor {
+	authn := AuthnInterceptor()
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if len(metadata.ValueFromIncomingContext(ctx, "authorization")) == 0 {
+			return handler(ctx, req)
+		}
+		return authn(ctx, req, info, handler)
+	}
+}
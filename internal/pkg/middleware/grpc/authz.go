@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/wshadm/miniblog/internal/pkg/contextx"
+	"github.com/wshadm/miniblog/internal/pkg/log"
+	"github.com/wshadm/miniblog/pkg/errorsx"
+	"google.golang.org/grpc"
+)
+
+// Authorizer 用于判断 subject 是否有权对 object 执行 action.
+type Authorizer interface {
+	Authorize(subject, object, action string) (bool, error)
+}
+
+// AuthzInterceptor 是一个 gRPC 拦截器，用于对请求进行授权.
+// 必须在认证拦截器之后执行，以便从上下文中获取用户 ID.
+func AuthzInterceptor(authorizer Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		subject := contextx.UserID(ctx)
+		object := info.FullMethod
+		action := "CALL"
+
+		if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+			log.W(ctx).Errorw("Permission denied", "subject", subject, "object", object, "action", action, "err", err)
+			return nil, errorsx.ErrPermissionDenied
+		}
+		return handler(ctx, req)
+	}
+}
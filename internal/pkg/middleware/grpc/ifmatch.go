@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/wshadm/miniblog/internal/pkg/etag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// IfMatchInterceptor 是一个 gRPC 拦截器，将 If-Match 元数据设置到请求的 etag 字段中.
+// grpc-gateway 会将 HTTP 的 If-Match 头转发为 grpcgateway-if-match 元数据.
+func IfMatchInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if msg, ok := req.(proto.Message); ok {
+			md, _ := metadata.FromIncomingContext(ctx)
+			key := strings.ToLower(etag.HeaderIfMatch)
+			for _, k := range []string{key, runtime.MetadataPrefix + key} {
+				if values := md.Get(k); len(values) > 0 {
+					etag.SetIfMatch(msg, values[0])
+					break
+				}
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,118 @@
+package pagetoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wshadm/miniblog/internal/pkg/filtering"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalid 表示分页令牌格式错误、签名不匹配或与当前查询不一致.
+var ErrInvalid = errors.New("invalid page token")
+
+var (
+	mu  sync.RWMutex
+	key = []byte("miniblog-page-token")
+)
+
+// Init 设置分页令牌的签名密钥.
+func Init(secret string) {
+	mu.Lock()
+	defer mu.Unlock()
+	// 与 JWT 等其他用途的密钥区分开
+	sum := sha256.Sum256([]byte("page-token:" + secret))
+	key = sum[:]
+}
+
+// Cursor 表示 keyset 分页的位置，即上一页最后一条记录的 (createdAt, id).
+type Cursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int64     `json:"i"`
+}
+
+// Encode 将 cursor 编码为不透明的分页令牌.
+// scope 用于标识查询（例如资源类型和过滤条件），令牌只能用于 scope 相同的查询.
+func Encode(cursor Cursor, scope string) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload, scope))
+}
+
+// Decode 校验分页令牌的签名并解析出 cursor.
+func Decode(token string, scope string) (Cursor, error) {
+	var cursor Cursor
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return cursor, ErrInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, ErrInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, sign(payload, scope)) {
+		return cursor, ErrInvalid
+	}
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return cursor, ErrInvalid
+	}
+	return cursor, nil
+}
+
+// After 返回按 (createdAt, id) 倒序排列时位于 cursor 之后的记录的查询条件.
+func After(cursor Cursor) clause.Expression {
+	return keyset{cursor: cursor}
+}
+
+// keyset 是 keyset 分页的查询条件，在生成 SQL 时根据数据库类型决定时间参数的格式.
+type keyset struct {
+	cursor Cursor
+}
+
+// Build 实现 clause.Expression 接口.
+func (k keyset) Build(builder clause.Builder) {
+	// SQLite 以文本保存时间并按字符串比较，比较前将字段和参数都转换为相同的 UTC 格式，
+	// 否则默认值 CURRENT_TIMESTAMP 与驱动写入的带纳秒和时区的时间比较时结果不正确
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.Dialector.Name() == "sqlite" {
+		column := filtering.SQLiteTime(stmt, "createdAt")
+		k.expression(column, k.cursor.CreatedAt.UTC().Format(filtering.SQLiteTimeLayout)).Build(builder)
+		return
+	}
+	k.expression(clause.Column{Name: "createdAt"}, k.cursor.CreatedAt).Build(builder)
+}
+
+// Unwrap 返回与数据库无关的查询条件，供不生成 SQL 的存储实现使用.
+func (k keyset) Unwrap() clause.Expression {
+	return k.expression(clause.Column{Name: "createdAt"}, k.cursor.CreatedAt)
+}
+
+// expression 使用 column 作为时间字段、createdAt 作为时间参数构造查询条件.
+func (k keyset) expression(column clause.Column, createdAt any) clause.Expression {
+	return clause.Or(
+		clause.Lt{Column: column, Value: createdAt},
+		clause.And(
+			clause.Eq{Column: column, Value: createdAt},
+			clause.Lt{Column: clause.Column{Name: "id"}, Value: k.cursor.ID},
+		),
+	)
+}
+
+// sign 计算 payload 和 scope 的签名.
+func sign(payload []byte, scope string) []byte {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	h := hmac.New(sha256.New, key)
+	h.Write(payload)
+	h.Write([]byte{0})
+	h.Write([]byte(scope))
+	return h.Sum(nil)
+}
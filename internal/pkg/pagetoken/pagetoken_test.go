@@ -0,0 +1,198 @@
+package pagetoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.FixedZone("CST", 8*3600)), ID: 42}
+	token := Encode(cursor, "post")
+
+	got, err := Decode(token, "post")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !got.CreatedAt.Equal(cursor.CreatedAt) || got.ID != cursor.ID {
+		t.Errorf("Decode() = %+v, want %+v", got, cursor)
+	}
+	if strings.ContainsAny(token, "+/=") {
+		t.Errorf("token %q is not URL safe", token)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), ID: 42}
+	token := Encode(cursor, "post")
+	payload, signature, _ := strings.Cut(token, ".")
+
+	// 修改游标但保留原签名
+	tampered, _ := json.Marshal(Cursor{CreatedAt: cursor.CreatedAt, ID: 1})
+	// 签名正确但内容不是游标
+	notJSON := base64.RawURLEncoding.EncodeToString([]byte("x"))
+	notJSON += "." + base64.RawURLEncoding.EncodeToString(sign([]byte("x"), "post"))
+
+	tests := []struct {
+		name  string
+		token string
+		scope string
+	}{
+		{"empty", "", "post"},
+		{"no signature", payload, "post"},
+		{"tampered payload", base64.RawURLEncoding.EncodeToString(tampered) + "." + signature, "post"},
+		{"tampered signature", payload + "." + base64.RawURLEncoding.EncodeToString([]byte("signature")), "post"},
+		{"invalid payload encoding", "!!!." + signature, "post"},
+		{"invalid signature encoding", payload + ".!!!", "post"},
+		{"not json", notJSON, "post"},
+		{"wrong scope", token, "user"},
+		{"scope of another query", token, "post\x00title"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.token, tt.scope); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Decode(%q, %q) error = %v, want %v", tt.token, tt.scope, err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestInitChangesKey(t *testing.T) {
+	mu.RLock()
+	old := key
+	mu.RUnlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		key = old
+	})
+
+	cursor := Cursor{CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), ID: 42}
+	Init("secret-a")
+	token := Encode(cursor, "post")
+	if _, err := Decode(token, "post"); err != nil {
+		t.Fatalf("Decode with the same secret: %v", err)
+	}
+
+	Init("secret-b")
+	if _, err := Decode(token, "post"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Decode with another secret error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+// row 为测试表中的一条记录，createdAt 为 SQLite 中保存的时间文本.
+type row struct {
+	id        int64
+	createdAt string
+}
+
+// newSQLite 创建 SQLite 内存数据库，并写入 rows.
+func newSQLite(t *testing.T, rows []row) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, createdAt DATETIME NOT NULL)").Error; err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	for _, r := range rows {
+		if err := db.Exec("INSERT INTO items (id, createdAt) VALUES (?, ?)", r.id, r.createdAt).Error; err != nil {
+			t.Fatalf("failed to insert row: %v", err)
+		}
+	}
+	return db
+}
+
+// after 返回位于 cursor 之后的记录 ID，按 ID 倒序排列.
+func after(t *testing.T, db *gorm.DB, cursor Cursor) []int64 {
+	t.Helper()
+
+	var ids []int64
+	if err := db.Table("items").Clauses(After(cursor)).Order("id desc").Pluck("id", &ids).Error; err != nil {
+		t.Fatalf("failed to query items: %v", err)
+	}
+	return ids
+}
+
+func TestAfterBreaksTiesByID(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := newSQLite(t, []row{
+		{1, "2026-01-01 00:00:00"},
+		{2, "2026-01-01 00:00:00"},
+		{3, "2026-01-01 00:00:00"},
+		{4, "2026-01-01 00:00:01"},
+		{5, "2026-01-01 00:00:01"},
+	})
+
+	tests := []struct {
+		name   string
+		cursor Cursor
+		want   []int64
+	}{
+		{"before newest", Cursor{CreatedAt: t0.Add(time.Second), ID: 6}, []int64{5, 4, 3, 2, 1}},
+		{"tie with larger id", Cursor{CreatedAt: t0.Add(time.Second), ID: 5}, []int64{4, 3, 2, 1}},
+		{"tie with smallest id", Cursor{CreatedAt: t0.Add(time.Second), ID: 4}, []int64{3, 2, 1}},
+		{"middle of tie", Cursor{CreatedAt: t0, ID: 2}, []int64{1}},
+		{"last", Cursor{CreatedAt: t0, ID: 1}, nil},
+		{"between times", Cursor{CreatedAt: t0.Add(500 * time.Millisecond), ID: 0}, []int64{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := after(t, db, tt.cursor); !slices.Equal(got, tt.want) {
+				t.Errorf("After(%+v) = %v, want %v", tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAfterNormalizesSQLiteTime 检查 SQLite 中不同格式的时间文本按时间而不是按字符串比较.
+func TestAfterNormalizesSQLiteTime(t *testing.T) {
+	rows := []row{
+		// 默认值 CURRENT_TIMESTAMP 的格式
+		{1, "2026-01-01 00:00:00"},
+		// 驱动写入的格式，带纳秒和时区，与 1 的时间相同
+		{2, "2026-01-01 00:00:00+00:00"},
+		{3, "2026-01-01 00:00:00.5+00:00"},
+		// 按字符串比较时会排在最后
+		{4, "2026-01-01 08:00:01+08:00"},
+		{5, "2026-01-01 00:00:02.000000000+00:00"},
+	}
+	db := newSQLite(t, rows)
+	times := []time.Time{
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 1, 0, 0, 0, 500_000_000, time.UTC),
+		time.Date(2026, 1, 1, 8, 0, 1, 0, time.FixedZone("CST", 8*3600)),
+		time.Date(2026, 1, 1, 0, 0, 2, 0, time.UTC),
+	}
+
+	// 按 (createdAt, id) 倒序依次以每条记录作为游标，之后的记录正好是排在其后的记录
+	order := []int64{5, 4, 3, 2, 1}
+	for i, id := range order {
+		cursor := Cursor{CreatedAt: times[id-1], ID: id}
+		if got, want := after(t, db, cursor), order[i+1:]; !slices.Equal(got, want) {
+			t.Errorf("After(%d) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+// TestAfterUnwrap 检查供内存存储使用的条件不依赖数据库.
+func TestAfterUnwrap(t *testing.T) {
+	expr, ok := After(Cursor{ID: 1}).(interface{ Unwrap() clause.Expression })
+	if !ok {
+		t.Fatal("After() should implement Unwrap")
+	}
+	if _, ok := expr.Unwrap().(clause.OrConditions); !ok {
+		t.Errorf("Unwrap() = %T, want clause.OrConditions", expr.Unwrap())
+	}
+}
@@ -1,10 +1,27 @@
 package rid
 
 import (
+	"context"
+	"encoding/binary"
+
 	"github.com/onexstack/onexstack/pkg/id"
 )
 
-const defaultABC = "abcdefghijklmnopqrstuvwxyz1234567890"
+const (
+	defaultABC = "abcdefghijklmnopqrstuvwxyz1234567890"
+	// codeLength 为唯一标识符中编码部分的长度.
+	codeLength = 13
+)
+
+// sf 用于在插入数据库之前生成全局唯一的序号，机器 ID 取自本机的机器 ID，
+// 避免多个实例生成相同的序号.
+var sf = id.NewSonyflake(id.WithSonyflakeMachineId(machineID()))
+
+func init() {
+	if sf.Error != nil {
+		panic(sf.Error)
+	}
+}
 
 type ResourceID string
 
@@ -13,6 +30,10 @@ const (
 	UserID ResourceID = "user"
 	//PostID 定义博文资源标识符
 	PostID ResourceID = "post"
+	// CommentID 定义评论资源标识符
+	CommentID ResourceID = "comment"
+	// EventID 定义领域事件标识符
+	EventID ResourceID = "event"
 )
 
 // String 将资源标识符转换成字符串
@@ -23,6 +44,20 @@ func (rid ResourceID) String() string {
 // New创建带前缀的唯一标识符
 func (rid ResourceID) New(conter uint64) string {
 	//使用自定义选项生成唯一标识符
-	uniqueStr := id.NewCode(conter, id.WithCodeChars([]rune(defaultABC)), id.WithCodeL(6), id.WithCodeSalt(Salt()))
+	uniqueStr := id.NewCode(conter, id.WithCodeChars([]rune(defaultABC)), id.WithCodeL(codeLength), id.WithCodeSalt(Salt()))
 	return rid.String() + "-" + uniqueStr
 }
+
+// Next 生成一个新的带前缀的唯一标识符，无需依赖数据库自增 ID.
+func (rid ResourceID) Next() string {
+	return rid.New(sf.Id(context.Background()))
+}
+
+// machineID 根据本机的机器 ID 计算 sonyflake 使用的机器 ID.
+func machineID() uint16 {
+	mid := binary.BigEndian.Uint16(ReadMachineID())
+	if mid == 0 {
+		mid = 1
+	}
+	return mid
+}
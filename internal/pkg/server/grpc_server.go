@@ -24,7 +24,7 @@ func NewGRPCServer(
 	registerServer func(grpc.ServiceRegistrar)) (*GRPCServer, error) {
 	lis, err := net.Listen("tcp", grpcOptions.Addr)
 	if err != nil {
-		logger.L().Error().Msgf("Failed to listen", "err", err)
+		logger.L().Error().Err(err).Msg("Failed to listen")
 		return nil, err
 	}
 	grpcsrv := grpc.NewServer(serverOptions...)
@@ -37,9 +37,9 @@ func NewGRPCServer(
 	}, nil
 }
 func (s *GRPCServer) RunOrDie() {
-	logger.L().Info().Msgf("Start to listening the incoming requests", "protocol", "grpc", "addr", s.lis.Addr().String())
+	logger.L().Info().Str("protocol", "grpc").Str("addr", s.lis.Addr().String()).Msg("Start to listening the incoming requests")
 	if err := s.srv.Serve(s.lis); err != nil {
-		logger.L().Fatal().Msgf("Failed to serve grpc server", "err", err)
+		logger.L().Fatal().Err(err).Msg("Failed to serve grpc server")
 	}
 
 }
@@ -26,9 +26,9 @@ func NewHTTPServer(httpOptions *options.HTTPOptions, handler http.Handler) *HTTP
 
 // RunOrDie 启动HTTP服务器
 func (s *HTTPServer) RunOrDie() {
-	logger.L().Info().Msgf("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.srv.Addr)
+	logger.L().Info().Str("protocol", protocolName(s.srv)).Str("addr", s.srv.Addr).Msg("Start to listening the incoming requests")
 	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		logger.L().Fatal().Msgf("Failed to server HTTP(s) server", "err", err)
+		logger.L().Fatal().Err(err).Msg("Failed to server HTTP(s) server")
 	}
 }
 
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"github.com/wshadm/miniblog/pkg/options"
+)
+
+const (
+	// maxRetryBackoff 为处理或写入死信主题失败后重试的最大等待时间.
+	maxRetryBackoff = time.Minute
+
+	// DeadLetterSuffix 为死信主题的后缀，处理失败的消息会被写入 <topic>.dlq.
+	DeadLetterSuffix = ".dlq"
+
+	// 死信消息通过以下消息头记录失败原因和原始位置.
+	HeaderDeadLetterError     = "dlq-error"
+	HeaderDeadLetterTopic     = "dlq-original-topic"
+	HeaderDeadLetterPartition = "dlq-original-partition"
+	HeaderDeadLetterOffset    = "dlq-original-offset"
+)
+
+// MessageHandler 处理一条 Kafka 消息. 返回错误时消息会被重试，重试次数耗尽后写入死信主题.
+// 投递语义为至少一次，处理函数需要是幂等的.
+type MessageHandler func(ctx context.Context, msg kafka.Message) error
+
+// MessageReader 定义了 KafkaWorker 读取消息所需的方法，*kafka.Reader 实现了该接口.
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// MessageWriter 定义了 KafkaWorker 写入死信消息所需的方法，*kafka.Writer 实现了该接口.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// KafkaWorker 是 Kafka 消费者运行时，为每个注册的主题创建一个 Reader，
+// 按顺序将消息分发给对应的处理函数，处理成功或写入死信主题后才提交位移.
+type KafkaWorker struct {
+	newReader    func(topic string) (MessageReader, error)
+	dlq          MessageWriter
+	maxRetries   int
+	retryBackoff time.Duration
+	handlers     map[string]MessageHandler
+
+	// fetchCtx 在停止时取消，不再拉取新消息；handleCtx 只在停止超时后取消，
+	// 保证正在处理的消息能够处理完成.
+	fetchCtx     context.Context
+	stopFetch    context.CancelFunc
+	handleCtx    context.Context
+	cancelHandle context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// 确保 KafkaWorker 实现了 Server 接口.
+var _ Server = (*KafkaWorker)(nil)
+
+// NewKafkaWorker 使用 kafkaOptions 创建 Reader 和死信 Writer.
+// 处理失败的消息最多重试 maxRetries 次，每次重试之间的等待时间从 retryBackoff 开始指数增长，最长为 1 分钟.
+func NewKafkaWorker(kafkaOptions *options.KafkaOptions, maxRetries int, retryBackoff time.Duration) (*KafkaWorker, error) {
+	// 死信 Writer 不指定主题，由每条消息指定，且需要同步写入，保证写入成功后才提交位移
+	dlqOptions := *kafkaOptions
+	dlqOptions.Topic = ""
+	dlq, err := dlqOptions.Writer()
+	if err != nil {
+		return nil, err
+	}
+	dlq.Async = false
+
+	newReader := func(topic string) (MessageReader, error) {
+		return kafkaOptions.Reader(topic)
+	}
+	return NewKafkaWorkerWith(newReader, dlq, maxRetries, retryBackoff), nil
+}
+
+// NewKafkaWorkerWith 使用自定义的 Reader 构造函数和死信 Writer 创建 KafkaWorker，便于测试.
+func NewKafkaWorkerWith(newReader func(topic string) (MessageReader, error), dlq MessageWriter, maxRetries int, retryBackoff time.Duration) *KafkaWorker {
+	fetchCtx, stopFetch := context.WithCancel(context.Background())
+	handleCtx, cancelHandle := context.WithCancel(context.Background())
+	return &KafkaWorker{
+		newReader:    newReader,
+		dlq:          dlq,
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+		handlers:     make(map[string]MessageHandler),
+		fetchCtx:     fetchCtx,
+		stopFetch:    stopFetch,
+		handleCtx:    handleCtx,
+		cancelHandle: cancelHandle,
+	}
+}
+
+// Register 注册主题的处理函数，需要在 RunOrDie 之前调用.
+func (w *KafkaWorker) Register(topic string, handler MessageHandler) {
+	w.handlers[topic] = handler
+}
+
+// RunOrDie 为每个注册的主题启动消费协程，创建 Reader 失败时退出程序.
+func (w *KafkaWorker) RunOrDie() {
+	for topic, handler := range w.handlers {
+		reader, err := w.newReader(topic)
+		if err != nil {
+			logger.L().Fatal().Err(err).Str("topic", topic).Msg("Failed to create kafka reader")
+		}
+
+		logger.L().Info().Str("topic", topic).Msg("Start to consume kafka messages")
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.consume(topic, reader, handler)
+		}()
+	}
+	w.wg.Wait()
+}
+
+// GracefulStop 停止拉取新消息，等待正在处理的消息处理完成并提交位移.
+// ctx 超时后取消正在处理的消息，未提交的消息会在重启后被重新消费.
+func (w *KafkaWorker) GracefulStop(ctx context.Context) {
+	logger.L().Info().Msg("Gracefully stop kafka worker")
+	w.stopFetch()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.L().Warn().Err(ctx.Err()).Msg("Kafka worker forced to shutdown")
+		w.cancelHandle()
+		<-done
+	}
+
+	if err := w.dlq.Close(); err != nil {
+		logger.L().Err(err).Msg("Failed to close dead letter writer")
+	}
+}
+
+// consume 依次拉取并处理 topic 中的消息，直到停止.
+func (w *KafkaWorker) consume(topic string, reader MessageReader, handler MessageHandler) {
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.L().Err(err).Str("topic", topic).Msg("Failed to close kafka reader")
+		}
+	}()
+
+	for {
+		// ctx 被取消后 FetchMessage 仍可能返回已缓冲的消息，因此先检查是否已经停止
+		if w.fetchCtx.Err() != nil {
+			return
+		}
+		msg, err := reader.FetchMessage(w.fetchCtx)
+		if err != nil {
+			if w.fetchCtx.Err() != nil {
+				return
+			}
+			logger.L().Warn().Err(err).Str("topic", topic).Msg("Failed to fetch kafka message")
+			if !w.sleep(w.fetchCtx, w.retryBackoff) {
+				return
+			}
+			continue
+		}
+
+		if !w.process(msg, handler) {
+			return
+		}
+		if err := reader.CommitMessages(w.handleCtx, msg); err != nil {
+			// 提交失败时消息会被重新消费，处理函数需要保证幂等
+			logger.L().Err(err).Str("topic", topic).Int64("offset", msg.Offset).Msg("Failed to commit kafka message")
+		}
+	}
+}
+
+// process 调用处理函数并在失败时重试，重试次数耗尽后写入死信主题.
+// 返回 false 表示 worker 被强制停止，消息未处理完成，不能提交位移.
+func (w *KafkaWorker) process(msg kafka.Message, handler MessageHandler) bool {
+	backoff := w.retryBackoff
+	var err error
+	for attempt := 0; attempt <= w.maxRetries; attempt++ {
+		if attempt > 0 {
+			if !w.sleep(w.handleCtx, backoff) {
+				return false
+			}
+			backoff = nextBackoff(backoff)
+		}
+		if err = handler(w.handleCtx, msg); err == nil {
+			return true
+		}
+		logger.L().Warn().Err(err).Str("topic", msg.Topic).Int64("offset", msg.Offset).Int("attempt", attempt+1).Msg("Failed to handle kafka message")
+	}
+
+	// 因为强制停止而失败的消息没有处理完成，不能写入死信主题
+	if w.handleCtx.Err() != nil {
+		return false
+	}
+	return w.deadLetter(msg, err)
+}
+
+// deadLetter 将处理失败的消息写入死信主题. 写入失败时持续重试，避免提交位移后丢失消息.
+func (w *KafkaWorker) deadLetter(msg kafka.Message, cause error) bool {
+	dlqMsg := kafka.Message{
+		Topic: msg.Topic + DeadLetterSuffix,
+		Key:   msg.Key,
+		Value: msg.Value,
+		Headers: append(slices.Clone(msg.Headers),
+			kafka.Header{Key: HeaderDeadLetterError, Value: []byte(cause.Error())},
+			kafka.Header{Key: HeaderDeadLetterTopic, Value: []byte(msg.Topic)},
+			kafka.Header{Key: HeaderDeadLetterPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+			kafka.Header{Key: HeaderDeadLetterOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		),
+	}
+
+	backoff := w.retryBackoff
+	for {
+		err := w.dlq.WriteMessages(w.handleCtx, dlqMsg)
+		if err == nil {
+			logger.L().Warn().Str("topic", msg.Topic).Int64("offset", msg.Offset).Str("dlq", dlqMsg.Topic).Msg("Kafka message moved to dead letter topic")
+			return true
+		}
+		logger.L().Err(err).Str("dlq", dlqMsg.Topic).Msg("Failed to write dead letter message")
+		if !w.sleep(w.handleCtx, backoff) {
+			return false
+		}
+		backoff = nextBackoff(backoff)
+	}
+}
+
+// nextBackoff 返回下一次重试前的等待时间，即 d 的两倍，最长为 maxRetryBackoff.
+func nextBackoff(d time.Duration) time.Duration {
+	return min(d*2, maxRetryBackoff)
+}
+
+// sleep 等待 d，ctx 被取消时返回 false.
+func (w *KafkaWorker) sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
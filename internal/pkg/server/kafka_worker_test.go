@@ -0,0 +1,304 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+var errHandle = errors.New("handle failed")
+
+// fakeReader 依次返回 msgs 中的消息，消息取完后阻塞直到 ctx 被取消.
+type fakeReader struct {
+	mu        sync.Mutex
+	msgs      []kafka.Message
+	committed []int64
+	closed    bool
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.msgs) > 0 {
+		msg := r.msgs[0]
+		r.msgs = r.msgs[1:]
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	return nil
+}
+
+// offsets 返回已提交的位移.
+func (r *fakeReader) offsets() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.committed)
+}
+
+// fakeWriter 记录写入的死信消息，前 fail 次写入返回错误.
+type fakeWriter struct {
+	mu     sync.Mutex
+	fail   int
+	calls  int
+	msgs   []kafka.Message
+	closed bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.calls++
+	if w.fail > 0 {
+		w.fail--
+		return errors.New("kafka unavailable")
+	}
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	return nil
+}
+
+// messages 返回 topic 中位移为 0 到 n-1 的消息.
+func messages(topic string, n int) []kafka.Message {
+	msgs := make([]kafka.Message, n)
+	for i := range msgs {
+		msgs[i] = kafka.Message{Topic: topic, Partition: 1, Offset: int64(i), Key: []byte(fmt.Sprintf("key-%d", i))}
+	}
+	return msgs
+}
+
+// startWorker 创建并启动消费 topic 的 worker，返回 RunOrDie 退出时关闭的 channel.
+func startWorker(t *testing.T, reader *fakeReader, dlq *fakeWriter, maxRetries int, handler MessageHandler) (*KafkaWorker, <-chan struct{}) {
+	t.Helper()
+
+	newReader := func(topic string) (MessageReader, error) {
+		return reader, nil
+	}
+	w := NewKafkaWorkerWith(newReader, dlq, maxRetries, time.Millisecond)
+	w.Register("posts", handler)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.RunOrDie()
+	}()
+	return w, done
+}
+
+// stop 停止 worker 并等待 RunOrDie 退出.
+func stop(t *testing.T, w *KafkaWorker, done <-chan struct{}, timeout time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	w.GracefulStop(ctx)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunOrDie did not return after GracefulStop")
+	}
+}
+
+// waitFor 等待 cond 成立.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestKafkaWorkerCommitsAfterHandling(t *testing.T) {
+	reader := &fakeReader{msgs: messages("posts", 3)}
+	dlq := &fakeWriter{}
+
+	var handled []int64
+	handler := func(ctx context.Context, msg kafka.Message) error {
+		// 处理时当前消息还没有被提交
+		if committed := reader.offsets(); slices.Contains(committed, msg.Offset) {
+			t.Errorf("message %d is committed before it is handled", msg.Offset)
+		}
+		handled = append(handled, msg.Offset)
+		return nil
+	}
+	w, done := startWorker(t, reader, dlq, 2, handler)
+	waitFor(t, func() bool { return len(reader.offsets()) == 3 })
+	stop(t, w, done, time.Second)
+
+	if want := []int64{0, 1, 2}; !slices.Equal(handled, want) || !slices.Equal(reader.offsets(), want) {
+		t.Errorf("handled = %v, committed = %v, want %v", handled, reader.offsets(), want)
+	}
+	if !reader.closed || !dlq.closed {
+		t.Errorf("reader closed = %v, dlq closed = %v, want both closed", reader.closed, dlq.closed)
+	}
+	if len(dlq.msgs) != 0 {
+		t.Errorf("dead letters = %d, want 0", len(dlq.msgs))
+	}
+}
+
+func TestKafkaWorkerRetriesThenDeadLetters(t *testing.T) {
+	reader := &fakeReader{msgs: messages("posts", 2)}
+	// 第一次写入死信主题失败，需要重试
+	dlq := &fakeWriter{fail: 1}
+
+	var mu sync.Mutex
+	attempts := make(map[int64]int)
+	handler := func(ctx context.Context, msg kafka.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[msg.Offset]++
+		// 第一条消息总是失败，第二条消息重试一次后成功
+		if msg.Offset == 0 || attempts[msg.Offset] == 1 {
+			return errHandle
+		}
+		return nil
+	}
+	w, done := startWorker(t, reader, dlq, 2, handler)
+	waitFor(t, func() bool { return len(reader.offsets()) == 2 })
+	stop(t, w, done, time.Second)
+
+	if attempts[0] != 3 || attempts[1] != 2 {
+		t.Errorf("attempts = %v, want 3 for offset 0 and 2 for offset 1", attempts)
+	}
+	if !slices.Equal(reader.offsets(), []int64{0, 1}) {
+		t.Errorf("committed = %v, want [0 1]", reader.offsets())
+	}
+	if dlq.calls != 2 || len(dlq.msgs) != 1 {
+		t.Fatalf("dead letter writes = %d, messages = %d, want 2 writes and 1 message", dlq.calls, len(dlq.msgs))
+	}
+
+	msg := dlq.msgs[0]
+	if msg.Topic != "posts"+DeadLetterSuffix || string(msg.Key) != "key-0" {
+		t.Errorf("dead letter topic = %s, key = %s, want posts.dlq and key-0", msg.Topic, msg.Key)
+	}
+	headers := make(map[string]string)
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	want := map[string]string{
+		HeaderDeadLetterError:     errHandle.Error(),
+		HeaderDeadLetterTopic:     "posts",
+		HeaderDeadLetterPartition: "1",
+		HeaderDeadLetterOffset:    "0",
+	}
+	for k, v := range want {
+		if headers[k] != v {
+			t.Errorf("header %s = %q, want %q", k, headers[k], v)
+		}
+	}
+}
+
+func TestKafkaWorkerWaitsForHandlingOnStop(t *testing.T) {
+	reader := &fakeReader{msgs: messages("posts", 2)}
+	dlq := &fakeWriter{}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := func(ctx context.Context, msg kafka.Message) error {
+		close(started)
+		<-release
+		return nil
+	}
+	w, done := startWorker(t, reader, dlq, 0, handler)
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		stop(t, w, done, 5*time.Second)
+	}()
+	// 停止后等待正在处理的消息处理完成，之后不再拉取新消息
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	<-stopped
+
+	if !slices.Equal(reader.offsets(), []int64{0}) {
+		t.Errorf("committed = %v, want [0]", reader.offsets())
+	}
+}
+
+func TestKafkaWorkerDoesNotCommitOnForcedStop(t *testing.T) {
+	for _, maxRetries := range []int{0, 2} {
+		t.Run(fmt.Sprintf("max retries %d", maxRetries), func(t *testing.T) {
+			reader := &fakeReader{msgs: messages("posts", 1)}
+			dlq := &fakeWriter{}
+
+			started := make(chan struct{})
+			handler := func(ctx context.Context, msg kafka.Message) error {
+				select {
+				case <-started:
+				default:
+					close(started)
+				}
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			w, done := startWorker(t, reader, dlq, maxRetries, handler)
+			<-started
+			// 处理超时，强制停止
+			stop(t, w, done, 10*time.Millisecond)
+
+			if committed := reader.offsets(); len(committed) != 0 {
+				t.Errorf("committed = %v, want none", committed)
+			}
+			if len(dlq.msgs) != 0 {
+				t.Errorf("dead letters = %d, want 0", len(dlq.msgs))
+			}
+		})
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want time.Duration
+	}{
+		{time.Second, 2 * time.Second},
+		{20 * time.Second, 40 * time.Second},
+		{40 * time.Second, maxRetryBackoff},
+		{maxRetryBackoff, maxRetryBackoff},
+	}
+	for _, tt := range tests {
+		if got := nextBackoff(tt.d); got != tt.want {
+			t.Errorf("nextBackoff(%v) = %v, want %v", tt.d, got, tt.want)
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/wshadm/miniblog/internal/pkg/core"
 	"github.com/wshadm/miniblog/internal/pkg/logger"
+	"github.com/wshadm/miniblog/pkg/errorsx"
 	"github.com/wshadm/miniblog/pkg/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
@@ -18,6 +20,8 @@ import (
 // GRPCGatewayServer 代表一个GRPC网关服务器
 type GRPCGatewayServer struct {
 	srv *http.Server
+	// conn 为连接到后端 gRPC 服务器的客户端连接，在服务器关闭时释放.
+	conn *grpc.ClientConn
 }
 
 // NewGRPCGatewayServer 创建示例
@@ -30,17 +34,17 @@ func NewGRPCGatewayServer(httpOptions *options.HTTPOptions, grpcOptions *options
 	dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	conn, err := grpc.NewClient(grpcOptions.Addr, dialOptions...)
 	if err != nil {
-		logger.L().Error().Err(err).Msgf("Failed to dial context: %s", err)
+		logger.L().Error().Err(err).Msg("Failed to dial context")
 		return nil, err
 	}
-	defer conn.Close()
 	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 		MarshalOptions: protojson.MarshalOptions{
 			UseEnumNumbers: true,
 		},
-	}))
+	}), runtime.WithErrorHandler(errorHandler))
 	if err := registerHandler(gwmux, conn); err != nil {
-		logger.L().Error().Err(err).Msgf("Failed to register handler", "err", err)
+		logger.L().Error().Err(err).Msg("Failed to register handler")
+		_ = conn.Close()
 		return nil, err
 	}
 	return &GRPCGatewayServer{
@@ -48,14 +52,21 @@ func NewGRPCGatewayServer(httpOptions *options.HTTPOptions, grpcOptions *options
 			Addr:    httpOptions.Addr,
 			Handler: gwmux,
 		},
+		conn: conn,
 	}, nil
 }
 
+// errorHandler 将 gRPC 错误转换为 errorsx.ErrorX 格式的 HTTP 响应，
+// 保证 grpc-gateway 与 Gin 服务器返回相同的错误格式.
+func errorHandler(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	core.WriteError(w, errorsx.FromError(err))
+}
+
 // RunOrDie 启动 GRPC 网关服务器并在出错时记录致命错误.
 func (s *GRPCGatewayServer) RunOrDie() {
-	logger.L().Info().Msgf("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.srv.Addr)
-	if err := s.srv.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
-		logger.L().Fatal().Msgf("Failed to server HTTP(s) server", "err", err)
+	logger.L().Info().Str("protocol", protocolName(s.srv)).Str("addr", s.srv.Addr).Msg("Start to listening the incoming requests")
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.L().Fatal().Err(err).Msg("Failed to server HTTP(s) server")
 	}
 
 }
@@ -65,6 +76,7 @@ func (s *GRPCGatewayServer) GracefulStop(ctx context.Context) {
 	logger.L().Info().Msg("Gracefully stop HTTP(s) server")
 	err := s.srv.Shutdown(ctx)
 	if err != nil {
-		logger.L().Error().Err(err).Msgf("HTTP(s) server forced to shutdown", "err", err)
+		logger.L().Error().Err(err).Msg("HTTP(s) server forced to shutdown")
 	}
+	_ = s.conn.Close()
 }
@@ -27,24 +27,237 @@ var File_apiserver_v1_apiserver_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\n" +
-	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x81\x01\n" +
+	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/user.proto\x1a\x17apiserver/v1/post.proto\x1a\x1aapiserver/v1/comment.proto\x1a\x16apiserver/v1/tag.proto\x1a\x1dapiserver/v1/engagement.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x80'\n" +
 	"\bMiniBlog\x12u\n" +
 	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x12.v1.HealthResponse\">\x92A+\n" +
 	"\f服务治理\x12\x12服务健康检查*\aHealthz\x82\xd3\xe4\x93\x02\n" +
-	"\x12\b/healthzB1Z/github.com/wshadm/miniblog/pkg/api/server/v1;v1b\x06proto3"
+	"\x12\b/healthz\x12e\n" +
+	"\x05Login\x12\x10.v1.LoginRequest\x1a\x11.v1.LoginResponse\"7\x92A#\n" +
+	"\f身份认证\x12\f用户登录*\x05Login\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\x89\x01\n" +
+	"\fRefreshToken\x12\x17.v1.RefreshTokenRequest\x1a\x18.v1.RefreshTokenResponse\"F\x92A*\n" +
+	"\f身份认证\x12\f刷新令牌*\fRefreshToken\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/refresh-token\x12|\n" +
+	"\n" +
+	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"?\x92A(\n" +
+	"\f用户管理\x12\f创建用户*\n" +
+	"CreateUser\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12\x8b\x01\n" +
+	"\n" +
+	"UpdateUser\x12\x15.v1.UpdateUserRequest\x1a\x16.v1.UpdateUserResponse\"N\x92A.\n" +
+	"\f用户管理\x12\x12更新用户信息*\n" +
+	"UpdateUser\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/users/{userID}\x12\xa5\x01\n" +
+	"\x0eChangePassword\x12\x19.v1.ChangePasswordRequest\x1a\x1a.v1.ChangePasswordResponse\"\\\x92A,\n" +
+	"\f用户管理\x12\f修改密码*\x0eChangePassword\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/users/{userID}/change-password\x12\x82\x01\n" +
+	"\n" +
+	"DeleteUser\x12\x15.v1.DeleteUserRequest\x1a\x16.v1.DeleteUserResponse\"E\x92A(\n" +
+	"\f用户管理\x12\f删除用户*\n" +
+	"DeleteUser\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userID}\x12|\n" +
+	"\aGetUser\x12\x12.v1.GetUserRequest\x1a\x13.v1.GetUserResponse\"H\x92A+\n" +
+	"\f用户管理\x12\x12获取用户信息*\aGetUser\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userID}\x12u\n" +
+	"\tListUsers\x12\x14.v1.ListUsersRequest\x1a\x15.v1.ListUsersResponse\";\x92A'\n" +
+	"\f用户管理\x12\f列出用户*\tListUsers\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12|\n" +
+	"\n" +
+	"CreatePost\x12\x15.v1.CreatePostRequest\x1a\x16.v1.CreatePostResponse\"?\x92A(\n" +
+	"\f博客管理\x12\f创建文章*\n" +
+	"CreatePost\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/posts\x12\x85\x01\n" +
+	"\n" +
+	"UpdatePost\x12\x15.v1.UpdatePostRequest\x1a\x16.v1.UpdatePostResponse\"H\x92A(\n" +
+	"\f博客管理\x12\f更新文章*\n" +
+	"UpdatePost\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/posts/{postID}\x12\x86\x01\n" +
+	"\vDeletePosts\x12\x16.v1.DeletePostsRequest\x1a\x17.v1.DeletePostsResponse\"F\x92A/\n" +
+	"\f博客管理\x12\x12批量删除文章*\vDeletePosts\x82\xd3\xe4\x93\x02\x0e:\x01**\t/v1/posts\x12|\n" +
+	"\aGetPost\x12\x12.v1.GetPostRequest\x1a\x13.v1.GetPostResponse\"H\x92A+\n" +
+	"\f博客管理\x12\x12获取文章信息*\aGetPost\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/posts/{postID}\x12q\n" +
+	"\bListPost\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\":\x92A&\n" +
+	"\f博客管理\x12\f列出文章*\bListPost\x82\xd3\xe4\x93\x02\v\x12\t/v1/posts\x12\x8a\x01\n" +
+	"\vSearchPosts\x12\x16.v1.SearchPostsRequest\x1a\x17.v1.SearchPostsResponse\"J\x92A/\n" +
+	"\f博客管理\x12\x12全文检索文章*\vSearchPosts\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/search/posts\x12\x91\x01\n" +
+	"\vPublishPost\x12\x16.v1.PublishPostRequest\x1a\x17.v1.PublishPostResponse\"Q\x92A)\n" +
+	"\f博客管理\x12\f发布文章*\vPublishPost\x82\xd3\xe4\x93\x02\x1f:\x01*\x1a\x1a/v1/posts/{postID}/publish\x12\x9b\x01\n" +
+	"\rUnpublishPost\x12\x18.v1.UnpublishPostRequest\x1a\x19.v1.UnpublishPostResponse\"U\x92A+\n" +
+	"\f博客管理\x12\f撤回文章*\rUnpublishPost\x82\xd3\xe4\x93\x02!:\x01*\x1a\x1c/v1/posts/{postID}/unpublish\x12\xb7\x01\n" +
+	"\x11ListPostRevisions\x12\x1c.v1.ListPostRevisionsRequest\x1a\x1d.v1.ListPostRevisionsResponse\"e\x92A>\n" +
+	"\f博客管理\x12\x1b列出文章的修订历史*\x11ListPostRevisions\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/posts/{postID}/revisions\x12\xba\x01\n" +
+	"\x0fGetPostRevision\x12\x1a.v1.GetPostRevisionRequest\x1a\x1b.v1.GetPostRevisionResponse\"n\x92A<\n" +
+	"\f博客管理\x12\x1b获取文章的某个修订*\x0fGetPostRevision\x82\xd3\xe4\x93\x02)\x12'/v1/posts/{postID}/revisions/{revision}\x12\x9e\x01\n" +
+	"\n" +
+	"RevertPost\x12\x15.v1.RevertPostRequest\x1a\x16.v1.RevertPostResponse\"a\x92A:\n" +
+	"\f博客管理\x12\x1e将文章回滚到某个修订*\n" +
+	"RevertPost\x82\xd3\xe4\x93\x02\x1e:\x01*\x1a\x19/v1/posts/{postID}/revert\x12\xb2\x01\n" +
+	"\x11DiffPostRevisions\x12\x1c.v1.DiffPostRevisionsRequest\x1a\x1d.v1.DiffPostRevisionsResponse\"`\x92A>\n" +
+	"\f博客管理\x12\x1b比较文章的两个修订*\x11DiffPostRevisions\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/posts/{postID}/diff\x12\x9a\x01\n" +
+	"\rCreateComment\x12\x18.v1.CreateCommentRequest\x1a\x19.v1.CreateCommentResponse\"T\x92A+\n" +
+	"\f评论管理\x12\f评论文章*\rCreateComment\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/posts/{postID}/comments\x12\x9c\x01\n" +
+	"\fListComments\x12\x17.v1.ListCommentsRequest\x1a\x18.v1.ListCommentsResponse\"Y\x92A3\n" +
+	"\f评论管理\x12\x15列出文章的评论*\fListComments\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/posts/{postID}/comments\x12\xa6\x01\n" +
+	"\rUpdateComment\x12\x18.v1.UpdateCommentRequest\x1a\x19.v1.UpdateCommentResponse\"`\x92A+\n" +
+	"\f评论管理\x12\f修改评论*\rUpdateComment\x82\xd3\xe4\x93\x02,:\x01*\x1a'/v1/posts/{postID}/comments/{commentID}\x12\xa3\x01\n" +
+	"\rDeleteComment\x12\x18.v1.DeleteCommentRequest\x1a\x19.v1.DeleteCommentResponse\"]\x92A+\n" +
+	"\f评论管理\x12\f删除评论*\rDeleteComment\x82\xd3\xe4\x93\x02)*'/v1/posts/{postID}/comments/{commentID}\x12p\n" +
+	"\bListTags\x12\x13.v1.ListTagsRequest\x1a\x14.v1.ListTagsResponse\"9\x92A&\n" +
+	"\f标签管理\x12\f列出标签*\bListTags\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/tags\x12\xa6\x01\n" +
+	"\x10ListDeletedPosts\x12\x1b.v1.ListDeletedPostsRequest\x1a\x1c.v1.ListDeletedPostsResponse\"W\x92A=\n" +
+	"\f博客管理\x12\x1b列出回收站中的文章*\x10ListDeletedPosts\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/trash/posts\x12\xa6\x01\n" +
+	"\vRestorePost\x12\x16.v1.RestorePostRequest\x1a\x17.v1.RestorePostResponse\"f\x92A8\n" +
+	"\f博客管理\x12\x1b恢复回收站中的文章*\vRestorePost\x82\xd3\xe4\x93\x02%:\x01*\x1a /v1/trash/posts/{postID}/restore\x12\x99\x01\n" +
+	"\tPurgePost\x12\x14.v1.PurgePostRequest\x1a\x15.v1.PurgePostResponse\"_\x92A<\n" +
+	"\f博客管理\x12!永久删除回收站中的文章*\tPurgePost\x82\xd3\xe4\x93\x02\x1a*\x18/v1/trash/posts/{postID}\x12\x82\x01\n" +
+	"\bLikePost\x12\x13.v1.LikePostRequest\x1a\x14.v1.LikePostResponse\"K\x92A&\n" +
+	"\f互动管理\x12\f点赞文章*\bLikePost\x82\xd3\xe4\x93\x02\x1c:\x01*\x1a\x17/v1/posts/{postID}/like\x12\x8d\x01\n" +
+	"\n" +
+	"UnlikePost\x12\x15.v1.UnlikePostRequest\x1a\x16.v1.UnlikePostResponse\"P\x92A.\n" +
+	"\f互动管理\x12\x12取消点赞文章*\n" +
+	"UnlikePost\x82\xd3\xe4\x93\x02\x19*\x17/v1/posts/{postID}/like\x12\x96\x01\n" +
+	"\fBookmarkPost\x12\x17.v1.BookmarkPostRequest\x1a\x18.v1.BookmarkPostResponse\"S\x92A*\n" +
+	"\f互动管理\x12\f收藏文章*\fBookmarkPost\x82\xd3\xe4\x93\x02 :\x01*\x1a\x1b/v1/posts/{postID}/bookmark\x12\xa1\x01\n" +
+	"\x0eUnbookmarkPost\x12\x19.v1.UnbookmarkPostRequest\x1a\x1a.v1.UnbookmarkPostResponse\"X\x92A2\n" +
+	"\f互动管理\x12\x12取消收藏文章*\x0eUnbookmarkPost\x82\xd3\xe4\x93\x02\x1d*\x1b/v1/posts/{postID}/bookmark\x12\x92\x01\n" +
+	"\rListBookmarks\x12\x18.v1.ListBookmarksRequest\x1a\x19.v1.ListBookmarksResponse\"L\x92A4\n" +
+	"\f互动管理\x12\x15列出收藏的文章*\rListBookmarks\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/bookmarksB1Z/github.com/wshadm/miniblog/pkg/api/server/v1;v1b\x06proto3"
 
 var file_apiserver_v1_apiserver_proto_goTypes = []any{
-	(*emptypb.Empty)(nil),  // 0: google.protobuf.Empty
-	(*HealthResponse)(nil), // 1: v1.HealthResponse
+	(*emptypb.Empty)(nil),             // 0: google.protobuf.Empty
+	(*LoginRequest)(nil),              // 1: v1.LoginRequest
+	(*RefreshTokenRequest)(nil),       // 2: v1.RefreshTokenRequest
+	(*CreateUserRequest)(nil),         // 3: v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),         // 4: v1.UpdateUserRequest
+	(*ChangePasswordRequest)(nil),     // 5: v1.ChangePasswordRequest
+	(*DeleteUserRequest)(nil),         // 6: v1.DeleteUserRequest
+	(*GetUserRequest)(nil),            // 7: v1.GetUserRequest
+	(*ListUsersRequest)(nil),          // 8: v1.ListUsersRequest
+	(*CreatePostRequest)(nil),         // 9: v1.CreatePostRequest
+	(*UpdatePostRequest)(nil),         // 10: v1.UpdatePostRequest
+	(*DeletePostsRequest)(nil),        // 11: v1.DeletePostsRequest
+	(*GetPostRequest)(nil),            // 12: v1.GetPostRequest
+	(*ListPostRequest)(nil),           // 13: v1.ListPostRequest
+	(*SearchPostsRequest)(nil),        // 14: v1.SearchPostsRequest
+	(*PublishPostRequest)(nil),        // 15: v1.PublishPostRequest
+	(*UnpublishPostRequest)(nil),      // 16: v1.UnpublishPostRequest
+	(*ListPostRevisionsRequest)(nil),  // 17: v1.ListPostRevisionsRequest
+	(*GetPostRevisionRequest)(nil),    // 18: v1.GetPostRevisionRequest
+	(*RevertPostRequest)(nil),         // 19: v1.RevertPostRequest
+	(*DiffPostRevisionsRequest)(nil),  // 20: v1.DiffPostRevisionsRequest
+	(*CreateCommentRequest)(nil),      // 21: v1.CreateCommentRequest
+	(*ListCommentsRequest)(nil),       // 22: v1.ListCommentsRequest
+	(*UpdateCommentRequest)(nil),      // 23: v1.UpdateCommentRequest
+	(*DeleteCommentRequest)(nil),      // 24: v1.DeleteCommentRequest
+	(*ListTagsRequest)(nil),           // 25: v1.ListTagsRequest
+	(*ListDeletedPostsRequest)(nil),   // 26: v1.ListDeletedPostsRequest
+	(*RestorePostRequest)(nil),        // 27: v1.RestorePostRequest
+	(*PurgePostRequest)(nil),          // 28: v1.PurgePostRequest
+	(*LikePostRequest)(nil),           // 29: v1.LikePostRequest
+	(*UnlikePostRequest)(nil),         // 30: v1.UnlikePostRequest
+	(*BookmarkPostRequest)(nil),       // 31: v1.BookmarkPostRequest
+	(*UnbookmarkPostRequest)(nil),     // 32: v1.UnbookmarkPostRequest
+	(*ListBookmarksRequest)(nil),      // 33: v1.ListBookmarksRequest
+	(*HealthResponse)(nil),            // 34: v1.HealthResponse
+	(*LoginResponse)(nil),             // 35: v1.LoginResponse
+	(*RefreshTokenResponse)(nil),      // 36: v1.RefreshTokenResponse
+	(*CreateUserResponse)(nil),        // 37: v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),        // 38: v1.UpdateUserResponse
+	(*ChangePasswordResponse)(nil),    // 39: v1.ChangePasswordResponse
+	(*DeleteUserResponse)(nil),        // 40: v1.DeleteUserResponse
+	(*GetUserResponse)(nil),           // 41: v1.GetUserResponse
+	(*ListUsersResponse)(nil),         // 42: v1.ListUsersResponse
+	(*CreatePostResponse)(nil),        // 43: v1.CreatePostResponse
+	(*UpdatePostResponse)(nil),        // 44: v1.UpdatePostResponse
+	(*DeletePostsResponse)(nil),       // 45: v1.DeletePostsResponse
+	(*GetPostResponse)(nil),           // 46: v1.GetPostResponse
+	(*ListPostResponse)(nil),          // 47: v1.ListPostResponse
+	(*SearchPostsResponse)(nil),       // 48: v1.SearchPostsResponse
+	(*PublishPostResponse)(nil),       // 49: v1.PublishPostResponse
+	(*UnpublishPostResponse)(nil),     // 50: v1.UnpublishPostResponse
+	(*ListPostRevisionsResponse)(nil), // 51: v1.ListPostRevisionsResponse
+	(*GetPostRevisionResponse)(nil),   // 52: v1.GetPostRevisionResponse
+	(*RevertPostResponse)(nil),        // 53: v1.RevertPostResponse
+	(*DiffPostRevisionsResponse)(nil), // 54: v1.DiffPostRevisionsResponse
+	(*CreateCommentResponse)(nil),     // 55: v1.CreateCommentResponse
+	(*ListCommentsResponse)(nil),      // 56: v1.ListCommentsResponse
+	(*UpdateCommentResponse)(nil),     // 57: v1.UpdateCommentResponse
+	(*DeleteCommentResponse)(nil),     // 58: v1.DeleteCommentResponse
+	(*ListTagsResponse)(nil),          // 59: v1.ListTagsResponse
+	(*ListDeletedPostsResponse)(nil),  // 60: v1.ListDeletedPostsResponse
+	(*RestorePostResponse)(nil),       // 61: v1.RestorePostResponse
+	(*PurgePostResponse)(nil),         // 62: v1.PurgePostResponse
+	(*LikePostResponse)(nil),          // 63: v1.LikePostResponse
+	(*UnlikePostResponse)(nil),        // 64: v1.UnlikePostResponse
+	(*BookmarkPostResponse)(nil),      // 65: v1.BookmarkPostResponse
+	(*UnbookmarkPostResponse)(nil),    // 66: v1.UnbookmarkPostResponse
+	(*ListBookmarksResponse)(nil),     // 67: v1.ListBookmarksResponse
 }
 var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
-	0, // 0: v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
-	1, // 1: v1.MiniBlog.Healthz:output_type -> v1.HealthResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
+	1,  // 1: v1.MiniBlog.Login:input_type -> v1.LoginRequest
+	2,  // 2: v1.MiniBlog.RefreshToken:input_type -> v1.RefreshTokenRequest
+	3,  // 3: v1.MiniBlog.CreateUser:input_type -> v1.CreateUserRequest
+	4,  // 4: v1.MiniBlog.UpdateUser:input_type -> v1.UpdateUserRequest
+	5,  // 5: v1.MiniBlog.ChangePassword:input_type -> v1.ChangePasswordRequest
+	6,  // 6: v1.MiniBlog.DeleteUser:input_type -> v1.DeleteUserRequest
+	7,  // 7: v1.MiniBlog.GetUser:input_type -> v1.GetUserRequest
+	8,  // 8: v1.MiniBlog.ListUsers:input_type -> v1.ListUsersRequest
+	9,  // 9: v1.MiniBlog.CreatePost:input_type -> v1.CreatePostRequest
+	10, // 10: v1.MiniBlog.UpdatePost:input_type -> v1.UpdatePostRequest
+	11, // 11: v1.MiniBlog.DeletePosts:input_type -> v1.DeletePostsRequest
+	12, // 12: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
+	13, // 13: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
+	14, // 14: v1.MiniBlog.SearchPosts:input_type -> v1.SearchPostsRequest
+	15, // 15: v1.MiniBlog.PublishPost:input_type -> v1.PublishPostRequest
+	16, // 16: v1.MiniBlog.UnpublishPost:input_type -> v1.UnpublishPostRequest
+	17, // 17: v1.MiniBlog.ListPostRevisions:input_type -> v1.ListPostRevisionsRequest
+	18, // 18: v1.MiniBlog.GetPostRevision:input_type -> v1.GetPostRevisionRequest
+	19, // 19: v1.MiniBlog.RevertPost:input_type -> v1.RevertPostRequest
+	20, // 20: v1.MiniBlog.DiffPostRevisions:input_type -> v1.DiffPostRevisionsRequest
+	21, // 21: v1.MiniBlog.CreateComment:input_type -> v1.CreateCommentRequest
+	22, // 22: v1.MiniBlog.ListComments:input_type -> v1.ListCommentsRequest
+	23, // 23: v1.MiniBlog.UpdateComment:input_type -> v1.UpdateCommentRequest
+	24, // 24: v1.MiniBlog.DeleteComment:input_type -> v1.DeleteCommentRequest
+	25, // 25: v1.MiniBlog.ListTags:input_type -> v1.ListTagsRequest
+	26, // 26: v1.MiniBlog.ListDeletedPosts:input_type -> v1.ListDeletedPostsRequest
+	27, // 27: v1.MiniBlog.RestorePost:input_type -> v1.RestorePostRequest
+	28, // 28: v1.MiniBlog.PurgePost:input_type -> v1.PurgePostRequest
+	29, // 29: v1.MiniBlog.LikePost:input_type -> v1.LikePostRequest
+	30, // 30: v1.MiniBlog.UnlikePost:input_type -> v1.UnlikePostRequest
+	31, // 31: v1.MiniBlog.BookmarkPost:input_type -> v1.BookmarkPostRequest
+	32, // 32: v1.MiniBlog.UnbookmarkPost:input_type -> v1.UnbookmarkPostRequest
+	33, // 33: v1.MiniBlog.ListBookmarks:input_type -> v1.ListBookmarksRequest
+	34, // 34: v1.MiniBlog.Healthz:output_type -> v1.HealthResponse
+	35, // 35: v1.MiniBlog.Login:output_type -> v1.LoginResponse
+	36, // 36: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
+	37, // 37: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
+	38, // 38: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
+	39, // 39: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
+	40, // 40: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
+	41, // 41: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
+	42, // 42: v1.MiniBlog.ListUsers:output_type -> v1.ListUsersResponse
+	43, // 43: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
+	44, // 44: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
+	45, // 45: v1.MiniBlog.DeletePosts:output_type -> v1.DeletePostsResponse
+	46, // 46: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
+	47, // 47: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
+	48, // 48: v1.MiniBlog.SearchPosts:output_type -> v1.SearchPostsResponse
+	49, // 49: v1.MiniBlog.PublishPost:output_type -> v1.PublishPostResponse
+	50, // 50: v1.MiniBlog.UnpublishPost:output_type -> v1.UnpublishPostResponse
+	51, // 51: v1.MiniBlog.ListPostRevisions:output_type -> v1.ListPostRevisionsResponse
+	52, // 52: v1.MiniBlog.GetPostRevision:output_type -> v1.GetPostRevisionResponse
+	53, // 53: v1.MiniBlog.RevertPost:output_type -> v1.RevertPostResponse
+	54, // 54: v1.MiniBlog.DiffPostRevisions:output_type -> v1.DiffPostRevisionsResponse
+	55, // 55: v1.MiniBlog.CreateComment:output_type -> v1.CreateCommentResponse
+	56, // 56: v1.MiniBlog.ListComments:output_type -> v1.ListCommentsResponse
+	57, // 57: v1.MiniBlog.UpdateComment:output_type -> v1.UpdateCommentResponse
+	58, // 58: v1.MiniBlog.DeleteComment:output_type -> v1.DeleteCommentResponse
+	59, // 59: v1.MiniBlog.ListTags:output_type -> v1.ListTagsResponse
+	60, // 60: v1.MiniBlog.ListDeletedPosts:output_type -> v1.ListDeletedPostsResponse
+	61, // 61: v1.MiniBlog.RestorePost:output_type -> v1.RestorePostResponse
+	62, // 62: v1.MiniBlog.PurgePost:output_type -> v1.PurgePostResponse
+	63, // 63: v1.MiniBlog.LikePost:output_type -> v1.LikePostResponse
+	64, // 64: v1.MiniBlog.UnlikePost:output_type -> v1.UnlikePostResponse
+	65, // 65: v1.MiniBlog.BookmarkPost:output_type -> v1.BookmarkPostResponse
+	66, // 66: v1.MiniBlog.UnbookmarkPost:output_type -> v1.UnbookmarkPostResponse
+	67, // 67: v1.MiniBlog.ListBookmarks:output_type -> v1.ListBookmarksResponse
+	34, // [34:68] is the sub-list for method output_type
+	0,  // [0:34] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_apiserver_proto_init() }
@@ -53,6 +266,11 @@ func file_apiserver_v1_apiserver_proto_init() {
 		return
 	}
 	file_apiserver_v1_healthz_proto_init()
+	file_apiserver_v1_user_proto_init()
+	file_apiserver_v1_post_proto_init()
+	file_apiserver_v1_comment_proto_init()
+	file_apiserver_v1_tag_proto_init()
+	file_apiserver_v1_engagement_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
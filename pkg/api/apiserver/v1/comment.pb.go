@@ -0,0 +1,674 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: apiserver/v1/comment.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Comment 表示文章的评论
+type Comment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// commentID 表示评论 ID
+	CommentID string `protobuf:"bytes,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	// postID 表示评论所属的文章 ID
+	PostID string `protobuf:"bytes,2,opt,name=postID,proto3" json:"postID,omitempty"`
+	// userID 表示评论者的用户 ID
+	UserID string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	// parentID 表示回复的评论 ID，顶层评论为空
+	ParentID string `protobuf:"bytes,4,opt,name=parentID,proto3" json:"parentID,omitempty"`
+	// content 表示评论内容
+	Content string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	// createdAt 表示评论创建时间
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// updatedAt 表示评论最后修改时间
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// replies 表示顶层评论下的全部回复，按创建时间正序排列，通过 parentID 组成回复树；回复本身不包含该字段
+	Replies       []*Comment `protobuf:"bytes,8,rep,name=replies,proto3" json:"replies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Comment) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+func (x *Comment) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *Comment) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Comment) GetParentID() string {
+	if x != nil {
+		return x.ParentID
+	}
+	return ""
+}
+
+func (x *Comment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetReplies() []*Comment {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+// CreateCommentRequest 表示创建评论请求
+type CreateCommentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要评论的文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// parentID 表示要回复的评论 ID，为空时创建顶层评论
+	ParentID string `protobuf:"bytes,2,opt,name=parentID,proto3" json:"parentID,omitempty"`
+	// content 表示评论内容
+	Content       string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentRequest) Reset() {
+	*x = CreateCommentRequest{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentRequest) ProtoMessage() {}
+
+func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateCommentRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetParentID() string {
+	if x != nil {
+		return x.ParentID
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// CreateCommentResponse 表示创建评论响应
+type CreateCommentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// commentID 表示创建的评论 ID
+	CommentID     string `protobuf:"bytes,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentResponse) Reset() {
+	*x = CreateCommentResponse{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentResponse) ProtoMessage() {}
+
+func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentResponse.ProtoReflect.Descriptor instead.
+func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateCommentResponse) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+// ListCommentsRequest 表示列出文章评论请求，按顶层评论分页
+type ListCommentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// offset 表示偏移量
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 表示每页的顶层评论数量
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// pageToken 表示上一页响应中的 nextPageToken，设置后按游标分页，忽略 offset
+	PageToken     string `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCommentsRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListCommentsResponse 表示列出文章评论响应
+type ListCommentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示顶层评论总数，使用 pageToken 时为当前页及之后的顶层评论数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// comments 表示顶层评论列表，按创建时间倒序排列，每条顶层评论包含其全部回复
+	Comments []*Comment `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
+	// nextPageToken 表示获取下一页时使用的 pageToken，为空表示没有更多数据
+	NextPageToken string `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCommentsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// UpdateCommentRequest 表示修改评论请求，只有评论者本人可以修改评论
+type UpdateCommentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示评论所属的文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// commentID 表示要修改的评论 ID
+	CommentID string `protobuf:"bytes,2,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	// content 表示新的评论内容
+	Content       string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCommentRequest) Reset() {
+	*x = UpdateCommentRequest{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCommentRequest) ProtoMessage() {}
+
+func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCommentRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *UpdateCommentRequest) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+func (x *UpdateCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// UpdateCommentResponse 表示修改评论响应
+type UpdateCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCommentResponse) Reset() {
+	*x = UpdateCommentResponse{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCommentResponse) ProtoMessage() {}
+
+func (x *UpdateCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCommentResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCommentResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{6}
+}
+
+// DeleteCommentRequest 表示删除评论请求，评论者本人和文章作者都可以删除评论，评论的回复会一并删除
+type DeleteCommentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示评论所属的文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// commentID 表示要删除的评论 ID
+	CommentID     string `protobuf:"bytes,2,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCommentRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *DeleteCommentRequest) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+// DeleteCommentResponse 表示删除评论响应
+type DeleteCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentResponse) Reset() {
+	*x = DeleteCommentResponse{}
+	mi := &file_apiserver_v1_comment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentResponse) ProtoMessage() {}
+
+func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_comment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_comment_proto_rawDescGZIP(), []int{8}
+}
+
+var File_apiserver_v1_comment_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_comment_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapiserver/v1/comment.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x02\n" +
+	"\aComment\x12\x1c\n" +
+	"\tcommentID\x18\x01 \x01(\tR\tcommentID\x12\x16\n" +
+	"\x06postID\x18\x02 \x01(\tR\x06postID\x12\x16\n" +
+	"\x06userID\x18\x03 \x01(\tR\x06userID\x12\x1a\n" +
+	"\bparentID\x18\x04 \x01(\tR\bparentID\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
+	"\areplies\x18\b \x03(\v2\v.v1.CommentR\areplies\"d\n" +
+	"\x14CreateCommentRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x1a\n" +
+	"\bparentID\x18\x02 \x01(\tR\bparentID\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"5\n" +
+	"\x15CreateCommentResponse\x12\x1c\n" +
+	"\tcommentID\x18\x01 \x01(\tR\tcommentID\"y\n" +
+	"\x13ListCommentsRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x1c\n" +
+	"\tpageToken\x18\x04 \x01(\tR\tpageToken\"\x85\x01\n" +
+	"\x14ListCommentsResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12'\n" +
+	"\bcomments\x18\x02 \x03(\v2\v.v1.CommentR\bcomments\x12$\n" +
+	"\rnextPageToken\x18\x03 \x01(\tR\rnextPageToken\"f\n" +
+	"\x14UpdateCommentRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x1c\n" +
+	"\tcommentID\x18\x02 \x01(\tR\tcommentID\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"\x17\n" +
+	"\x15UpdateCommentResponse\"L\n" +
+	"\x14DeleteCommentRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x1c\n" +
+	"\tcommentID\x18\x02 \x01(\tR\tcommentID\"\x17\n" +
+	"\x15DeleteCommentResponseB1Z/github.com/wshadm/miniblog/pkg/api/server/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_comment_proto_rawDescOnce sync.Once
+	file_apiserver_v1_comment_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_comment_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_comment_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_comment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_comment_proto_rawDesc), len(file_apiserver_v1_comment_proto_rawDesc)))
+	})
+	return file_apiserver_v1_comment_proto_rawDescData
+}
+
+var file_apiserver_v1_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_apiserver_v1_comment_proto_goTypes = []any{
+	(*Comment)(nil),               // 0: v1.Comment
+	(*CreateCommentRequest)(nil),  // 1: v1.CreateCommentRequest
+	(*CreateCommentResponse)(nil), // 2: v1.CreateCommentResponse
+	(*ListCommentsRequest)(nil),   // 3: v1.ListCommentsRequest
+	(*ListCommentsResponse)(nil),  // 4: v1.ListCommentsResponse
+	(*UpdateCommentRequest)(nil),  // 5: v1.UpdateCommentRequest
+	(*UpdateCommentResponse)(nil), // 6: v1.UpdateCommentResponse
+	(*DeleteCommentRequest)(nil),  // 7: v1.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil), // 8: v1.DeleteCommentResponse
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_apiserver_v1_comment_proto_depIdxs = []int32{
+	9, // 0: v1.Comment.createdAt:type_name -> google.protobuf.Timestamp
+	9, // 1: v1.Comment.updatedAt:type_name -> google.protobuf.Timestamp
+	0, // 2: v1.Comment.replies:type_name -> v1.Comment
+	0, // 3: v1.ListCommentsResponse.comments:type_name -> v1.Comment
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_comment_proto_init() }
+func file_apiserver_v1_comment_proto_init() {
+	if File_apiserver_v1_comment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_comment_proto_rawDesc), len(file_apiserver_v1_comment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_comment_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_comment_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_comment_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_comment_proto = out.File
+	file_apiserver_v1_comment_proto_goTypes = nil
+	file_apiserver_v1_comment_proto_depIdxs = nil
+}
@@ -0,0 +1,643 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: apiserver/v1/engagement.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LikePostRequest 表示点赞文章请求
+type LikePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要点赞的文章 ID
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikePostRequest) Reset() {
+	*x = LikePostRequest{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikePostRequest) ProtoMessage() {}
+
+func (x *LikePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikePostRequest.ProtoReflect.Descriptor instead.
+func (*LikePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LikePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// LikePostResponse 表示点赞文章响应
+type LikePostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// likeCount 表示文章当前的点赞数
+	LikeCount     int64 `protobuf:"varint,1,opt,name=likeCount,proto3" json:"likeCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikePostResponse) Reset() {
+	*x = LikePostResponse{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikePostResponse) ProtoMessage() {}
+
+func (x *LikePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikePostResponse.ProtoReflect.Descriptor instead.
+func (*LikePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LikePostResponse) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+// UnlikePostRequest 表示取消点赞文章请求
+type UnlikePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要取消点赞的文章 ID
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlikePostRequest) Reset() {
+	*x = UnlikePostRequest{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlikePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlikePostRequest) ProtoMessage() {}
+
+func (x *UnlikePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlikePostRequest.ProtoReflect.Descriptor instead.
+func (*UnlikePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UnlikePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// UnlikePostResponse 表示取消点赞文章响应
+type UnlikePostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// likeCount 表示文章当前的点赞数
+	LikeCount     int64 `protobuf:"varint,1,opt,name=likeCount,proto3" json:"likeCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlikePostResponse) Reset() {
+	*x = UnlikePostResponse{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlikePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlikePostResponse) ProtoMessage() {}
+
+func (x *UnlikePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlikePostResponse.ProtoReflect.Descriptor instead.
+func (*UnlikePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UnlikePostResponse) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+// BookmarkPostRequest 表示收藏文章请求
+type BookmarkPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要收藏的文章 ID
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookmarkPostRequest) Reset() {
+	*x = BookmarkPostRequest{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookmarkPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookmarkPostRequest) ProtoMessage() {}
+
+func (x *BookmarkPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookmarkPostRequest.ProtoReflect.Descriptor instead.
+func (*BookmarkPostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BookmarkPostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// BookmarkPostResponse 表示收藏文章响应
+type BookmarkPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookmarkPostResponse) Reset() {
+	*x = BookmarkPostResponse{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookmarkPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookmarkPostResponse) ProtoMessage() {}
+
+func (x *BookmarkPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookmarkPostResponse.ProtoReflect.Descriptor instead.
+func (*BookmarkPostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{5}
+}
+
+// UnbookmarkPostRequest 表示取消收藏文章请求
+type UnbookmarkPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要取消收藏的文章 ID
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbookmarkPostRequest) Reset() {
+	*x = UnbookmarkPostRequest{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbookmarkPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbookmarkPostRequest) ProtoMessage() {}
+
+func (x *UnbookmarkPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbookmarkPostRequest.ProtoReflect.Descriptor instead.
+func (*UnbookmarkPostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UnbookmarkPostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// UnbookmarkPostResponse 表示取消收藏文章响应
+type UnbookmarkPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbookmarkPostResponse) Reset() {
+	*x = UnbookmarkPostResponse{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbookmarkPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbookmarkPostResponse) ProtoMessage() {}
+
+func (x *UnbookmarkPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbookmarkPostResponse.ProtoReflect.Descriptor instead.
+func (*UnbookmarkPostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{7}
+}
+
+// Bookmark 表示当前用户收藏的一篇文章
+type Bookmark struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示收藏的文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// post 表示收藏的文章，文章已被删除或撤回时为空
+	Post *Post `protobuf:"bytes,2,opt,name=post,proto3" json:"post,omitempty"`
+	// createdAt 表示收藏时间
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bookmark) Reset() {
+	*x = Bookmark{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bookmark) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bookmark) ProtoMessage() {}
+
+func (x *Bookmark) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bookmark.ProtoReflect.Descriptor instead.
+func (*Bookmark) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Bookmark) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *Bookmark) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *Bookmark) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ListBookmarksRequest 表示列出当前用户收藏的文章请求
+type ListBookmarksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 表示每页数量
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookmarksRequest) Reset() {
+	*x = ListBookmarksRequest{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookmarksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookmarksRequest) ProtoMessage() {}
+
+func (x *ListBookmarksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookmarksRequest.ProtoReflect.Descriptor instead.
+func (*ListBookmarksRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListBookmarksRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListBookmarksRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListBookmarksResponse 表示列出当前用户收藏的文章响应
+type ListBookmarksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示收藏总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// bookmarks 表示收藏列表，按收藏时间倒序排列
+	Bookmarks     []*Bookmark `protobuf:"bytes,2,rep,name=bookmarks,proto3" json:"bookmarks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookmarksResponse) Reset() {
+	*x = ListBookmarksResponse{}
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookmarksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookmarksResponse) ProtoMessage() {}
+
+func (x *ListBookmarksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_engagement_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookmarksResponse.ProtoReflect.Descriptor instead.
+func (*ListBookmarksResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_engagement_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListBookmarksResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListBookmarksResponse) GetBookmarks() []*Bookmark {
+	if x != nil {
+		return x.Bookmarks
+	}
+	return nil
+}
+
+var File_apiserver_v1_engagement_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_engagement_proto_rawDesc = "" +
+	"\n" +
+	"\x1dapiserver/v1/engagement.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17apiserver/v1/post.proto\")\n" +
+	"\x0fLikePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"0\n" +
+	"\x10LikePostResponse\x12\x1c\n" +
+	"\tlikeCount\x18\x01 \x01(\x03R\tlikeCount\"+\n" +
+	"\x11UnlikePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"2\n" +
+	"\x12UnlikePostResponse\x12\x1c\n" +
+	"\tlikeCount\x18\x01 \x01(\x03R\tlikeCount\"-\n" +
+	"\x13BookmarkPostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\x16\n" +
+	"\x14BookmarkPostResponse\"/\n" +
+	"\x15UnbookmarkPostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\x18\n" +
+	"\x16UnbookmarkPostResponse\"z\n" +
+	"\bBookmark\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x1c\n" +
+	"\x04post\x18\x02 \x01(\v2\b.v1.PostR\x04post\x128\n" +
+	"\tcreatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"D\n" +
+	"\x14ListBookmarksRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"c\n" +
+	"\x15ListBookmarksResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12*\n" +
+	"\tbookmarks\x18\x02 \x03(\v2\f.v1.BookmarkR\tbookmarksB1Z/github.com/wshadm/miniblog/pkg/api/server/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_engagement_proto_rawDescOnce sync.Once
+	file_apiserver_v1_engagement_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_engagement_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_engagement_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_engagement_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_engagement_proto_rawDesc), len(file_apiserver_v1_engagement_proto_rawDesc)))
+	})
+	return file_apiserver_v1_engagement_proto_rawDescData
+}
+
+var file_apiserver_v1_engagement_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_apiserver_v1_engagement_proto_goTypes = []any{
+	(*LikePostRequest)(nil),        // 0: v1.LikePostRequest
+	(*LikePostResponse)(nil),       // 1: v1.LikePostResponse
+	(*UnlikePostRequest)(nil),      // 2: v1.UnlikePostRequest
+	(*UnlikePostResponse)(nil),     // 3: v1.UnlikePostResponse
+	(*BookmarkPostRequest)(nil),    // 4: v1.BookmarkPostRequest
+	(*BookmarkPostResponse)(nil),   // 5: v1.BookmarkPostResponse
+	(*UnbookmarkPostRequest)(nil),  // 6: v1.UnbookmarkPostRequest
+	(*UnbookmarkPostResponse)(nil), // 7: v1.UnbookmarkPostResponse
+	(*Bookmark)(nil),               // 8: v1.Bookmark
+	(*ListBookmarksRequest)(nil),   // 9: v1.ListBookmarksRequest
+	(*ListBookmarksResponse)(nil),  // 10: v1.ListBookmarksResponse
+	(*Post)(nil),                   // 11: v1.Post
+	(*timestamppb.Timestamp)(nil),  // 12: google.protobuf.Timestamp
+}
+var file_apiserver_v1_engagement_proto_depIdxs = []int32{
+	11, // 0: v1.Bookmark.post:type_name -> v1.Post
+	12, // 1: v1.Bookmark.createdAt:type_name -> google.protobuf.Timestamp
+	8,  // 2: v1.ListBookmarksResponse.bookmarks:type_name -> v1.Bookmark
+	3,  // [3:3] is the sub-list for method output_type
+	3,  // [3:3] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_engagement_proto_init() }
+func file_apiserver_v1_engagement_proto_init() {
+	if File_apiserver_v1_engagement_proto != nil {
+		return
+	}
+	file_apiserver_v1_post_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_engagement_proto_rawDesc), len(file_apiserver_v1_engagement_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_engagement_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_engagement_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_engagement_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_engagement_proto = out.File
+	file_apiserver_v1_engagement_proto_goTypes = nil
+	file_apiserver_v1_engagement_proto_depIdxs = nil
+}
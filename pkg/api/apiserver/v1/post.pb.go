@@ -0,0 +1,2310 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: apiserver/v1/post.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Post 表示博客文章
+type Post struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示博文 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// userID 表示用户 ID
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// title 表示博客标题
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// content 表示博客内容
+	Content string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// createdAt 表示博客创建时间
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// updatedAt 表示博客最后更新时间
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// etag 表示博客的当前版本，每次更新都会变化，更新时通过 etag 或 If-Match 头进行并发控制
+	Etag string `protobuf:"bytes,7,opt,name=etag,proto3" json:"etag,omitempty"`
+	// deletedAt 表示博客被删除的时间，只有回收站中的博客才会设置
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	// status 表示博客状态，可选值为 draft、scheduled、published 和 archived，只有 published 的博客对匿名用户可见
+	Status string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	// publishAt 表示博客的发布时间，定时发布的博客为计划发布时间
+	PublishAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	// tags 表示博客的标签，按标签名排列
+	Tags []string `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	// category 表示博客的分类，未分类时为空
+	Category string `protobuf:"bytes,12,opt,name=category,proto3" json:"category,omitempty"`
+	// likeCount 表示博客的点赞数
+	LikeCount int64 `protobuf:"varint,13,opt,name=likeCount,proto3" json:"likeCount,omitempty"`
+	// viewCount 表示博客的浏览数，最近的浏览可能需要几秒后才会计入
+	ViewCount     int64 `protobuf:"varint,14,opt,name=viewCount,proto3" json:"viewCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Post) Reset() {
+	*x = Post{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Post) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Post) ProtoMessage() {}
+
+func (x *Post) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Post.ProtoReflect.Descriptor instead.
+func (*Post) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Post) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *Post) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Post) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Post) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Post) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Post) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Post) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *Post) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Post) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Post) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Post) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Post) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Post) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *Post) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+// CreatePostRequest 表示创建文章请求
+type CreatePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// title 表示博客标题
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// content 表示博客内容
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// status 表示博客的初始状态，可选值为 draft 和 published，为空时为 published.
+	// 设置了未来的 publishAt 时为 scheduled
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// publishAt 表示定时发布的时间，必须晚于当前时间
+	PublishAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	// tags 表示博客的标签，标签名会被转换为小写，不存在的标签会被自动创建
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// category 表示博客的分类，分类名会被转换为小写，不存在的分类会被自动创建
+	Category      string `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePostRequest) Reset() {
+	*x = CreatePostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePostRequest) ProtoMessage() {}
+
+func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePostRequest.ProtoReflect.Descriptor instead.
+func (*CreatePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreatePostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *CreatePostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreatePostRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+// CreatePostResponse 表示创建文章响应
+type CreatePostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示创建的文章 ID
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePostResponse) Reset() {
+	*x = CreatePostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePostResponse) ProtoMessage() {}
+
+func (x *CreatePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePostResponse.ProtoReflect.Descriptor instead.
+func (*CreatePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePostResponse) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// UpdatePostRequest 表示更新文章请求
+type UpdatePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要更新的文章 ID，对应 {postID}
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// title 表示更新后的博客标题
+	Title *string `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// content 表示更新后的博客内容
+	Content *string `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	// updateMask 表示要更新的字段，支持 title、content、tags 和 category. 为空时更新请求中设置了的字段，
+	// 不为空时只更新其中的字段，未设置值的字段会被更新为空值
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
+	// etag 表示读取博客时返回的 etag，设置后只有博客未被修改时才会更新，也可以通过 If-Match 头设置
+	Etag string `protobuf:"bytes,5,opt,name=etag,proto3" json:"etag,omitempty"`
+	// tags 表示更新后的标签，会替换博客原有的标签，元素必须是字符串. 使用 ListValue 以区分未设置和空列表，
+	// JSON 中仍然是字符串数组，设置为 [] 时清空标签
+	Tags *structpb.ListValue `protobuf:"bytes,6,opt,name=tags,proto3" json:"tags,omitempty"`
+	// category 表示更新后的分类，为空字符串时取消分类
+	Category      *string `protobuf:"bytes,7,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePostRequest) Reset() {
+	*x = UpdatePostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePostRequest) ProtoMessage() {}
+
+func (x *UpdatePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePostRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdatePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdatePostRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetTags() *structpb.ListValue {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdatePostRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+// UpdatePostResponse 表示更新文章响应
+type UpdatePostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// etag 表示更新后博客的 etag，可用于下一次更新
+	Etag          string `protobuf:"bytes,1,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePostResponse) Reset() {
+	*x = UpdatePostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePostResponse) ProtoMessage() {}
+
+func (x *UpdatePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePostResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdatePostResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// DeletePostsRequest 表示批量删除文章请求，删除的文章会被移入回收站
+type DeletePostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postIDs 表示要删除的文章 ID 列表
+	PostIDs       []string `protobuf:"bytes,1,rep,name=postIDs,proto3" json:"postIDs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePostsRequest) Reset() {
+	*x = DeletePostsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostsRequest) ProtoMessage() {}
+
+func (x *DeletePostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostsRequest.ProtoReflect.Descriptor instead.
+func (*DeletePostsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeletePostsRequest) GetPostIDs() []string {
+	if x != nil {
+		return x.PostIDs
+	}
+	return nil
+}
+
+// DeletePostsResponse 表示批量删除文章响应
+type DeletePostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePostsResponse) Reset() {
+	*x = DeletePostsResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostsResponse) ProtoMessage() {}
+
+func (x *DeletePostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostsResponse.ProtoReflect.Descriptor instead.
+func (*DeletePostsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{6}
+}
+
+// GetPostRequest 表示获取文章请求
+type GetPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要获取的文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// readMask 表示要返回的文章字段，为空时返回全部字段
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=readMask,proto3" json:"readMask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostRequest) Reset() {
+	*x = GetPostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRequest) ProtoMessage() {}
+
+func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
+func (*GetPostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *GetPostRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+// GetPostResponse 表示获取文章响应
+type GetPostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// post 表示返回的文章信息
+	Post          *Post `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostResponse) Reset() {
+	*x = GetPostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostResponse) ProtoMessage() {}
+
+func (x *GetPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostResponse.ProtoReflect.Descriptor instead.
+func (*GetPostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPostResponse) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+// ListPostRequest 表示获取文章列表请求
+type ListPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 表示每页数量
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// title 表示可选的标题过滤，按标题模糊匹配
+	Title *string `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// pageToken 表示上一页响应中的 nextPageToken，设置后按游标分页，忽略 offset
+	PageToken string `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// filter 表示 AIP-160 风格的过滤表达式，例如 title:"go" AND createdAt > "2026-01-01"，
+	// 支持的字段为 postID、userID、title、content、status、publishAt、likeCount、viewCount、createdAt 和 updatedAt.
+	// 匿名用户只能查询到已发布的博客
+	Filter string `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	// orderBy 表示排序字段，例如 "title, createdAt desc"，支持的字段与 filter 相同，不能与 pageToken 同时使用
+	OrderBy string `protobuf:"bytes,6,opt,name=orderBy,proto3" json:"orderBy,omitempty"`
+	// readMask 表示要返回的文章字段，为空时返回全部字段
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=readMask,proto3" json:"readMask,omitempty"`
+	// tag 表示只列出带有该标签的文章
+	Tag string `protobuf:"bytes,8,opt,name=tag,proto3" json:"tag,omitempty"`
+	// category 表示只列出该分类下的文章
+	Category      string `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostRequest) Reset() {
+	*x = ListPostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostRequest) ProtoMessage() {}
+
+func (x *ListPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostRequest.ProtoReflect.Descriptor instead.
+func (*ListPostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListPostRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListPostRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPostRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *ListPostRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListPostRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListPostRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListPostRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListPostRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListPostRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+// ListPostResponse 表示获取文章列表响应
+type ListPostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示总文章数，使用 pageToken 时为当前页及之后的文章数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// posts 表示文章列表
+	Posts []*Post `protobuf:"bytes,2,rep,name=posts,proto3" json:"posts,omitempty"`
+	// nextPageToken 表示获取下一页时使用的 pageToken，为空表示没有更多数据
+	NextPageToken string `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostResponse) Reset() {
+	*x = ListPostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostResponse) ProtoMessage() {}
+
+func (x *ListPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostResponse.ProtoReflect.Descriptor instead.
+func (*ListPostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListPostResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListPostResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ListPostResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SearchPostsRequest 表示全文检索文章请求
+type SearchPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query 表示检索语句，多个词之间为或的关系，使用双引号检索短语，例如 "全文检索" golang，
+	// 可以使用 title:、content: 和 userID: 限定字段，使用 + 和 - 表示必须包含和必须不包含
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// offset 表示偏移量
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 表示每页数量
+	Limit         int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPostsRequest) Reset() {
+	*x = SearchPostsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPostsRequest) ProtoMessage() {}
+
+func (x *SearchPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPostsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPostsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchPostsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchPostsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SearchPostsResponse 表示全文检索文章响应
+type SearchPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示命中的文章总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// hits 表示按相关度倒序排列的检索结果
+	Hits          []*PostSearchHit `protobuf:"bytes,2,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPostsResponse) Reset() {
+	*x = SearchPostsResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPostsResponse) ProtoMessage() {}
+
+func (x *SearchPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPostsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPostsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchPostsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchPostsResponse) GetHits() []*PostSearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+// PostSearchHit 表示一条文章检索结果
+type PostSearchHit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// post 表示命中的文章
+	Post *Post `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	// score 表示相关度得分
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// titleHighlights 表示标题中命中的片段，命中的词使用 <mark></mark> 标记，其余内容经过 HTML 转义
+	TitleHighlights []string `protobuf:"bytes,3,rep,name=titleHighlights,proto3" json:"titleHighlights,omitempty"`
+	// contentHighlights 表示正文中命中的片段，格式与 titleHighlights 相同
+	ContentHighlights []string `protobuf:"bytes,4,rep,name=contentHighlights,proto3" json:"contentHighlights,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PostSearchHit) Reset() {
+	*x = PostSearchHit{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSearchHit) ProtoMessage() {}
+
+func (x *PostSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSearchHit.ProtoReflect.Descriptor instead.
+func (*PostSearchHit) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PostSearchHit) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *PostSearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PostSearchHit) GetTitleHighlights() []string {
+	if x != nil {
+		return x.TitleHighlights
+	}
+	return nil
+}
+
+func (x *PostSearchHit) GetContentHighlights() []string {
+	if x != nil {
+		return x.ContentHighlights
+	}
+	return nil
+}
+
+// ListDeletedPostsRequest 表示获取回收站文章列表请求
+type ListDeletedPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 表示每页数量
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedPostsRequest) Reset() {
+	*x = ListDeletedPostsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedPostsRequest) ProtoMessage() {}
+
+func (x *ListDeletedPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedPostsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListDeletedPostsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListDeletedPostsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListDeletedPostsResponse 表示获取回收站文章列表响应
+type ListDeletedPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示回收站中的总文章数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// posts 表示文章列表，按删除时间倒序排列
+	Posts         []*Post `protobuf:"bytes,2,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedPostsResponse) Reset() {
+	*x = ListDeletedPostsResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedPostsResponse) ProtoMessage() {}
+
+func (x *ListDeletedPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedPostsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedPostsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListDeletedPostsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListDeletedPostsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+// RestorePostRequest 表示从回收站恢复文章请求
+type RestorePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要恢复的文章 ID
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePostRequest) Reset() {
+	*x = RestorePostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePostRequest) ProtoMessage() {}
+
+func (x *RestorePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePostRequest.ProtoReflect.Descriptor instead.
+func (*RestorePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestorePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// RestorePostResponse 表示从回收站恢复文章响应
+type RestorePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePostResponse) Reset() {
+	*x = RestorePostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePostResponse) ProtoMessage() {}
+
+func (x *RestorePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePostResponse.ProtoReflect.Descriptor instead.
+func (*RestorePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{17}
+}
+
+// PurgePostRequest 表示永久删除回收站中文章的请求
+type PurgePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要永久删除的文章 ID
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgePostRequest) Reset() {
+	*x = PurgePostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgePostRequest) ProtoMessage() {}
+
+func (x *PurgePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgePostRequest.ProtoReflect.Descriptor instead.
+func (*PurgePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PurgePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// PurgePostResponse 表示永久删除回收站中文章的响应
+type PurgePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgePostResponse) Reset() {
+	*x = PurgePostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgePostResponse) ProtoMessage() {}
+
+func (x *PurgePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgePostResponse.ProtoReflect.Descriptor instead.
+func (*PurgePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{19}
+}
+
+// PublishPostRequest 表示发布文章请求
+type PublishPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要发布的文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// publishAt 表示定时发布的时间，为空或早于当前时间时立即发布
+	PublishAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	// etag 表示读取文章时返回的 etag，设置后只有文章未被修改时才会发布，也可以通过 If-Match 头设置
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishPostRequest) Reset() {
+	*x = PublishPostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishPostRequest) ProtoMessage() {}
+
+func (x *PublishPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishPostRequest.ProtoReflect.Descriptor instead.
+func (*PublishPostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PublishPostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *PublishPostRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *PublishPostRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// PublishPostResponse 表示发布文章响应
+type PublishPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishPostResponse) Reset() {
+	*x = PublishPostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishPostResponse) ProtoMessage() {}
+
+func (x *PublishPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishPostResponse.ProtoReflect.Descriptor instead.
+func (*PublishPostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{21}
+}
+
+// UnpublishPostRequest 表示撤回文章请求
+type UnpublishPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要撤回的文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// archive 为 true 时将文章归档，否则将文章恢复为草稿
+	Archive bool `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	// etag 表示读取文章时返回的 etag，设置后只有文章未被修改时才会撤回，也可以通过 If-Match 头设置
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishPostRequest) Reset() {
+	*x = UnpublishPostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishPostRequest) ProtoMessage() {}
+
+func (x *UnpublishPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishPostRequest.ProtoReflect.Descriptor instead.
+func (*UnpublishPostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UnpublishPostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *UnpublishPostRequest) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
+func (x *UnpublishPostRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// UnpublishPostResponse 表示撤回文章响应
+type UnpublishPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishPostResponse) Reset() {
+	*x = UnpublishPostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishPostResponse) ProtoMessage() {}
+
+func (x *UnpublishPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishPostResponse.ProtoReflect.Descriptor instead.
+func (*UnpublishPostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{23}
+}
+
+// PostRevision 表示文章的一次修订，即某次修改后文章标题和内容的快照
+type PostRevision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// revision 表示修订号，与修改后文章的 etag 一致。修改文章状态不会产生修订，因此修订号可能不连续
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// userID 表示修改文章的用户 ID
+	UserID string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	// title 表示文章标题
+	Title string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	// content 表示文章内容，列出修订时不返回
+	Content string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	// createdAt 表示修订创建时间
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRevision) Reset() {
+	*x = PostRevision{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRevision) ProtoMessage() {}
+
+func (x *PostRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRevision.ProtoReflect.Descriptor instead.
+func (*PostRevision) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PostRevision) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *PostRevision) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *PostRevision) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *PostRevision) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PostRevision) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PostRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ListPostRevisionsRequest 表示列出文章修订请求
+type ListPostRevisionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// offset 表示偏移量
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 表示每页数量
+	Limit         int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostRevisionsRequest) Reset() {
+	*x = ListPostRevisionsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostRevisionsRequest) ProtoMessage() {}
+
+func (x *ListPostRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListPostRevisionsRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *ListPostRevisionsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListPostRevisionsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListPostRevisionsResponse 表示列出文章修订响应，最新的修订在前
+type ListPostRevisionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// revisions 表示修订列表
+	Revisions     []*PostRevision `protobuf:"bytes,2,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostRevisionsResponse) Reset() {
+	*x = ListPostRevisionsResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostRevisionsResponse) ProtoMessage() {}
+
+func (x *ListPostRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPostRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListPostRevisionsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListPostRevisionsResponse) GetRevisions() []*PostRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// GetPostRevisionRequest 表示获取文章修订请求
+type GetPostRevisionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// revision 表示修订号
+	Revision      int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostRevisionRequest) Reset() {
+	*x = GetPostRevisionRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRevisionRequest) ProtoMessage() {}
+
+func (x *GetPostRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRevisionRequest.ProtoReflect.Descriptor instead.
+func (*GetPostRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetPostRevisionRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *GetPostRevisionRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// GetPostRevisionResponse 表示获取文章修订响应
+type GetPostRevisionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// revision 表示返回的修订
+	Revision      *PostRevision `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostRevisionResponse) Reset() {
+	*x = GetPostRevisionResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRevisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRevisionResponse) ProtoMessage() {}
+
+func (x *GetPostRevisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRevisionResponse.ProtoReflect.Descriptor instead.
+func (*GetPostRevisionResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetPostRevisionResponse) GetRevision() *PostRevision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+// RevertPostRequest 表示将文章回滚到某个修订的请求，回滚本身会产生一个新的修订
+type RevertPostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// revision 表示要回滚到的修订号
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// etag 表示读取文章时返回的 etag，设置后只有文章未被修改时才会回滚，也可以通过 If-Match 头设置
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertPostRequest) Reset() {
+	*x = RevertPostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertPostRequest) ProtoMessage() {}
+
+func (x *RevertPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertPostRequest.ProtoReflect.Descriptor instead.
+func (*RevertPostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RevertPostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *RevertPostRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RevertPostRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// RevertPostResponse 表示回滚文章响应
+type RevertPostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// revision 表示回滚后文章的修订号
+	Revision      int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertPostResponse) Reset() {
+	*x = RevertPostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertPostResponse) ProtoMessage() {}
+
+func (x *RevertPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertPostResponse.ProtoReflect.Descriptor instead.
+func (*RevertPostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RevertPostResponse) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// DiffPostRevisionsRequest 表示比较文章两个修订的请求
+type DiffPostRevisionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示文章 ID
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// from 表示旧的修订号，为 0 时使用 to 的上一个修订
+	From int64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to 表示新的修订号，为 0 时使用最新的修订
+	To            int64 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffPostRevisionsRequest) Reset() {
+	*x = DiffPostRevisionsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffPostRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffPostRevisionsRequest) ProtoMessage() {}
+
+func (x *DiffPostRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffPostRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffPostRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DiffPostRevisionsRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *DiffPostRevisionsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DiffPostRevisionsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// DiffPostRevisionsResponse 表示比较文章两个修订的响应
+type DiffPostRevisionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// from 表示旧的修订号，to 没有上一个修订时为 0
+	From int64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// to 表示新的修订号
+	To int64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	// diff 表示两个修订之间的 unified diff，比较的文本第一行为标题，空一行后为内容；没有差异时为空
+	Diff          string `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffPostRevisionsResponse) Reset() {
+	*x = DiffPostRevisionsResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffPostRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffPostRevisionsResponse) ProtoMessage() {}
+
+func (x *DiffPostRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffPostRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DiffPostRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DiffPostRevisionsResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DiffPostRevisionsResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *DiffPostRevisionsResponse) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+var File_apiserver_v1_post_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_post_proto_rawDesc = "" +
+	"\n" +
+	"\x17apiserver/v1/post.proto\x12\x02v1\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe6\x03\n" +
+	"\x04Post\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x128\n" +
+	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04etag\x18\a \x01(\tR\x04etag\x128\n" +
+	"\tdeletedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x128\n" +
+	"\tpublishAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\f \x01(\tR\bcategory\x12\x1c\n" +
+	"\tlikeCount\x18\r \x01(\x03R\tlikeCount\x12\x1c\n" +
+	"\tviewCount\x18\x0e \x01(\x03R\tviewCount\"\xc5\x01\n" +
+	"\x11CreatePostRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x128\n" +
+	"\tpublishAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\",\n" +
+	"\x12CreatePostResponse\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\xa9\x02\n" +
+	"\x11UpdatePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
+	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x12:\n" +
+	"\n" +
+	"updateMask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04etag\x18\x05 \x01(\tR\x04etag\x12.\n" +
+	"\x04tags\x18\x06 \x01(\v2\x1a.google.protobuf.ListValueR\x04tags\x12\x1f\n" +
+	"\bcategory\x18\a \x01(\tH\x02R\bcategory\x88\x01\x01B\b\n" +
+	"\x06_titleB\n" +
+	"\n" +
+	"\b_contentB\v\n" +
+	"\t_category\"(\n" +
+	"\x12UpdatePostResponse\x12\x12\n" +
+	"\x04etag\x18\x01 \x01(\tR\x04etag\".\n" +
+	"\x12DeletePostsRequest\x12\x18\n" +
+	"\apostIDs\x18\x01 \x03(\tR\apostIDs\"\x15\n" +
+	"\x13DeletePostsResponse\"`\n" +
+	"\x0eGetPostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x126\n" +
+	"\breadMask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"/\n" +
+	"\x0fGetPostResponse\x12\x1c\n" +
+	"\x04post\x18\x01 \x01(\v2\b.v1.PostR\x04post\"\x9a\x02\n" +
+	"\x0fListPostRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1c\n" +
+	"\tpageToken\x18\x04 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06filter\x18\x05 \x01(\tR\x06filter\x12\x18\n" +
+	"\aorderBy\x18\x06 \x01(\tR\aorderBy\x126\n" +
+	"\breadMask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x10\n" +
+	"\x03tag\x18\b \x01(\tR\x03tag\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategoryB\b\n" +
+	"\x06_title\"x\n" +
+	"\x10ListPostResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12\x1e\n" +
+	"\x05posts\x18\x02 \x03(\v2\b.v1.PostR\x05posts\x12$\n" +
+	"\rnextPageToken\x18\x03 \x01(\tR\rnextPageToken\"X\n" +
+	"\x12SearchPostsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"\\\n" +
+	"\x13SearchPostsResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12%\n" +
+	"\x04hits\x18\x02 \x03(\v2\x11.v1.PostSearchHitR\x04hits\"\x9b\x01\n" +
+	"\rPostSearchHit\x12\x1c\n" +
+	"\x04post\x18\x01 \x01(\v2\b.v1.PostR\x04post\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12(\n" +
+	"\x0ftitleHighlights\x18\x03 \x03(\tR\x0ftitleHighlights\x12,\n" +
+	"\x11contentHighlights\x18\x04 \x03(\tR\x11contentHighlights\"G\n" +
+	"\x17ListDeletedPostsRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"Z\n" +
+	"\x18ListDeletedPostsResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12\x1e\n" +
+	"\x05posts\x18\x02 \x03(\v2\b.v1.PostR\x05posts\",\n" +
+	"\x12RestorePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\x15\n" +
+	"\x13RestorePostResponse\"*\n" +
+	"\x10PurgePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\x13\n" +
+	"\x11PurgePostResponse\"z\n" +
+	"\x12PublishPostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x128\n" +
+	"\tpublishAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"\x15\n" +
+	"\x13PublishPostResponse\"\\\n" +
+	"\x14UnpublishPostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x18\n" +
+	"\aarchive\x18\x02 \x01(\bR\aarchive\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"\x17\n" +
+	"\x15UnpublishPostResponse\"\xc4\x01\n" +
+	"\fPostRevision\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12\x16\n" +
+	"\x06userID\x18\x03 \x01(\tR\x06userID\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"`\n" +
+	"\x18ListPostRevisionsRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"k\n" +
+	"\x19ListPostRevisionsResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12.\n" +
+	"\trevisions\x18\x02 \x03(\v2\x10.v1.PostRevisionR\trevisions\"L\n" +
+	"\x16GetPostRevisionRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\"G\n" +
+	"\x17GetPostRevisionResponse\x12,\n" +
+	"\brevision\x18\x01 \x01(\v2\x10.v1.PostRevisionR\brevision\"[\n" +
+	"\x11RevertPostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"0\n" +
+	"\x12RevertPostResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\"V\n" +
+	"\x18DiffPostRevisionsRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\"S\n" +
+	"\x19DiffPostRevisionsResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12\x12\n" +
+	"\x04diff\x18\x03 \x01(\tR\x04diffB1Z/github.com/wshadm/miniblog/pkg/api/server/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_post_proto_rawDescOnce sync.Once
+	file_apiserver_v1_post_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_post_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_post_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_post_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_post_proto_rawDesc), len(file_apiserver_v1_post_proto_rawDesc)))
+	})
+	return file_apiserver_v1_post_proto_rawDescData
+}
+
+var file_apiserver_v1_post_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_apiserver_v1_post_proto_goTypes = []any{
+	(*Post)(nil),                      // 0: v1.Post
+	(*CreatePostRequest)(nil),         // 1: v1.CreatePostRequest
+	(*CreatePostResponse)(nil),        // 2: v1.CreatePostResponse
+	(*UpdatePostRequest)(nil),         // 3: v1.UpdatePostRequest
+	(*UpdatePostResponse)(nil),        // 4: v1.UpdatePostResponse
+	(*DeletePostsRequest)(nil),        // 5: v1.DeletePostsRequest
+	(*DeletePostsResponse)(nil),       // 6: v1.DeletePostsResponse
+	(*GetPostRequest)(nil),            // 7: v1.GetPostRequest
+	(*GetPostResponse)(nil),           // 8: v1.GetPostResponse
+	(*ListPostRequest)(nil),           // 9: v1.ListPostRequest
+	(*ListPostResponse)(nil),          // 10: v1.ListPostResponse
+	(*SearchPostsRequest)(nil),        // 11: v1.SearchPostsRequest
+	(*SearchPostsResponse)(nil),       // 12: v1.SearchPostsResponse
+	(*PostSearchHit)(nil),             // 13: v1.PostSearchHit
+	(*ListDeletedPostsRequest)(nil),   // 14: v1.ListDeletedPostsRequest
+	(*ListDeletedPostsResponse)(nil),  // 15: v1.ListDeletedPostsResponse
+	(*RestorePostRequest)(nil),        // 16: v1.RestorePostRequest
+	(*RestorePostResponse)(nil),       // 17: v1.RestorePostResponse
+	(*PurgePostRequest)(nil),          // 18: v1.PurgePostRequest
+	(*PurgePostResponse)(nil),         // 19: v1.PurgePostResponse
+	(*PublishPostRequest)(nil),        // 20: v1.PublishPostRequest
+	(*PublishPostResponse)(nil),       // 21: v1.PublishPostResponse
+	(*UnpublishPostRequest)(nil),      // 22: v1.UnpublishPostRequest
+	(*UnpublishPostResponse)(nil),     // 23: v1.UnpublishPostResponse
+	(*PostRevision)(nil),              // 24: v1.PostRevision
+	(*ListPostRevisionsRequest)(nil),  // 25: v1.ListPostRevisionsRequest
+	(*ListPostRevisionsResponse)(nil), // 26: v1.ListPostRevisionsResponse
+	(*GetPostRevisionRequest)(nil),    // 27: v1.GetPostRevisionRequest
+	(*GetPostRevisionResponse)(nil),   // 28: v1.GetPostRevisionResponse
+	(*RevertPostRequest)(nil),         // 29: v1.RevertPostRequest
+	(*RevertPostResponse)(nil),        // 30: v1.RevertPostResponse
+	(*DiffPostRevisionsRequest)(nil),  // 31: v1.DiffPostRevisionsRequest
+	(*DiffPostRevisionsResponse)(nil), // 32: v1.DiffPostRevisionsResponse
+	(*timestamppb.Timestamp)(nil),     // 33: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),     // 34: google.protobuf.FieldMask
+	(*structpb.ListValue)(nil),        // 35: google.protobuf.ListValue
+}
+var file_apiserver_v1_post_proto_depIdxs = []int32{
+	33, // 0: v1.Post.createdAt:type_name -> google.protobuf.Timestamp
+	33, // 1: v1.Post.updatedAt:type_name -> google.protobuf.Timestamp
+	33, // 2: v1.Post.deletedAt:type_name -> google.protobuf.Timestamp
+	33, // 3: v1.Post.publishAt:type_name -> google.protobuf.Timestamp
+	33, // 4: v1.CreatePostRequest.publishAt:type_name -> google.protobuf.Timestamp
+	34, // 5: v1.UpdatePostRequest.updateMask:type_name -> google.protobuf.FieldMask
+	35, // 6: v1.UpdatePostRequest.tags:type_name -> google.protobuf.ListValue
+	34, // 7: v1.GetPostRequest.readMask:type_name -> google.protobuf.FieldMask
+	0,  // 8: v1.GetPostResponse.post:type_name -> v1.Post
+	34, // 9: v1.ListPostRequest.readMask:type_name -> google.protobuf.FieldMask
+	0,  // 10: v1.ListPostResponse.posts:type_name -> v1.Post
+	13, // 11: v1.SearchPostsResponse.hits:type_name -> v1.PostSearchHit
+	0,  // 12: v1.PostSearchHit.post:type_name -> v1.Post
+	0,  // 13: v1.ListDeletedPostsResponse.posts:type_name -> v1.Post
+	33, // 14: v1.PublishPostRequest.publishAt:type_name -> google.protobuf.Timestamp
+	33, // 15: v1.PostRevision.createdAt:type_name -> google.protobuf.Timestamp
+	24, // 16: v1.ListPostRevisionsResponse.revisions:type_name -> v1.PostRevision
+	24, // 17: v1.GetPostRevisionResponse.revision:type_name -> v1.PostRevision
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_post_proto_init() }
+func file_apiserver_v1_post_proto_init() {
+	if File_apiserver_v1_post_proto != nil {
+		return
+	}
+	file_apiserver_v1_post_proto_msgTypes[3].OneofWrappers = []any{}
+	file_apiserver_v1_post_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_post_proto_rawDesc), len(file_apiserver_v1_post_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   33,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_post_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_post_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_post_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_post_proto = out.File
+	file_apiserver_v1_post_proto_goTypes = nil
+	file_apiserver_v1_post_proto_depIdxs = nil
+}
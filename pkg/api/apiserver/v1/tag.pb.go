@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: apiserver/v1/tag.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Tag 表示标签及其已发布的文章数
+type Tag struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name 表示标签名
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// postCount 表示带有该标签的已发布文章数
+	PostCount     int64 `protobuf:"varint,2,opt,name=postCount,proto3" json:"postCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_apiserver_v1_tag_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tag_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetPostCount() int64 {
+	if x != nil {
+		return x.PostCount
+	}
+	return 0
+}
+
+// ListTagsRequest 表示列出标签请求
+type ListTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 表示每页数量
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_apiserver_v1_tag_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tag_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tag_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListTagsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListTagsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListTagsResponse 表示列出标签响应
+type ListTagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示有已发布文章的标签总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// tags 表示标签列表，按已发布文章数倒序、标签名升序排列
+	Tags          []*Tag `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_apiserver_v1_tag_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tag_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tag_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTagsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+var File_apiserver_v1_tag_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_tag_proto_rawDesc = "" +
+	"\n" +
+	"\x16apiserver/v1/tag.proto\x12\x02v1\"7\n" +
+	"\x03Tag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tpostCount\x18\x02 \x01(\x03R\tpostCount\"?\n" +
+	"\x0fListTagsRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"O\n" +
+	"\x10ListTagsResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12\x1b\n" +
+	"\x04tags\x18\x02 \x03(\v2\a.v1.TagR\x04tagsB1Z/github.com/wshadm/miniblog/pkg/api/server/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_tag_proto_rawDescOnce sync.Once
+	file_apiserver_v1_tag_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_tag_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_tag_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_tag_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_tag_proto_rawDesc), len(file_apiserver_v1_tag_proto_rawDesc)))
+	})
+	return file_apiserver_v1_tag_proto_rawDescData
+}
+
+var file_apiserver_v1_tag_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_apiserver_v1_tag_proto_goTypes = []any{
+	(*Tag)(nil),              // 0: v1.Tag
+	(*ListTagsRequest)(nil),  // 1: v1.ListTagsRequest
+	(*ListTagsResponse)(nil), // 2: v1.ListTagsResponse
+}
+var file_apiserver_v1_tag_proto_depIdxs = []int32{
+	0, // 0: v1.ListTagsResponse.tags:type_name -> v1.Tag
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_tag_proto_init() }
+func file_apiserver_v1_tag_proto_init() {
+	if File_apiserver_v1_tag_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_tag_proto_rawDesc), len(file_apiserver_v1_tag_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_tag_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_tag_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_tag_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_tag_proto = out.File
+	file_apiserver_v1_tag_proto_goTypes = nil
+	file_apiserver_v1_tag_proto_depIdxs = nil
+}
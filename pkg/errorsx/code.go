@@ -27,4 +27,7 @@ var (
 
 	// ErrOperationFailed 表示操作失败.
 	ErrOperationFailed = &ErrorX{Code: http.StatusConflict, Reason: "OperationFailed", Message: "The requested operation has failed. Please try again later."}
+
+	// ErrAborted 表示请求的前置条件不满足，例如资源已被其他请求修改. 对应的 gRPC 状态码为 Aborted.
+	ErrAborted = &ErrorX{Code: http.StatusPreconditionFailed, Reason: "Aborted", Message: "The operation was aborted because the resource has been modified."}
 )
\ No newline at end of file
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	httpstatus "github.com/go-kratos/kratos/v2/transport/http/status"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -37,36 +39,51 @@ func (e *ErrorX) Error() string {
 }
 
 // WithMessage 设置错误的 Message 字段.
+// 预定义错误是全局共享的，所以这里返回一个副本，避免并发请求之间互相覆盖.
 func (err *ErrorX) WithMessage(format string, args ...any) *ErrorX {
-	err.Message = fmt.Sprintf(format, args...)
-	return err
+	e := err.clone()
+	e.Message = fmt.Sprintf(format, args...)
+	return e
 }
 
 // WithMetadata 设置元数据.
-
 func (e *ErrorX) WithMetadata(md map[string]string) *ErrorX {
-	e.Metadata = md
-	return e
+	ne := e.clone()
+	ne.Metadata = md
+	return ne
 }
 
 // KV 使用 key-value 对设置元数据.
 func (e *ErrorX) KV(kvs ...string) *ErrorX {
-	if e.Metadata == nil {
-		e.Metadata = make(map[string]string) //如果为空将初始化一个map，避免空指针
+	ne := e.clone()
+	if ne.Metadata == nil {
+		ne.Metadata = make(map[string]string) //如果为空将初始化一个map，避免空指针
 	}
 	for i := 0; i < len(kvs); i += 2 {
 		//kvs必须是成对的
 		if i+1 < len(kvs) {
-			e.Metadata[kvs[i]] = kvs[i+1]
+			ne.Metadata[kvs[i]] = kvs[i+1]
 		}
 	}
-	return e
+	return ne
+}
+
+// clone 深度拷贝 ErrorX，Metadata 也会被拷贝.
+func (e *ErrorX) clone() *ErrorX {
+	ne := *e
+	if e.Metadata != nil {
+		ne.Metadata = make(map[string]string, len(e.Metadata))
+		for k, v := range e.Metadata {
+			ne.Metadata[k] = v
+		}
+	}
+	return &ne
 }
 
 // GRPCStatus 返回 gRPC 状态表示.
 func (e *ErrorX) GRPCStatus() *status.Status {
 	details := errdetails.ErrorInfo{Reason: e.Reason, Metadata: e.Metadata}
-	s, _ := status.New(httpstatus.ToGRPCCode(e.Code), e.Message).WithDetails(&details)
+	s, _ := status.New(grpcCode(e.Code), e.Message).WithDetails(&details)
 	return s
 }
 
@@ -117,18 +134,35 @@ func FromError(err error) *ErrorX {
 	// 则返回一个带有默认值的 ErrorX，表示是一个未知类型的错误.
 	gs, ok := status.FromError(err)
 	if !ok {
-		return New(ErrInternal.Code, ErrInternal.Reason, err.Error())
+		return New(ErrInternal.Code, ErrInternal.Reason, "%s", err.Error())
 	}
 	// 如果 err 是 gRPC 的错误类型，会成功返回一个 gRPC status 对象（gs）.
 	// 使用 gRPC 状态中的错误代码和消息创建一个 ErrorX.
-	ret := New(httpstatus.FromGRPCCode(gs.Code()), ErrInternal.Reason, gs.Message())
+	ret := New(httpstatus.FromGRPCCode(gs.Code()), ErrInternal.Reason, "%s", gs.Message())
 
 	// 遍历 gRPC 错误详情中的所有附加信息（Details）.
 	for _, detail := range gs.Details() {
 		if typed, ok := detail.(*errdetails.ErrorInfo); ok {
 			ret.Reason = typed.Reason
+			// Aborted 默认对应 409，原因为 Aborted 的错误还原为 412
+			if gs.Code() == codes.Aborted && isAborted(typed.Reason) {
+				ret.Code = ErrAborted.Code
+			}
 			return ret.WithMetadata(typed.Metadata)
 		}
 	}
 	return ret
 }
+
+// grpcCode 将 HTTP 状态码转换为 gRPC 状态码. 412 没有默认的对应关系，按 AIP-154 转换为 Aborted.
+func grpcCode(code int) codes.Code {
+	if code == http.StatusPreconditionFailed {
+		return codes.Aborted
+	}
+	return httpstatus.ToGRPCCode(code)
+}
+
+// isAborted 判断 reason 是否为 ErrAborted 或其细分的原因，例如 Aborted.EtagMismatch.
+func isAborted(reason string) bool {
+	return reason == ErrAborted.Reason || strings.HasPrefix(reason, ErrAborted.Reason+".")
+}
@@ -0,0 +1,95 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Supported database drivers.
+const (
+	DriverMySQL      = "mysql"
+	DriverPostgreSQL = "postgresql"
+	DriverSQLite     = "sqlite"
+)
+
+var availableDrivers = sets.New(DriverMySQL, DriverPostgreSQL, DriverSQLite)
+
+var _ IOptions = (*DBOptions)(nil)
+
+// DBOptions defines options for the database, the driver field selects which
+// of the database options is used.
+type DBOptions struct {
+	Driver     string             `json:"driver" mapstructure:"driver"`
+	MySQL      *MySQLOptions      `json:"mysql" mapstructure:"mysql"`
+	PostgreSQL *PostgreSQLOptions `json:"postgresql" mapstructure:"postgresql"`
+	SQLite     *SQLiteOptions     `json:"sqlite" mapstructure:"sqlite"`
+}
+
+// NewDBOptions create a `zero` value instance.
+func NewDBOptions() *DBOptions {
+	return &DBOptions{
+		Driver:     DriverMySQL,
+		MySQL:      NewMySQLOptions(),
+		PostgreSQL: NewPostgreSQLOptions(),
+		SQLite:     NewSQLiteOptions(),
+	}
+}
+
+// Validate verifies flags passed to DBOptions.
+func (o *DBOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Driver {
+	case DriverMySQL:
+		errs = append(errs, o.MySQL.Validate()...)
+	case DriverPostgreSQL:
+		errs = append(errs, o.PostgreSQL.Validate()...)
+	case DriverSQLite:
+		errs = append(errs, o.SQLite.Validate()...)
+	default:
+		errs = append(errs, fmt.Errorf("invalid db driver %q, available drivers: %v", o.Driver, sets.List(availableDrivers)))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to the database for a specific APIServer to the specified FlagSet.
+func (o *DBOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.StringVar(&o.Driver, join(prefixes...)+"db.driver", o.Driver, fmt.Sprintf(""+
+		"Database driver, available drivers: %v.", sets.List(availableDrivers)))
+
+	prefixes = append(prefixes, "db")
+	o.MySQL.AddFlags(fs, prefixes...)
+	o.PostgreSQL.AddFlags(fs, prefixes...)
+	o.SQLite.AddFlags(fs, prefixes...)
+}
+
+// Addr returns the address of the selected database, used for logging.
+func (o *DBOptions) Addr() string {
+	switch o.Driver {
+	case DriverMySQL:
+		return o.MySQL.Addr
+	case DriverPostgreSQL:
+		return o.PostgreSQL.Addr
+	case DriverSQLite:
+		return o.SQLite.Path
+	}
+	return ""
+}
+
+// NewDB create a database connection with the selected driver.
+func (o *DBOptions) NewDB() (*gorm.DB, error) {
+	switch o.Driver {
+	case DriverMySQL:
+		return o.MySQL.NewDB()
+	case DriverPostgreSQL:
+		return o.PostgreSQL.NewDB()
+	case DriverSQLite:
+		return o.SQLite.NewDB()
+	default:
+		return nil, fmt.Errorf("unsupported db driver %q", o.Driver)
+	}
+}
@@ -367,3 +367,43 @@ func (o *KafkaOptions) Writer() (*kafka.Writer, error) {
 	kafkaWriter := kafka.NewWriter(config)
 	return kafkaWriter, nil
 }
+
+// Reader creates a kafka reader for the given topic based on ReaderOptions.
+// An empty topic means the configured --kafka.topic.
+func (o *KafkaOptions) Reader(topic string) (*kafka.Reader, error) {
+	dialer, err := o.Dialer()
+	if err != nil {
+		return nil, err
+	}
+
+	if topic == "" {
+		topic = o.Topic
+	}
+
+	config := kafka.ReaderConfig{
+		Brokers:           o.Brokers,
+		GroupID:           o.ReaderOptions.GroupID,
+		Topic:             topic,
+		Partition:         o.ReaderOptions.Partition,
+		Dialer:            dialer,
+		QueueCapacity:     o.ReaderOptions.QueueCapacity,
+		MinBytes:          o.ReaderOptions.MinBytes,
+		MaxBytes:          o.ReaderOptions.MaxBytes,
+		MaxWait:           o.ReaderOptions.MaxWait,
+		ReadBatchTimeout:  o.ReaderOptions.ReadBatchTimeout,
+		HeartbeatInterval: o.ReaderOptions.HeartbeatInterval,
+		CommitInterval:    o.ReaderOptions.CommitInterval,
+		RebalanceTimeout:  o.ReaderOptions.RebalanceTimeout,
+		StartOffset:       o.ReaderOptions.StartOffset,
+		MaxAttempts:       o.ReaderOptions.MaxAttempts,
+		Logger:            &logger{4},
+		ErrorLogger:       &logger{1},
+	}
+
+	// kafka.NewReader panics on invalid config, so validate it first.
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return kafka.NewReader(config), nil
+}
@@ -0,0 +1,69 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/glebarez/sqlite"
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/onexstack/onexstack/pkg/log"
+)
+
+var _ IOptions = (*SQLiteOptions)(nil)
+
+// SQLiteOptions defines options for sqlite database.
+// SQLite is implemented in pure Go, so no external database is required.
+type SQLiteOptions struct {
+	// Path is the database file path. Use ":memory:" for an in-memory database.
+	Path     string `json:"path" mapstructure:"path"`
+	LogLevel int    `json:"log-level" mapstructure:"log-level"`
+}
+
+// NewSQLiteOptions create a `zero` value instance.
+func NewSQLiteOptions() *SQLiteOptions {
+	return &SQLiteOptions{
+		Path:     "miniblog.db",
+		LogLevel: 1, // Silent
+	}
+}
+
+// Validate verifies flags passed to SQLiteOptions.
+func (o *SQLiteOptions) Validate() []error {
+	errs := []error{}
+
+	if o.Path == "" {
+		errs = append(errs, fmt.Errorf("sqlite path cannot be empty"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to sqlite storage for a specific APIServer to the specified FlagSet.
+func (o *SQLiteOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.StringVar(&o.Path, join(prefixes...)+"sqlite.path", o.Path, ""+
+		"SQLite database file path. Use :memory: for an in-memory database.")
+	fs.IntVar(&o.LogLevel, join(prefixes...)+"sqlite.log-mode", o.LogLevel, ""+
+		"Specify gorm log level.")
+}
+
+// NewDB create sqlite store with the given config.
+func (o *SQLiteOptions) NewDB() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(o.Path), &gorm.Config{
+		Logger: log.Default().LogMode(gormlogger.LogLevel(o.LogLevel)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows only one writer at a time, and every connection to ":memory:"
+	// opens a separate database, so a single connection is used.
+	sqlDB.SetMaxOpenConns(1)
+
+	return db, nil
+}